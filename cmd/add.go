@@ -7,16 +7,19 @@ import (
 
 	"github.com/genesix/pkt/internal/ai"
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/genesix/pkt/internal/pm"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var (
-	devFlag    bool
-	allFlag    bool
-	aiFlag     bool
-	aiProvider string
+	devFlag      bool
+	allFlag      bool
+	aiFlag       bool
+	aiProvider   string
+	addFilter    string
+	addComponent string
 )
 
 var addCmd = &cobra.Command{
@@ -32,7 +35,9 @@ Examples:
   pkt add requests flask           # Python
   pkt add -D typescript eslint     # Dev dependencies
   pkt add .                        # Install all dependencies
-  pkt add -a                       # Install all dependencies`,
+  pkt add -a                       # Install all dependencies
+  pkt add --filter web axios       # Add to a workspace member
+  pkt add --component web axios    # Add to the web/ component of a polyglot project`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		isAll, _ := cmd.Flags().GetBool("all")
 		if !isAll && len(args) == 0 {
@@ -64,8 +69,31 @@ Examples:
 			return fmt.Errorf("not in a tracked project. Run this command inside a project folder")
 		}
 
+		// Target a workspace member instead of the current project
+		if addFilter != "" {
+			project, err = resolveWorkspaceMember(project, addFilter)
+			if err != nil {
+				return err
+			}
+			cwd = project.Path
+			fmt.Printf("Using workspace member %s (%s)\n", project.Name, utils.ShortPath(project.Path))
+		}
+
+		// Target another language of a polyglot project, e.g. its web/ frontend
+		language, managerName := project.Language, project.PackageManager
+		var component *db.Component
+		if addComponent != "" {
+			component, err = resolveComponent(project, addComponent)
+			if err != nil {
+				return err
+			}
+			language, managerName = component.Language, component.PackageManager
+			cwd = component.Dir(project.Path)
+			fmt.Printf("Using component %s (%s, %s)\n", component.Name, component.Path, managerName)
+		}
+
 		// Get package manager for this language
-		packageManager, err := pm.Get(project.Language, project.PackageManager)
+		packageManager, err := pm.Get(language, managerName)
 		if err != nil {
 			return err
 		}
@@ -77,7 +105,7 @@ Examples:
 			desc := strings.Join(packages, " ")
 
 			fmt.Println("🤖 Thinking...")
-			sysPrompt := fmt.Sprintf("You are a package-manager assistant for a %s project. The user wants to add a dependency based on their description. Return ONLY the exact space-separated module names to install. No explanation, no markdown backticks, no code blocks.", project.Language)
+			sysPrompt := fmt.Sprintf("You are a package-manager assistant for a %s project. The user wants to add a dependency based on their description. Return ONLY the exact space-separated module names to install. No explanation, no markdown backticks, no code blocks.", language)
 			resp, err := ai.AskAI(sysPrompt, desc, aiProvider)
 			if err != nil {
 				return fmt.Errorf("ai error: %w", err)
@@ -89,11 +117,11 @@ Examples:
 			}
 
 			packages = strings.Split(resp, " ")
-			fmt.Printf("🤖 AI suggests: \033[36m%s\033[0m\n\n", strings.Join(packages, " "))
+			fmt.Printf("🤖 AI suggests: %s\n\n", output.Color("36", strings.Join(packages, " ")))
 		}
 
 		if isAll {
-			fmt.Printf("📦 Installing dependencies using %s for %s project...\n", project.PackageManager, project.Language)
+			fmt.Printf("📦 Installing dependencies using %s for %s project...\n", managerName, language)
 			if err := packageManager.Install(cwd); err != nil {
 				return fmt.Errorf("failed to install dependencies: %w", err)
 			}
@@ -108,15 +136,16 @@ Examples:
 		}
 
 		// Sync dependencies to database for all languages
-		deps, err := utils.ParseDependencies(cwd, project.Language)
+		var count int
+		if component != nil {
+			count, err = utils.SyncComponentDependencies(project.ID, project.Path, component)
+		} else {
+			count, err = utils.SyncProjectDependencies(project.ID, cwd, project.Language)
+		}
 		if err != nil {
-			fmt.Printf("⚠️  Warning: failed to parse dependencies: %v\n", err)
-		} else if len(deps) > 0 {
-			if err := db.SyncDependencies(project.ID, deps); err != nil {
-				fmt.Printf("⚠️  Warning: failed to sync dependencies: %v\n", err)
-			} else if isAll {
-				fmt.Printf("✓ Synced %d dependencies to database\n", len(deps))
-			}
+			fmt.Printf("⚠️  Warning: %v\n", err)
+		} else if isAll && count > 0 {
+			fmt.Printf("✓ Synced %d dependencies to database\n", count)
 		}
 
 		if isAll {
@@ -139,4 +168,6 @@ func init() {
 	addCmd.Flags().BoolVarP(&allFlag, "all", "a", false, "Install all dependencies")
 	addCmd.Flags().BoolVarP(&aiFlag, "ai", "", false, "Use AI to determine and add dependencies based on description")
 	addCmd.Flags().StringVarP(&aiProvider, "provider", "p", "", "Specific AI Provider to use with --ai (openai, gemini, groq)")
+	addCmd.Flags().StringVarP(&addFilter, "filter", "F", "", "Add to a workspace member (name, directory or glob)")
+	addCmd.Flags().StringVarP(&addComponent, "component", "c", "", "Add to a component of a polyglot project")
 }
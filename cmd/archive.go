@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"github.com/genesix/pkt/internal/config"
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/pm"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	archiveFormat    string
+	unarchiveInstall bool
+	unarchiveKeep    bool
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive <project | id>...",
+	Short: "Compress dormant projects into tarballs",
+	Long: `Compress one or more projects into a tarball and remove their folder. Cache and
+build folders are left out using the same rules as 'pkt clean' (see
+'pkt config set-clean'); everything else, including .git, is kept.
+
+Archives are written to ~/.pkt/archive, or to archive_dir in the config, as
+.tar.zst when zstd is installed and .tar.gz otherwise. Archived projects stay
+tracked: they are shown by 'pkt list --archived' and their dependencies still
+appear in 'pkt deps who' and 'pkt deps drift'. Bring one back with
+'pkt unarchive <project>'.
+
+Workspace members are archived along with their workspace root.
+
+Examples:
+  pkt archive old-app
+  pkt archive old-app legacy-api --format gz --yes`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := archiveFormat
+		if format == "" {
+			format = utils.DefaultArchiveFormat()
+		}
+		if format != utils.ArchiveZstd && format != utils.ArchiveGzip {
+			return fmt.Errorf("unsupported archive format: %s (use %s or %s)", format, utils.ArchiveZstd, utils.ArchiveGzip)
+		}
+
+		var projects []*db.Project
+		for _, input := range args {
+			project, err := utils.ResolveProject(input)
+			if err != nil {
+				return fmt.Errorf("failed to resolve project '%s': %w", input, err)
+			}
+			if err := utils.CheckNotArchived(project); err != nil {
+				return err
+			}
+			if project.IsMember() {
+				return fmt.Errorf("%s is a workspace member; archive its workspace root instead", project.Name)
+			}
+			projects = append(projects, project)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		archiveDir, err := utils.ExpandPath(cfg.ArchiveDirectory())
+		if err != nil {
+			return err
+		}
+
+		msg := fmt.Sprintf("Archive '%s' to %s and remove its folder?", projects[0].Name, utils.ShortPath(archiveDir))
+		if len(projects) > 1 {
+			msg = fmt.Sprintf("Archive %d projects to %s and remove their folders?", len(projects), utils.ShortPath(archiveDir))
+		}
+		confirm, err := utils.Confirm(msg, false)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println("Archiving cancelled.")
+			return nil
+		}
+
+		if err := os.MkdirAll(archiveDir, 0755); err != nil {
+			return fmt.Errorf("failed to create archive directory: %w", err)
+		}
+
+		failed := 0
+		for _, project := range projects {
+			if err := archiveProject(cfg, project, archiveDir, format); err != nil {
+				fmt.Printf("❌ %s: %v\n", project.Name, err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d projects could not be archived", failed, len(projects))
+		}
+		return nil
+	},
+}
+
+// archiveProject writes a project without its cache folders to a tarball, marks it
+// archived and removes its folder
+func archiveProject(cfg *config.Config, project *db.Project, archiveDir, format string) error {
+	if info, err := os.Stat(project.Path); err != nil || !info.IsDir() {
+		return fmt.Errorf("folder %s not found (see 'pkt doctor projects')", utils.ShortPath(project.Path))
+	}
+
+	// Members are cleaned according to their own language
+	owners := []*db.Project{project}
+	members, err := db.GetWorkspaceMembers(project.ID)
+	if err != nil {
+		return err
+	}
+	owners = append(owners, members...)
+
+	var exclude []string
+	for _, owner := range owners {
+		dirs, err := projectCleanDirs(cfg, owner, "")
+		if err != nil {
+			return err
+		}
+		for _, dir := range dirs {
+			exclude = append(exclude, dir.Path)
+		}
+	}
+
+	fmt.Printf("📦 Archiving %s...\n", project.Name)
+	before, _ := utils.GetDirSize(project.Path)
+
+	dest := filepath.Join(archiveDir, fmt.Sprintf("%s-%s%s", filepath.Base(project.Path), project.ID, utils.ArchiveExt(format)))
+	if err := utils.WriteArchive(project.Path, dest, format, exclude); err != nil {
+		return err
+	}
+
+	if err := db.SetProjectArchive(project.ID, dest); err != nil {
+		_ = os.Remove(dest)
+		return err
+	}
+
+	if err := os.RemoveAll(project.Path); err != nil {
+		fmt.Printf("⚠️  Warning: archived, but failed to remove %s: %v\n", project.Path, err)
+	}
+
+	var after int64
+	if info, err := os.Stat(dest); err == nil {
+		after = info.Size()
+	}
+	fmt.Printf("✓ Archived %s to %s (%s → %s)\n", project.Name, utils.ShortPath(dest), humanize.Bytes(uint64(before)), humanize.Bytes(uint64(after)))
+	return nil
+}
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive <project | id>...",
+	Short: "Extract archived projects back into their folder",
+	Long: `Extract archived projects back into their original folder and remove the
+archive. Cache folders were left out when archiving; use --install to
+reinstall dependencies with the project's package manager.
+
+Examples:
+  pkt unarchive old-app
+  pkt unarchive old-app --install`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projects []*db.Project
+		for _, input := range args {
+			project, err := utils.ResolveProject(input)
+			if err != nil {
+				return fmt.Errorf("failed to resolve project '%s': %w", input, err)
+			}
+			if !project.IsArchived() {
+				return fmt.Errorf("%s is not archived", project.Name)
+			}
+			if project.IsMember() {
+				return fmt.Errorf("%s is a workspace member; unarchive its workspace root instead", project.Name)
+			}
+			projects = append(projects, project)
+		}
+
+		failed := 0
+		for _, project := range projects {
+			if err := unarchiveProject(project); err != nil {
+				fmt.Printf("❌ %s: %v\n", project.Name, err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d projects could not be unarchived", failed, len(projects))
+		}
+		return nil
+	},
+}
+
+// unarchiveProject extracts a project's archive into its folder and marks it unarchived
+func unarchiveProject(project *db.Project) error {
+	archive := project.ArchivePath
+	if _, err := os.Stat(archive); err != nil {
+		return fmt.Errorf("archive %s not found", utils.ShortPath(archive))
+	}
+
+	fmt.Printf("📦 Extracting %s...\n", project.Name)
+	if err := os.MkdirAll(filepath.Dir(project.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(project.Path), err)
+	}
+	if err := utils.ExtractArchive(archive, project.Path); err != nil {
+		return err
+	}
+
+	if err := db.SetProjectArchive(project.ID, ""); err != nil {
+		_ = os.RemoveAll(project.Path)
+		return err
+	}
+
+	if !unarchiveKeep {
+		if err := os.Remove(archive); err != nil {
+			fmt.Printf("⚠️  Warning: failed to remove %s: %v\n", archive, err)
+		}
+	}
+	fmt.Printf("✓ Unarchived %s to %s\n", project.Name, utils.ShortPath(project.Path))
+
+	if unarchiveInstall {
+		// The project is back either way, so a failed install is only a warning
+		manager, err := pm.Get(project.Language, project.PackageManager)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: cannot install dependencies: %v\n", err)
+			return nil
+		}
+		fmt.Printf("Installing dependencies with %s...\n", manager.Name())
+		if err := manager.Install(project.Path); err != nil {
+			fmt.Printf("⚠️  Warning: failed to install dependencies: %v\n", err)
+			return nil
+		}
+		fmt.Println("✓ Dependencies installed")
+	}
+	return nil
+}
+
+func init() {
+	archiveCmd.Flags().StringVar(&archiveFormat, "format", "", "Archive format: zst or gz (default: zst when zstd is installed)")
+	unarchiveCmd.Flags().BoolVar(&unarchiveInstall, "install", false, "Install dependencies after extracting")
+	unarchiveCmd.Flags().BoolVar(&unarchiveKeep, "keep", false, "Keep the archive after extracting")
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
+}
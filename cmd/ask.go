@@ -7,6 +7,7 @@ import (
 
 	"github.com/genesix/pkt/internal/ai"
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -42,7 +43,7 @@ var askCmd = &cobra.Command{
 			return fmt.Errorf("failed to Ask AI: %w", err)
 		}
 
-		fmt.Printf("\033[36m%s\033[0m\n\n", resp)
+		fmt.Printf("%s\n\n", output.Color("36", resp))
 		return nil
 	},
 }
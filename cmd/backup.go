@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Back up the config and database",
+	Long: `Write the config and a consistent snapshot of the database to a single
+.tar.gz file. The database is copied with SQLite's VACUUM INTO, so the backup
+is consistent even while other pkt commands are running.
+
+Without a file the backup is written to ~/.pkt/backups. Bring it back with
+'pkt restore <file>'.
+
+pkt also backs up automatically to ~/.pkt/backups before 'pkt delete',
+'pkt pm set' and 'pkt restore', keeping the 10 most recent of these backups,
+and before schema migrations, keeping the 10 most recent migration backups.
+
+Examples:
+  pkt backup
+  pkt backup ~/Dropbox/pkt-backup.tar.gz`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var dest string
+		if len(args) == 1 {
+			path, err := utils.ExpandPath(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", args[0], err)
+			}
+			dest = path
+			if !strings.HasSuffix(dest, utils.BackupExt) {
+				dest += utils.BackupExt
+			}
+		} else {
+			dir, err := db.BackupDir()
+			if err != nil {
+				return err
+			}
+			dest = filepath.Join(dir, fmt.Sprintf("pkt-%s%s", time.Now().Format("20060102-150405"), utils.BackupExt))
+		}
+
+		if err := utils.WriteBackup(dest); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Backed up config and database to %s\n", utils.ShortPath(dest))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
@@ -5,57 +5,101 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/dustin/go-humanize"
+	"github.com/genesix/pkt/internal/config"
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/lang"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/pm"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	cleanLangFilter string
+	cleanTagFilter  string
+	cleanComponent  string
+	cleanProjects   []string
+	cleanDryRun     bool
+	cleanOlderThan  string
+	cleanGlobal     bool
+	cleanJobs       int
+)
+
 var cleanCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Prune heavy project cache folders",
-	Long:  "Find and safely delete bulky cache/build folders (like node_modules, target, venv) across all tracked projects.",
+	Long: `Find and safely delete bulky cache/build folders (like node_modules, target, venv) across all tracked projects.
+
+Components of polyglot projects (e.g. a JavaScript frontend in web/) are cleaned
+according to their own language. Use --component to only clean components with
+that name.
+
+The folders pruned per language can be replaced in the config, e.g.
+'pkt config set-clean js node_modules .turbo'. Patterns are relative to the
+project and may use globs such as "packages/*/dist".
+
+With --older-than only projects without activity for that long are cleaned.
+Activity is the last git commit, or the newest file change outside dependency
+and build folders when the project has no commit.
+
+--global reports the size of caches shared by every project (Go build and
+module caches, the npm cache, the pnpm store, ~/.cargo/registry, ...) with the
+command that clears each; they are never deleted by pkt.
+
+Examples:
+  pkt clean --dry-run
+  pkt clean --older-than 30d
+  pkt clean --project web --project api
+  pkt clean -t archive --yes
+  pkt clean --global`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		projects, err := db.ListAllProjects()
+		if cleanGlobal {
+			return reportGlobalCaches()
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		var olderThan time.Duration
+		if cleanOlderThan != "" {
+			if olderThan, err = utils.ParseAge(cleanOlderThan); err != nil {
+				return err
+			}
+		}
+
+		projects, err := selectCleanProjects()
 		if err != nil {
 			return err
 		}
 
+		if olderThan > 0 {
+			projects = inactiveProjects(projects, olderThan)
+		}
+
 		fmt.Println("🧹 Scanning discrete cache folders... This may take a moment.")
 
 		type target struct {
-			ProjectName string
-			Path        string
-			Size        int64
+			cleanDir
+			Size int64
 		}
 
 		var targets []target
-		var totalSaved int64
+		seen := make(map[string]bool)
 
 		for _, p := range projects {
-			var dirs []string
-			lang := strings.ToLower(p.Language)
-			switch {
-			case strings.Contains(lang, "javascript") || strings.Contains(lang, "node"):
-				dirs = []string{"node_modules", "dist", "build", ".next"}
-			case strings.Contains(lang, "python"):
-				dirs = []string{"venv", ".venv", "__pycache__"}
-			case strings.Contains(lang, "rust"):
-				dirs = []string{"target"}
+			dirs, err := projectCleanDirs(cfg, p, cleanComponent)
+			if err != nil {
+				return err
 			}
-
-			for _, d := range dirs {
-				candidate := filepath.Join(p.Path, d)
-				if info, err := os.Stat(candidate); err == nil && info.IsDir() {
-					size, _ := utils.GetDirSize(candidate)
-					targets = append(targets, target{
-						ProjectName: p.Name,
-						Path:        candidate,
-						Size:        size,
-					})
-					totalSaved += size
+			for _, dir := range dirs {
+				if !seen[dir.Path] {
+					seen[dir.Path] = true
+					targets = append(targets, target{cleanDir: dir})
 				}
 			}
 		}
@@ -65,20 +109,29 @@ var cleanCmd = &cobra.Command{
 			return nil
 		}
 
+		sizes := utils.ParallelMap(targets, cleanJobs, func(t target) int64 {
+			size, _ := utils.GetDirSize(t.Path)
+			return size
+		})
+
+		var totalSize int64
 		fmt.Println("\nCache directories found:")
-		for _, t := range targets {
-			fmt.Printf("  • %s (%s): %s\n", t.ProjectName, filepath.Base(t.Path), humanize.Bytes(uint64(t.Size)))
+		for i := range targets {
+			targets[i].Size = sizes[i]
+			totalSize += sizes[i]
+			fmt.Printf("  • %s (%s): %s\n", targets[i].Owner, targets[i].Rel, humanize.Bytes(uint64(sizes[i])))
 		}
 
-		fmt.Printf("\nTotal recoverable space: \033[1;32m%s\033[0m\n", humanize.Bytes(uint64(totalSaved)))
+		fmt.Printf("\nTotal recoverable space: %s\n", output.Color("1;32", humanize.Bytes(uint64(totalSize))))
 
-		var confirm bool
-		prompt := &survey.Confirm{
-			Message: "Do you want to permanently delete these directories?",
-			Default: false,
+		if cleanDryRun {
+			fmt.Println("Dry run: nothing was deleted.")
+			return nil
 		}
-		if err := survey.AskOne(prompt, &confirm); err != nil {
-			return fmt.Errorf("cancelled: %w", err)
+
+		confirm, err := utils.Confirm("Do you want to permanently delete these directories?", false)
+		if err != nil {
+			return err
 		}
 
 		if !confirm {
@@ -87,21 +140,181 @@ var cleanCmd = &cobra.Command{
 		}
 
 		fmt.Println()
+		var totalSaved int64
 		for _, t := range targets {
 			fmt.Printf("Removing %s... ", t.Path)
 			if err := os.RemoveAll(t.Path); err != nil {
 				fmt.Printf("❌ Failed: %v\n", err)
 			} else {
 				fmt.Println("✓")
+				totalSaved += t.Size
 			}
 		}
 
-		fmt.Printf("\n✨ Successfully reclaimed \033[1;32m%s\033[0m of disk space!\n", humanize.Bytes(uint64(totalSaved)))
+		fmt.Printf("\n✨ Successfully reclaimed %s of disk space!\n", output.Color("1;32", humanize.Bytes(uint64(totalSaved))))
 
 		return nil
 	},
 }
 
+// selectCleanProjects returns the projects named by --project, or the root projects
+// matching --lang and --tag
+func selectCleanProjects() ([]*db.Project, error) {
+	if len(cleanProjects) == 0 {
+		return selectRootProjects(cleanLangFilter, cleanTagFilter)
+	}
+	if cleanLangFilter != "" || cleanTagFilter != "" {
+		return nil, fmt.Errorf("--project cannot be combined with --lang or --tag")
+	}
+
+	var projects []*db.Project
+	for _, name := range cleanProjects {
+		project, err := utils.ResolveProject(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.CheckNotArchived(project); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+// inactiveProjects returns the projects without activity for at least age
+func inactiveProjects(projects []*db.Project, age time.Duration) []*db.Project {
+	cutoff := time.Now().Add(-age)
+	active := utils.ParallelMap(projects, cleanJobs, func(p *db.Project) bool {
+		last, err := utils.LastActivity(p.Path)
+		return err != nil || last.After(cutoff)
+	})
+
+	var inactive []*db.Project
+	for i, p := range projects {
+		if !active[i] {
+			inactive = append(inactive, p)
+		}
+	}
+	if skipped := len(projects) - len(inactive); skipped > 0 {
+		fmt.Printf("Skipping %d project(s) active in the last %s\n", skipped, cleanOlderThan)
+	}
+	return inactive
+}
+
+// cleanDir is a cache folder found in a project or one of its components
+type cleanDir struct {
+	Owner string // Project name, or project/component
+	Path  string
+	Rel   string // Relative to the project or component
+}
+
+// projectCleanDirs returns the cache folders of a project and its components, each
+// cleaned according to its own language. A component name limits the search to
+// components with that name.
+func projectCleanDirs(cfg *config.Config, p *db.Project, component string) ([]cleanDir, error) {
+	type root struct {
+		name     string
+		dir      string
+		language string
+	}
+	var roots []root
+	if component == "" {
+		roots = append(roots, root{p.Name, p.Path, p.Language})
+	}
+	components, err := db.GetComponents(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range components {
+		if component == "" || c.Name == component {
+			roots = append(roots, root{p.Name + "/" + c.Name, c.Dir(p.Path), c.Language})
+		}
+	}
+
+	var dirs []cleanDir
+	for _, r := range roots {
+		for _, path := range matchCleanDirs(r.dir, cleanPatterns(cfg, r.language)) {
+			rel, _ := filepath.Rel(r.dir, path)
+			dirs = append(dirs, cleanDir{Owner: r.name, Path: path, Rel: rel})
+		}
+	}
+	return dirs, nil
+}
+
+// cleanPatterns returns the folders pruned for a language: the clean_patterns
+// configured for it, or the language's defaults
+func cleanPatterns(cfg *config.Config, language string) []string {
+	if patterns, ok := cfg.CleanPatterns[language]; ok {
+		return patterns
+	}
+	l, err := lang.Get(language)
+	if err != nil {
+		return nil
+	}
+	return l.CleanDirs()
+}
+
+// matchCleanDirs returns the directories inside dir matched by the patterns
+func matchCleanDirs(dir string, patterns []string) []string {
+	var dirs []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			output.Warnf("invalid clean pattern %q: %v", pattern, err)
+			continue
+		}
+		for _, match := range matches {
+			// Never prune the project itself or anything outside it
+			if rel, err := filepath.Rel(dir, match); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			if info, err := os.Lstat(match); err == nil && info.IsDir() {
+				dirs = append(dirs, match)
+			}
+		}
+	}
+	return dirs
+}
+
+// reportGlobalCaches lists the caches shared by every project with their size
+func reportGlobalCaches() error {
+	fmt.Println("🧹 Measuring shared caches... This may take a moment.")
+
+	caches := pm.GlobalCaches()
+	if len(caches) == 0 {
+		fmt.Println("No shared caches found.")
+		return nil
+	}
+
+	sizes := utils.ParallelMap(caches, cleanJobs, func(c pm.Cache) int64 {
+		size, _ := utils.GetDirSize(c.Path)
+		return size
+	})
+
+	fmt.Println()
+	var total int64
+	table := output.NewTable("CACHE", "SIZE", "PATH", "CLEAR WITH")
+	for i, c := range caches {
+		total += sizes[i]
+		table.Add(c.Name, humanize.Bytes(uint64(sizes[i])), utils.ShortPath(c.Path), c.Clean)
+	}
+	if err := table.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nTotal shared cache size: %s\n", output.Color("1;32", humanize.Bytes(uint64(total))))
+	fmt.Println("These caches are shared by every project, so pkt does not delete them.")
+	return nil
+}
+
 func init() {
+	cleanCmd.Flags().StringVarP(&cleanLangFilter, "lang", "l", "", "Filter by language (js, py, go, rs)")
+	cleanCmd.Flags().StringVarP(&cleanTagFilter, "tag", "t", "", "Filter by tag")
+	cleanCmd.Flags().StringVarP(&cleanComponent, "component", "c", "", "Only clean components with this name")
+	cleanCmd.Flags().StringArrayVar(&cleanProjects, "project", nil, "Only clean this project (repeatable)")
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Only list the folders that would be deleted")
+	cleanCmd.Flags().StringVar(&cleanOlderThan, "older-than", "", "Only clean projects without activity for this long (e.g. 30d, 2w)")
+	cleanCmd.Flags().BoolVar(&cleanGlobal, "global", false, "Report the size of caches shared by every project")
+	cleanCmd.Flags().IntVarP(&cleanJobs, "jobs", "j", 0, "Number of folders to measure concurrently (default: number of CPUs)")
 	rootCmd.AddCommand(cleanCmd)
 }
@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/genesix/pkt/internal/config"
-	"github.com/genesix/pkt/internal/db"
 	"github.com/genesix/pkt/internal/lang"
 	"github.com/genesix/pkt/internal/pm"
 	"github.com/genesix/pkt/internal/utils"
@@ -20,6 +19,7 @@ var (
 	cloneName    string
 	cloneInstall bool
 	cloneOpen    bool
+	cloneRoot    string
 )
 
 var cloneCmd = &cobra.Command{
@@ -27,14 +27,16 @@ var cloneCmd = &cobra.Command{
 	Short: "Clone a git repository and track it",
 	Long: `Clone a git repository into the pkt workspace and automatically track it.
 
-The repository will be cloned to the workspace directory, language will be
-auto-detected, and the project will be registered in the database.
+The repository will be cloned to the workspace directory (or the workspace
+root chosen with --root), language will be auto-detected, and the project will
+be registered in the database.
 
 Examples:
   pkt clone https://github.com/user/repo
   pkt clone git@github.com:user/repo.git
   pkt clone https://github.com/user/repo --name my-project
-  pkt clone https://github.com/user/repo --install`,
+  pkt clone https://github.com/user/repo --install
+  pkt clone https://github.com/user/repo --root oss`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		repoURL := args[0]
@@ -51,8 +53,12 @@ Examples:
 			projectName = extractRepoName(repoURL)
 		}
 
-		// Target directory
-		targetPath := filepath.Join(cfg.ProjectsRoot, projectName)
+		// Target directory inside the chosen workspace root
+		rootDir, err := workspaceRoot(cfg, cloneRoot)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(rootDir, projectName)
 
 		// Check if directory already exists
 		if _, err := os.Stat(targetPath); err == nil {
@@ -78,24 +84,11 @@ Examples:
 		// Detect package manager
 		packageManager := detectedLang.DetectPackageManager(targetPath)
 
-		// Generate project ID
-		projectID := utils.GenerateID()
-
-		// Create project in database
-		project, err := db.CreateProject(projectID, projectName, targetPath, detectedLang.Name(), packageManager)
+		tracked, err := trackProject(utils.GenerateID(), projectName, targetPath, detectedLang.Name(), packageManager)
 		if err != nil {
-			return fmt.Errorf("failed to register project: %w", err)
-		}
-
-		// Sync dependencies
-		deps, parseErr := utils.ParseDependencies(targetPath, detectedLang.Name())
-		if parseErr != nil {
-			fmt.Printf("⚠️  Warning: failed to parse dependencies: %v\n", parseErr)
-		} else if len(deps) > 0 {
-			if syncErr := db.SyncDependencies(project.ID, deps); syncErr != nil {
-				fmt.Printf("⚠️  Warning: failed to sync dependencies: %v\n", syncErr)
-			}
+			return err
 		}
+		project := tracked.Project
 
 		fmt.Println()
 		fmt.Printf("✓ Cloned and registered: %s\n", projectName)
@@ -103,6 +96,12 @@ Examples:
 		fmt.Printf("  Path: %s\n", project.Path)
 		fmt.Printf("  Language: %s\n", detectedLang.DisplayName())
 		fmt.Printf("  Package Manager: %s\n", project.PackageManager)
+		if len(tracked.Members) > 0 {
+			fmt.Printf("  Workspace tracked.Members: %d tracked\n", len(tracked.Members))
+		}
+		if len(tracked.Components) > 0 {
+			fmt.Printf("  Components: %s\n", componentSummary(tracked.Components))
+		}
 
 		// Optionally run install
 		if cloneInstall {
@@ -169,5 +168,6 @@ func init() {
 	cloneCmd.Flags().StringVarP(&cloneName, "name", "n", "", "Custom name for the project")
 	cloneCmd.Flags().BoolVarP(&cloneInstall, "install", "i", false, "Run install after cloning")
 	cloneCmd.Flags().BoolVarP(&cloneOpen, "open", "o", false, "Open project in editor after cloning")
+	cloneCmd.Flags().StringVarP(&cloneRoot, "root", "r", "", "Workspace root to clone into (see 'pkt config')")
 	rootCmd.AddCommand(cloneCmd)
 }
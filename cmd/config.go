@@ -2,9 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/genesix/pkt/internal/config"
+	"github.com/genesix/pkt/internal/lang"
+	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -16,15 +22,21 @@ var configCmd = &cobra.Command{
 Without arguments, displays current configuration.
 
 Available keys:
-  editor    - Editor command (e.g., code, cursor, vim)
-  pm        - Default package manager (pnpm, npm, bun)
-  ai        - Switch active AI provider
+  editor      - Editor command (e.g., code, cursor, vim)
+  pm          - Default package manager (pnpm, npm, bun)
+  ai          - Switch active AI provider
+  in_place    - Register projects where they are on 'pkt init' (true/false)
+  archive_dir - Where 'pkt archive' writes tarballs (default ~/.pkt/archive)
 
 Examples:
   pkt config                    # Show current config
   pkt config editor cursor      # Change editor to cursor
   pkt config pm npm             # Change default PM to npm
-  pkt config ai ollama          # Switch to Ollama (local)`,
+  pkt config ai ollama          # Switch to Ollama (local)
+  pkt config in_place true      # Never move projects on init
+  pkt config archive_dir /mnt/cold/pkt  # Keep archives on another disk
+  pkt config add-root oss ~/oss # Register another workspace root
+  pkt config set-clean js node_modules .turbo  # Folders pkt clean prunes`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load()
@@ -39,6 +51,23 @@ Examples:
 			fmt.Printf("  editor:        %s\n", cfg.EditorCommand)
 			fmt.Printf("  pm:            %s\n", cfg.DefaultPM)
 			fmt.Printf("  ai (active):   %s\n", cfg.AIProvider)
+			fmt.Printf("  in_place:      %t\n", cfg.InitInPlace)
+			fmt.Printf("  archive_dir:   %s\n", cfg.ArchiveDirectory())
+			if len(cfg.Roots) > 0 {
+				fmt.Println("\nWorkspace roots:")
+				for _, name := range cfg.RootNames() {
+					dir, _ := cfg.Root(name)
+					fmt.Printf("  %-10s  %s\n", name, dir)
+				}
+			}
+			if len(cfg.CleanPatterns) > 0 {
+				fmt.Println("\nClean patterns:")
+				for _, language := range lang.List() {
+					if patterns, ok := cfg.CleanPatterns[language]; ok {
+						fmt.Printf("  %-10s  %s\n", language, strings.Join(patterns, " "))
+					}
+				}
+			}
 			if len(cfg.AIProviders) > 0 {
 				fmt.Println("\nRegistered AI Providers:")
 				for name, pc := range cfg.AIProviders {
@@ -67,6 +96,10 @@ Examples:
 				fmt.Printf("pm: %s\n", cfg.DefaultPM)
 			case "ai":
 				fmt.Printf("ai: %s\n", cfg.AIProvider)
+			case "in_place":
+				fmt.Printf("in_place: %t\n", cfg.InitInPlace)
+			case "archive_dir":
+				fmt.Printf("archive_dir: %s\n", cfg.ArchiveDirectory())
 			default:
 				return fmt.Errorf("unknown config key: %s", args[0])
 			}
@@ -113,8 +146,30 @@ Examples:
 			}
 			fmt.Printf("✓ Active AI provider set to: %s\n", value)
 
+		case "in_place":
+			inPlace, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value for in_place: %s (use true or false)", value)
+			}
+			cfg.InitInPlace = inPlace
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			if inPlace {
+				fmt.Println("✓ pkt init will register projects in place")
+			} else {
+				fmt.Println("✓ pkt init will move projects into the projects folder")
+			}
+
+		case "archive_dir":
+			cfg.ArchiveDir = value
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			fmt.Printf("✓ Archives will be written to: %s\n", value)
+
 		default:
-			return fmt.Errorf("unknown config key: %s\nAvailable keys: editor, pm, ai", key)
+			return fmt.Errorf("unknown config key: %s\nAvailable keys: editor, pm, ai, in_place, archive_dir", key)
 		}
 
 		return nil
@@ -212,9 +267,148 @@ Examples:
 	},
 }
 
+// configAddRootCmd registers an additional named workspace root.
+var configAddRootCmd = &cobra.Command{
+	Use:   "add-root <name> <path>",
+	Short: "Register an additional workspace root",
+	Long: `Register a named workspace root next to projects_root. 'pkt create',
+'pkt clone' and 'pkt init' accept --root <name> to target it.
+
+Examples:
+  pkt config add-root work ~/work
+  pkt config add-root oss  ~/oss
+  pkt clone https://github.com/user/repo --root oss`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(strings.TrimSpace(args[0]))
+		if name == "" || name == config.DefaultRoot || strings.ContainsAny(name, " \t/\\") {
+			return fmt.Errorf("invalid root name %q", args[0])
+		}
+
+		dir, err := utils.ExpandPath(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to expand path: %w", err)
+		}
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if cfg.Roots == nil {
+			cfg.Roots = make(map[string]string)
+		}
+		cfg.Roots[name] = dir
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Workspace root '%s' set to: %s\n", name, dir)
+		return nil
+	},
+}
+
+// configRemoveRootCmd unregisters a named workspace root.
+var configRemoveRootCmd = &cobra.Command{
+	Use:   "remove-root <name>",
+	Short: "Unregister a workspace root",
+	Long: `Unregister a named workspace root. Projects inside it stay tracked and
+nothing is deleted from disk.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if name == config.DefaultRoot {
+			return fmt.Errorf("the default root cannot be removed")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if _, exists := cfg.Roots[name]; !exists {
+			return fmt.Errorf("unknown workspace root: %s", name)
+		}
+		delete(cfg.Roots, name)
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed workspace root '%s'\n", name)
+		return nil
+	},
+}
+
+// configSetCleanCmd replaces the folders pkt clean prunes for a language.
+var configSetCleanCmd = &cobra.Command{
+	Use:   "set-clean <lang> [pattern...]",
+	Short: "Set the folders pkt clean prunes for a language",
+	Long: `Replace the cache and build folders 'pkt clean' prunes for a language.
+Patterns are relative to the project (or component) and may use globs.
+Without patterns the language's defaults are restored.
+
+Examples:
+  pkt config set-clean js node_modules .next .turbo "packages/*/dist"
+  pkt config set-clean py .venv __pycache__ .pytest_cache .mypy_cache
+  pkt config set-clean go bin
+  pkt config set-clean js           # Back to the defaults`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := lang.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		patterns := args[1:]
+		for _, pattern := range patterns {
+			if _, err := filepath.Match(pattern, ""); err != nil || filepath.IsAbs(pattern) || strings.HasPrefix(filepath.Clean(pattern), "..") {
+				return fmt.Errorf("invalid pattern %q: patterns must be relative to the project", pattern)
+			}
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if len(patterns) == 0 {
+			delete(cfg.CleanPatterns, l.Name())
+		} else {
+			if cfg.CleanPatterns == nil {
+				cfg.CleanPatterns = make(map[string][]string)
+			}
+			cfg.CleanPatterns[l.Name()] = patterns
+		}
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		if len(patterns) == 0 {
+			defaults := strings.Join(l.CleanDirs(), " ")
+			if defaults == "" {
+				defaults = "(none)"
+			}
+			fmt.Printf("✓ pkt clean uses the defaults for %s: %s\n", l.DisplayName(), defaults)
+		} else {
+			fmt.Printf("✓ pkt clean prunes for %s: %s\n", l.DisplayName(), strings.Join(patterns, " "))
+		}
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configSetAICmd)
 	configCmd.AddCommand(configSetModelCmd)
+	configCmd.AddCommand(configAddRootCmd)
+	configCmd.AddCommand(configRemoveRootCmd)
+	configCmd.AddCommand(configSetCleanCmd)
 	configSetAICmd.Flags().String("url", "", "Custom base URL for local/self-hosted providers")
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os/exec"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/genesix/pkt/internal/config"
 	"github.com/genesix/pkt/internal/db"
 	"github.com/genesix/pkt/internal/lang"
@@ -16,6 +15,7 @@ import (
 var (
 	createLang string
 	createOpen bool
+	createRoot string
 )
 
 var createCmd = &cobra.Command{
@@ -31,11 +31,12 @@ Supported languages:
   rs   - Rust (cargo)
 
 Examples:
-  pkt create my-app           # Prompts for language
+  pkt create my-app           # Prompts for language (required with --yes)
   pkt create my-api -l js     # JavaScript project
   pkt create my-cli -l py     # Python project
   pkt create my-tool -l go    # Go project
-  pkt create my-lib -l rs     # Rust project`,
+  pkt create my-lib -l rs     # Rust project
+  pkt create my-fork -r oss   # Create in the "oss" workspace root`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
@@ -46,31 +47,21 @@ Examples:
 			return err
 		}
 
+		// Workspace root to create the project in (projects_root unless --root is set)
+		rootDir, err := workspaceRoot(cfg, createRoot)
+		if err != nil {
+			return err
+		}
+
 		// Determine language
 		language := createLang
 		if language == "" {
-			// Prompt user to select language
-			langOptions := []string{
-				"js - JavaScript/Node.js",
-				"py - Python",
-				"go - Go",
-				"rs - Rust",
-			}
-			var selected string
-			prompt := &survey.Select{
-				Message: "Select project language:",
-				Options: langOptions,
-				Default: langOptions[0],
-			}
-			if err := survey.AskOne(prompt, &selected); err != nil {
-				return fmt.Errorf("cancelled: %w", err)
+			if utils.NonInteractive() {
+				return fmt.Errorf("--lang is required in non-interactive mode (js, py, go, rs)")
 			}
-			// Extract language code from selection
-			for i := 0; i < len(selected); i++ {
-				if selected[i] == ' ' {
-					language = selected[:i]
-					break
-				}
+			language, err = promptLanguage()
+			if err != nil {
+				return err
 			}
 		}
 
@@ -109,7 +100,7 @@ Examples:
 		}
 
 		// Create project directory
-		projectPath, err := utils.CreateProjectDir(cfg.ProjectsRoot, projectName)
+		projectPath, err := utils.CreateProjectDir(rootDir, projectName)
 		if err != nil {
 			return fmt.Errorf("failed to create project directory: %w", err)
 		}
@@ -159,4 +150,5 @@ Examples:
 func init() {
 	createCmd.Flags().StringVarP(&createLang, "lang", "l", "", "Project language (js, py, go, rs)")
 	createCmd.Flags().BoolVarP(&createOpen, "open", "o", false, "Open project in editor after creation")
+	createCmd.Flags().StringVarP(&createRoot, "root", "r", "", "Workspace root to create the project in (see 'pkt config')")
 }
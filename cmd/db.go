@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var dbMigrateStatus bool
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Database maintenance commands",
+	Long:  `Inspect and maintain pkt's SQLite database (~/.pkt/pkt2.db).`,
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Long: `Apply pending schema migrations to the pkt database.
+The database file is backed up to ~/.pkt/backups before any migration runs.
+
+Migrations are also applied automatically whenever another pkt command connects
+to the database, so this is mostly useful for inspecting the schema version.
+
+Examples:
+  pkt db migrate            # Apply pending migrations
+  pkt db migrate --status   # Show applied and pending migrations`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if dbMigrateStatus {
+			statuses, err := db.MigrationStatuses()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+			_, _ = fmt.Fprintln(w, "-------\t----\t------\t----------")
+			pending := 0
+			for _, s := range statuses {
+				status := "applied"
+				appliedAt := s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+				if !s.Applied {
+					status = "pending"
+					appliedAt = "-"
+					pending++
+				}
+				_, _ = fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+			}
+			_ = w.Flush()
+
+			fmt.Println()
+			if pending == 0 {
+				fmt.Println("✓ Database schema is up to date")
+			} else {
+				fmt.Printf("%d pending migration(s). Run 'pkt db migrate' to apply them.\n", pending)
+			}
+			return nil
+		}
+
+		pending, err := db.PendingMigrations()
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			version, err := db.SchemaVersion()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Database schema is up to date (version %d)\n", version)
+			return nil
+		}
+
+		backupPath, err := db.Migrate()
+		if backupPath != "" {
+			fmt.Printf("✓ Backed up database to %s\n", utils.ShortPath(backupPath))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+
+		for _, m := range pending {
+			fmt.Printf("✓ Applied %04d_%s\n", m.Version, m.Name)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	dbMigrateCmd.Flags().BoolVar(&dbMigrateStatus, "status", false, "Show applied and pending migrations without applying them")
+	dbCmd.AddCommand(dbMigrateCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/genesix/pkt/internal/ai"
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -50,7 +51,7 @@ var debugCmd = &cobra.Command{
 			return fmt.Errorf("failed to Ask AI: %w", err)
 		}
 
-		fmt.Printf("\033[33m%s\033[0m\n\n", resp)
+		fmt.Printf("%s\n\n", output.Color("33", resp))
 		return nil
 	},
 }
@@ -2,18 +2,28 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/genesix/pkt/internal/db"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var deletePurge bool
+
 var deleteCmd = &cobra.Command{
 	Use:   "delete <project | id>...",
 	Short: "Delete one or more projects",
-	Long: `Delete one or more project folders and remove them from the database.
-This action cannot be undone!`,
+	Long: `Delete one or more projects: their folders are moved to the trash in ~/.pkt/trash
+together with everything pkt recorded about them, and they are removed from the database.
+
+Bring a project back with 'pkt restore <id>', list the trash with 'pkt trash ls'
+and empty it with 'pkt trash empty'.
+
+The database and config are backed up to ~/.pkt/backups first.
+
+Use --purge to delete the folders right away instead. This cannot be undone!
+Use --yes to skip the confirmation, e.g. in scripts.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var projectsToDel []*db.Project
@@ -25,19 +35,21 @@ This action cannot be undone!`,
 			projectsToDel = append(projectsToDel, project)
 		}
 
-		// Confirm deletion
-		var confirm bool
-		msg := fmt.Sprintf("Delete project '%s' and all its files?", projectsToDel[0].Name)
+		// Confirm deletion (accepted by --yes)
+		msg := fmt.Sprintf("Move project '%s' to the trash?", projectsToDel[0].Name)
 		if len(projectsToDel) > 1 {
-			msg = fmt.Sprintf("Delete %d projects and all their files?", len(projectsToDel))
+			msg = fmt.Sprintf("Move %d projects to the trash?", len(projectsToDel))
 		}
-
-		prompt := &survey.Confirm{
-			Message: msg,
-			Default: false,
+		if deletePurge {
+			msg = fmt.Sprintf("Permanently delete project '%s' and all its files?", projectsToDel[0].Name)
+			if len(projectsToDel) > 1 {
+				msg = fmt.Sprintf("Permanently delete %d projects and all their files?", len(projectsToDel))
+			}
 		}
-		if err := survey.AskOne(prompt, &confirm); err != nil {
-			return fmt.Errorf("cancelled: %w", err)
+
+		confirm, err := utils.Confirm(msg, false)
+		if err != nil {
+			return err
 		}
 
 		if !confirm {
@@ -45,9 +57,29 @@ This action cannot be undone!`,
 			return nil
 		}
 
+		if _, err := utils.AutoBackup("delete"); err != nil {
+			fmt.Printf("⚠️  Warning: failed to back up before deleting: %v\n", err)
+		}
+
+		if !deletePurge {
+			for _, project := range projectsToDel {
+				entry, err := utils.MoveToTrash(project)
+				if err != nil {
+					fmt.Printf("⚠️  Warning: failed to move %s to the trash: %v\n", project.Name, err)
+					continue
+				}
+				fmt.Printf("✓ Moved %s to the trash (restore with: pkt restore %s)\n", project.Name, entry.ID)
+			}
+			return nil
+		}
+
 		// Delete from filesystem and database
 		for _, project := range projectsToDel {
-			if err := utils.DeleteProjectDir(project.Path); err != nil {
+			if project.IsArchived() {
+				if err := os.Remove(project.ArchivePath); err != nil && !os.IsNotExist(err) {
+					fmt.Printf("⚠️  Warning: failed to delete archive %s: %v\n", project.ArchivePath, err)
+				}
+			} else if err := utils.DeleteProjectDir(project.Path); err != nil {
 				fmt.Printf("⚠️  Warning: failed to delete project directory %s: %v\n", project.Path, err)
 			}
 			if err := db.DeleteProject(project.ID); err != nil {
@@ -59,3 +91,7 @@ This action cannot be undone!`,
 		return nil
 	},
 }
+
+func init() {
+	deleteCmd.Flags().BoolVar(&deletePurge, "purge", false, "Delete the folders permanently instead of moving them to the trash")
+}
@@ -3,18 +3,45 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	depsShowIndirect bool
+	depsFilter       string
+	depsComponent    string
+)
+
 var depsCmd = &cobra.Command{
 	Use:   "deps [project | id | .]",
 	Short: "List dependencies for a project",
 	Long: `List all dependencies for a project.
-If no argument is provided, uses the current directory.`,
+If no argument is provided, uses the current directory.
+
+Versions resolved by the lockfile (package-lock.json, pnpm-lock.yaml, bun.lock,
+Cargo.lock, go.sum, uv.lock, poetry.lock) are shown next to the declared ranges.
+Use --indirect to also list transitive dependencies. For Go modules, replace,
+tool, exclude, retract and toolchain directives are listed after the table; for
+JavaScript projects, engines and packageManager.
+
+In a monorepo, workspace members (npm/pnpm workspaces, Cargo members, go.work
+modules, uv members) are tracked as sub-projects and listed below the root's
+dependencies. Use --filter to show a member, or run pkt deps inside its folder.
+
+Polyglot projects, such as a Go backend with a JavaScript frontend in web/, have
+one component per additional language. Components are summarized below the
+primary language's dependencies; use --component to show one.
+
+Cross-project queries:
+  pkt deps who <package>   # Which projects depend on a package
+  pkt deps drift           # Packages pinned to different versions across projects`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var project *db.Project
@@ -37,17 +64,25 @@ If no argument is provided, uses the current directory.`,
 			if err != nil {
 				return err
 			}
+			if err := utils.CheckNotArchived(project); err != nil {
+				return err
+			}
 		}
 
-		// Parse dependencies based on project language
-		deps, err := utils.ParseDependencies(project.Path, project.Language)
-		if err != nil {
-			return fmt.Errorf("failed to parse dependencies: %w", err)
+		if depsFilter != "" {
+			project, err = resolveWorkspaceMember(project, depsFilter)
+			if err != nil {
+				return err
+			}
+		}
+
+		if depsComponent != "" {
+			return showComponentDependencies(project, depsComponent)
 		}
 
-		// Sync to database
-		if err := db.SyncDependencies(project.ID, deps); err != nil {
-			return fmt.Errorf("failed to sync dependencies: %w", err)
+		// Parse dependencies and module directives, then sync them to the database
+		if _, err := utils.SyncProjectDependencies(project.ID, project.Path, project.Language); err != nil {
+			return err
 		}
 
 		// Get dependencies from database
@@ -56,28 +91,543 @@ If no argument is provided, uses the current directory.`,
 			return fmt.Errorf("failed to get dependencies: %w", err)
 		}
 
+		directives, err := db.GetDirectives(project.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get module directives: %w", err)
+		}
+
+		// Workspace roots list their members; target one with --filter
+		var members []*db.Project
+		if !project.IsMember() {
+			members, err = utils.SyncWorkspaceMembers(project)
+			if err != nil {
+				output.Warnf("failed to sync workspace members: %v", err)
+			}
+		}
+
+		// Polyglot projects list their other languages; target one with --component
+		components := syncComponents(project)
+
+		if output.Structured() {
+			report := newDepsReport(project, nil, dbDeps, directives)
+			report.Members = memberRecords(project, members)
+			report.Components = componentRecords(project, components)
+			return output.PrintDocument(report, report.Dependencies)
+		}
+
 		if len(dbDeps) == 0 {
 			fmt.Printf("No dependencies found for %s\n", project.Name)
-			return nil
+		} else {
+			fmt.Printf("Dependencies for %s:\n\n", project.Name)
+			printDependencies(dbDeps)
+		}
+		printDirectives(directives)
+		printWorkspaceMembers(project, members)
+		printComponents(project, components)
+
+		return nil
+	},
+}
+
+// depsReport is a project's dependencies in --output json and yaml; csv lists the dependencies
+type depsReport struct {
+	Project        string             `json:"project" yaml:"project"`
+	Component      string             `json:"component" yaml:"component"` // Empty for the project's primary language
+	Language       string             `json:"language" yaml:"language"`
+	PackageManager string             `json:"package_manager" yaml:"package_manager"`
+	Dependencies   []dependencyRecord `json:"dependencies" yaml:"dependencies"`
+	Directives     []directiveRecord  `json:"directives" yaml:"directives"`
+	Members        []memberRecord     `json:"members" yaml:"members"`
+	Components     []componentRecord  `json:"components" yaml:"components"`
+}
+
+// dependencyRecord is one dependency in machine-readable output
+type dependencyRecord struct {
+	Name     string `json:"name" yaml:"name"`
+	Version  string `json:"version" yaml:"version"`   // Range declared in the manifest
+	Resolved string `json:"resolved" yaml:"resolved"` // Version pinned by the lockfile
+	Type     string `json:"type" yaml:"type"`         // prod or dev
+	Group    string `json:"group" yaml:"group"`
+	Indirect bool   `json:"indirect" yaml:"indirect"`
+}
+
+// directiveRecord is a manifest directive (go.mod replace, package.json engine, ...) in machine-readable output
+type directiveRecord struct {
+	Kind          string `json:"kind" yaml:"kind"`
+	Path          string `json:"path" yaml:"path"`
+	Version       string `json:"version" yaml:"version"`
+	Target        string `json:"target" yaml:"target"`
+	TargetVersion string `json:"target_version" yaml:"target_version"`
+	Note          string `json:"note" yaml:"note"`
+}
+
+// memberRecord is a workspace member in machine-readable output
+type memberRecord struct {
+	Name     string `json:"name" yaml:"name"`
+	Language string `json:"language" yaml:"language"`
+	Path     string `json:"path" yaml:"path"` // Relative to the workspace root
+	Deps     int    `json:"deps" yaml:"deps"` // Direct dependencies
+}
+
+// componentRecord is a component of a polyglot project in machine-readable output
+type componentRecord struct {
+	Name           string `json:"name" yaml:"name"`
+	Language       string `json:"language" yaml:"language"`
+	PackageManager string `json:"package_manager" yaml:"package_manager"`
+	Path           string `json:"path" yaml:"path"` // Relative to the project
+	Deps           int    `json:"deps" yaml:"deps"` // Direct dependencies
+}
+
+// newDepsReport builds the machine-readable dependency report of a project or one of its
+// components, leaving out indirect dependencies unless --indirect is set
+func newDepsReport(project *db.Project, component *db.Component, deps []*db.Dependency, directives []*db.Directive) depsReport {
+	report := depsReport{
+		Project:        project.Name,
+		Language:       project.Language,
+		PackageManager: project.PackageManager,
+		Dependencies:   []dependencyRecord{},
+		Directives:     []directiveRecord{},
+		Members:        []memberRecord{},
+		Components:     []componentRecord{},
+	}
+	if component != nil {
+		report.Component = component.Name
+		report.Language = component.Language
+		report.PackageManager = component.PackageManager
+	}
+
+	for _, dep := range deps {
+		if dep.Indirect && !depsShowIndirect {
+			continue
+		}
+		report.Dependencies = append(report.Dependencies, dependencyRecord{
+			Name:     dep.Name,
+			Version:  dep.Version,
+			Resolved: dep.Resolved,
+			Type:     dep.DepType,
+			Group:    dep.Group,
+			Indirect: dep.Indirect,
+		})
+	}
+	for _, d := range directives {
+		report.Directives = append(report.Directives, directiveRecord{
+			Kind:          d.Kind,
+			Path:          d.Path,
+			Version:       d.Version,
+			Target:        d.Target,
+			TargetVersion: d.TargetVersion,
+			Note:          d.Note,
+		})
+	}
+	return report
+}
+
+// memberRecords describes the members of a workspace root with their direct dependency counts
+func memberRecords(root *db.Project, members []*db.Project) []memberRecord {
+	records := make([]memberRecord, 0, len(members))
+	for _, member := range members {
+		deps, _ := db.GetDependencies(member.ID)
+		rel, err := filepath.Rel(root.Path, member.Path)
+		if err != nil {
+			rel = member.Path
 		}
+		records = append(records, memberRecord{Name: member.Name, Language: member.Language, Path: rel, Deps: countDirect(deps)})
+	}
+	return records
+}
+
+// componentRecords describes the components of a polyglot project with their direct dependency counts
+func componentRecords(project *db.Project, components []*db.Component) []componentRecord {
+	records := make([]componentRecord, 0, len(components))
+	for _, component := range components {
+		deps, _ := db.GetComponentDependencies(project.ID, component.Name)
+		records = append(records, componentRecord{
+			Name:           component.Name,
+			Language:       component.Language,
+			PackageManager: component.PackageManager,
+			Path:           component.Path,
+			Deps:           countDirect(deps),
+		})
+	}
+	return records
+}
+
+// showComponentDependencies syncs and prints the dependencies of one component of a polyglot project
+func showComponentDependencies(project *db.Project, name string) error {
+	component, err := resolveComponent(project, name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := utils.SyncComponentDependencies(project.ID, project.Path, component); err != nil {
+		return err
+	}
+
+	dbDeps, err := db.GetComponentDependencies(project.ID, component.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+
+	directives, err := db.GetComponentDirectives(project.ID, component.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get module directives: %w", err)
+	}
 
-		// Display dependencies
-		fmt.Printf("Dependencies for %s:\n\n", project.Name)
+	if output.Structured() {
+		report := newDepsReport(project, component, dbDeps, directives)
+		return output.PrintDocument(report, report.Dependencies)
+	}
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		_, _ = fmt.Fprintln(w, "NAME\tVERSION\tTYPE")
-		_, _ = fmt.Fprintln(w, "----\t-------\t----")
+	if len(dbDeps) == 0 {
+		fmt.Printf("No dependencies found for %s/%s\n", project.Name, component.Name)
+	} else {
+		fmt.Printf("Dependencies for %s/%s (%s, %s):\n\n", project.Name, component.Name, langToShort(component.Language), component.PackageManager)
+		printDependencies(dbDeps)
+	}
+	printDirectives(directives)
 
-		for _, dep := range dbDeps {
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n",
-				dep.Name,
-				dep.Version,
-				dep.DepType,
-			)
+	return nil
+}
+
+// printDependencies prints the dependency table, hiding indirect dependencies unless --indirect is set
+func printDependencies(dbDeps []*db.Dependency) {
+	// Groups are only recorded by some ecosystems (Python extras and groups, JS peer/optional/bundled, Cargo build)
+	showGroups := false
+	for _, dep := range dbDeps {
+		if dep.Group != "" {
+			showGroups = true
+			break
+		}
+	}
+
+	table := output.NewTable("NAME", "VERSION", "RESOLVED", "TYPE")
+	if showGroups {
+		table = output.NewTable("NAME", "VERSION", "RESOLVED", "TYPE", "GROUP")
+	}
+
+	hidden := 0
+	for _, dep := range dbDeps {
+		if dep.Indirect && !depsShowIndirect {
+			hidden++
+			continue
 		}
+		cells := []string{dep.Name, orDash(dep.Version), orDash(dep.Resolved), depTypeLabel(dep)}
+		if showGroups {
+			cells = append(cells, orDash(dep.Group))
+		}
+		table.Add(cells...)
+	}
+
+	_ = table.Write(os.Stdout)
+
+	if hidden > 0 {
+		fmt.Printf("\n%d indirect dependencies not shown (use --indirect to list them)\n", hidden)
+	}
+}
 
-		_ = w.Flush()
+// printWorkspaceMembers lists the members of a workspace root with their direct dependency counts
+func printWorkspaceMembers(root *db.Project, members []*db.Project) {
+	if len(members) == 0 {
+		return
+	}
 
+	fmt.Printf("\nWorkspace members (%d):\n\n", len(members))
+
+	table := output.NewTable("NAME", "LANG", "DEPS", "PATH")
+	for _, member := range memberRecords(root, members) {
+		table.Add(member.Name, langToShort(member.Language), strconv.Itoa(member.Deps), member.Path)
+	}
+	_ = table.Write(os.Stdout)
+	fmt.Println("\nShow a member's dependencies with: pkt deps --filter <member>")
+}
+
+// syncComponents records the components of a polyglot project and their dependencies,
+// printing warnings instead of failing
+func syncComponents(project *db.Project) []*db.Component {
+	components, err := utils.SyncComponents(project)
+	if err != nil {
+		output.Warnf("failed to sync components: %v", err)
 		return nil
+	}
+
+	for _, component := range components {
+		if _, err := utils.SyncComponentDependencies(project.ID, project.Path, component); err != nil {
+			output.Warnf("%s: %v", component.Name, err)
+		}
+	}
+	return components
+}
+
+// componentSummary describes components as "web (js), python (py)"
+func componentSummary(components []*db.Component) string {
+	parts := make([]string, len(components))
+	for i, component := range components {
+		parts[i] = fmt.Sprintf("%s (%s)", component.Name, langToShort(component.Language))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printComponents lists the components of a polyglot project with their direct dependency counts
+func printComponents(project *db.Project, components []*db.Component) {
+	if len(components) == 0 {
+		return
+	}
+
+	fmt.Printf("\nComponents (%d):\n\n", len(components))
+
+	table := output.NewTable("NAME", "LANG", "PM", "DEPS", "PATH")
+	for _, component := range componentRecords(project, components) {
+		table.Add(component.Name, langToShort(component.Language), component.PackageManager, strconv.Itoa(component.Deps), component.Path)
+	}
+	_ = table.Write(os.Stdout)
+	fmt.Println("\nShow a component's dependencies with: pkt deps --component <name>")
+}
+
+// countDirect returns the number of dependencies declared in the manifest
+func countDirect(deps []*db.Dependency) int {
+	direct := 0
+	for _, dep := range deps {
+		if !dep.Indirect {
+			direct++
+		}
+	}
+	return direct
+}
+
+// printDirectives lists manifest directives (go.mod replace, tool, exclude, retract and
+// toolchain lines; package.json engines and packageManager) by kind
+func printDirectives(directives []*db.Directive) {
+	byKind := make(map[string][]*db.Directive)
+	for _, d := range directives {
+		byKind[d.Kind] = append(byKind[d.Kind], d)
+	}
+
+	if replaces := byKind[db.DirectiveReplace]; len(replaces) > 0 {
+		fmt.Println("\nReplacements:")
+		for _, d := range replaces {
+			target := d.Target + " (local)"
+			if !d.IsLocalReplace() {
+				target = d.Target + " " + d.TargetVersion
+			}
+			fmt.Printf("  %s => %s\n", strings.TrimSpace(d.Path+" "+d.Version), target)
+		}
+	}
+
+	if tools := byKind[db.DirectiveTool]; len(tools) > 0 {
+		fmt.Println("\nTools:")
+		for _, d := range tools {
+			if d.Target != "" && d.Version != "" {
+				fmt.Printf("  %s (%s %s)\n", d.Path, d.Target, d.Version)
+			} else {
+				fmt.Printf("  %s\n", d.Path)
+			}
+		}
+	}
+
+	if excludes := byKind[db.DirectiveExclude]; len(excludes) > 0 {
+		fmt.Println("\nExcluded:")
+		for _, d := range excludes {
+			fmt.Printf("  %s %s\n", d.Path, d.Version)
+		}
+	}
+
+	if retracts := byKind[db.DirectiveRetract]; len(retracts) > 0 {
+		fmt.Println("\nRetracted:")
+		for _, d := range retracts {
+			if d.Note != "" {
+				fmt.Printf("  %s  %s\n", d.Version, d.Note)
+			} else {
+				fmt.Printf("  %s\n", d.Version)
+			}
+		}
+	}
+
+	if toolchains := byKind[db.DirectiveToolchain]; len(toolchains) > 0 {
+		fmt.Printf("\nToolchain: %s\n", toolchains[0].Version)
+	}
+
+	if engines := byKind[db.DirectiveEngine]; len(engines) > 0 {
+		fmt.Println("\nEngines:")
+		for _, d := range engines {
+			fmt.Printf("  %s %s\n", d.Path, d.Version)
+		}
+	}
+
+	if managers := byKind[db.DirectivePackageManager]; len(managers) > 0 {
+		fmt.Printf("\nPackage manager: %s\n", strings.TrimSpace(managers[0].Path+" "+managers[0].Version))
+	}
+}
+
+// depTypeLabel describes a dependency's type, marking transitive ones
+func depTypeLabel(dep *db.Dependency) string {
+	if dep.Indirect {
+		return dep.DepType + " (indirect)"
+	}
+	return dep.DepType
+}
+
+// orDash returns s, or "-" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+var depsWhoCmd = &cobra.Command{
+	Use:   "who <package>",
+	Short: "List every tracked project that depends on a package",
+	Long: `List every tracked project that depends on a package, directly or
+transitively, with the declared and resolved versions.
+
+Uses the dependency data recorded by pkt; run 'pkt deps <project>' to refresh a project.
+Archived projects are included with the dependencies they had when archived.
+
+Examples:
+  pkt deps who lodash
+  pkt deps who requests`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pkg := args[0]
+
+		usages, err := db.FindDependents(pkg)
+		if err != nil {
+			return fmt.Errorf("failed to query dependents: %w", err)
+		}
+
+		if output.Structured() {
+			records := make([]dependentRecord, 0, len(usages))
+			for _, u := range usages {
+				records = append(records, dependentRecord{
+					Project:   u.Project.Name,
+					Component: u.Dependency.Component,
+					Path:      u.Project.Path,
+					Version:   u.Dependency.Version,
+					Resolved:  u.Dependency.Resolved,
+					Type:      u.Dependency.DepType,
+					Group:     u.Dependency.Group,
+					Indirect:  u.Dependency.Indirect,
+					Archived:  u.Project.IsArchived(),
+				})
+			}
+			return output.Print(records)
+		}
+
+		if len(usages) == 0 {
+			fmt.Printf("No tracked projects depend on %s\n", pkg)
+			return nil
+		}
+
+		// A project may list the package in several components
+		projects := make(map[string]bool)
+		for _, u := range usages {
+			projects[u.Project.ID] = true
+		}
+		fmt.Printf("%d project(s) depend on %s:\n\n", len(projects), pkg)
+
+		table := output.NewTable("PROJECT", "VERSION", "RESOLVED", "TYPE", "PATH")
+		for _, u := range usages {
+			name := u.Project.Name
+			if u.Dependency.Component != "" {
+				name += "/" + u.Dependency.Component
+			}
+			path := utils.ShortPath(u.Project.Path)
+			if u.Project.IsArchived() {
+				path += output.Color("90", " (archived)")
+			}
+			depType := depTypeLabel(u.Dependency)
+			if u.Dependency.Group != "" {
+				depType += ", " + u.Dependency.Group
+			}
+			table.Add(name, orDash(u.Dependency.Version), orDash(u.Dependency.Resolved), depType, path)
+		}
+		return table.Write(os.Stdout)
+	},
+}
+
+// dependentRecord is a project depending on a package in --output json, yaml and csv
+type dependentRecord struct {
+	Project   string `json:"project" yaml:"project"`
+	Component string `json:"component" yaml:"component"`
+	Path      string `json:"path" yaml:"path"`
+	Version   string `json:"version" yaml:"version"`
+	Resolved  string `json:"resolved" yaml:"resolved"`
+	Type      string `json:"type" yaml:"type"`
+	Group     string `json:"group" yaml:"group"`
+	Indirect  bool   `json:"indirect" yaml:"indirect"`
+	Archived  bool   `json:"archived" yaml:"archived"`
+}
+
+var depsDriftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Show packages pinned to different versions across projects",
+	Long: `Show direct dependencies that resolve to different versions in different
+tracked projects, grouped by version. Packages are only compared with packages
+of the same language, and components of polyglot projects are listed as
+project/component. The lockfile version is compared when known, otherwise the
+declared range.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		drifts, err := db.GetVersionDrift()
+		if err != nil {
+			return fmt.Errorf("failed to query version drift: %w", err)
+		}
+
+		if output.Structured() {
+			records := []driftRecord{}
+			for _, drift := range drifts {
+				for _, usage := range drift.Versions {
+					records = append(records, driftRecord{Package: drift.Name, Language: drift.Language, Version: usage.Version, Projects: driftUsers(usage)})
+				}
+			}
+			return output.Print(records)
+		}
+
+		if len(drifts) == 0 {
+			fmt.Println("✓ No version drift: every shared package uses the same version")
+			return nil
+		}
+
+		fmt.Printf("%d package(s) with version drift:\n\n", len(drifts))
+
+		table := output.NewTable("PACKAGE", "LANGUAGE", "VERSION", "PROJECTS")
+		for _, drift := range drifts {
+			for i, usage := range drift.Versions {
+				name, language := "", ""
+				if i == 0 {
+					name, language = drift.Name, drift.Language
+				}
+				table.Add(name, language, usage.Version, strings.Join(driftUsers(usage), ", "))
+			}
+		}
+		return table.Write(os.Stdout)
 	},
 }
+
+// driftRecord is one version of a drifting package in --output json, yaml and csv
+type driftRecord struct {
+	Package  string   `json:"package" yaml:"package"`
+	Language string   `json:"language" yaml:"language"`
+	Version  string   `json:"version" yaml:"version"`
+	Projects []string `json:"projects" yaml:"projects"`
+}
+
+// driftUsers names the projects using a version, as project/component for components
+func driftUsers(usage *db.VersionUsage) []string {
+	names := make([]string, len(usage.Projects))
+	for i, project := range usage.Projects {
+		names[i] = project.Name
+		if usage.Components[i] != "" {
+			names[i] += "/" + usage.Components[i]
+		}
+	}
+	return names
+}
+
+func init() {
+	depsCmd.Flags().BoolVarP(&depsShowIndirect, "indirect", "i", false, "Also list transitive dependencies from the lockfile")
+	depsCmd.Flags().StringVarP(&depsFilter, "filter", "F", "", "Show a workspace member (name, directory or glob)")
+	depsCmd.Flags().StringVarP(&depsComponent, "component", "c", "", "Show a component of a polyglot project")
+	depsCmd.AddCommand(depsWhoCmd)
+	depsCmd.AddCommand(depsDriftCmd)
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	discoverAll    bool
+	discoverDryRun bool
+	discoverDepth  int
+)
+
+var discoverCmd = &cobra.Command{
+	Use:   "discover [dir]",
+	Short: "Find existing projects in a directory tree and register them",
+	Long: `Walk a directory tree, detect every project (package.json, pyproject.toml,
+requirements.txt, go.mod, Cargo.toml) and register the ones you pick.
+
+Unlike 'pkt init', discovered projects are registered in place: nothing is moved
+into the projects folder. Dependency and build folders (node_modules, target,
+venv, ...) and hidden folders are skipped, and the sub-directories of a detected
+project are not searched; its workspace members and components are tracked with it.
+
+Examples:
+  pkt discover ~/code              # Preview, then pick projects to register
+  pkt discover ~/code --all        # Register every new project without asking
+  pkt discover ~/code --dry-run    # Only show what would be registered
+  pkt discover . --depth 2         # Search at most two levels deep`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := "."
+		if len(args) > 0 {
+			inputPath = args[0]
+		}
+
+		expanded, err := utils.ExpandPath(inputPath)
+		if err != nil {
+			return fmt.Errorf("failed to expand path: %w", err)
+		}
+		root, err := filepath.Abs(expanded)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return fmt.Errorf("not a directory: %s", root)
+		}
+
+		fmt.Printf("🔍 Scanning %s...\n", utils.ShortPath(root))
+
+		found, err := utils.DiscoverProjects(root, discoverDepth)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+
+		if len(found) == 0 {
+			fmt.Println("No projects found.")
+			return nil
+		}
+
+		// Projects already tracked (including workspace members) are shown but not offered
+		var candidates []utils.DiscoveredProject
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Println()
+		_, _ = fmt.Fprintln(w, "NAME\tLANG\tPM\tSTATUS\tPATH")
+		_, _ = fmt.Fprintln(w, "----\t----\t--\t------\t----")
+		for _, project := range found {
+			status := "new"
+			if _, err := db.GetProjectByPath(project.Path); err == nil {
+				status = "tracked"
+			} else {
+				candidates = append(candidates, project)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				project.Name,
+				langToShort(project.Language),
+				project.PackageManager,
+				status,
+				utils.ShortPath(project.Path),
+			)
+		}
+		_ = w.Flush()
+
+		fmt.Printf("\nFound %d projects, %d not tracked yet\n", len(found), len(candidates))
+
+		if len(candidates) == 0 || discoverDryRun {
+			return nil
+		}
+
+		selected := candidates
+		if !discoverAll {
+			selected, err = pickDiscovered(candidates)
+			if err != nil {
+				return err
+			}
+			if len(selected) == 0 {
+				fmt.Println("Nothing selected.")
+				return nil
+			}
+		}
+
+		fmt.Println()
+		registered := 0
+		for _, project := range selected {
+			if err := registerDiscovered(project); err != nil {
+				fmt.Printf("❌ %s: %v\n", project.Name, err)
+				continue
+			}
+			registered++
+		}
+
+		fmt.Printf("\n✓ Registered %d of %d projects\n", registered, len(selected))
+		return nil
+	},
+}
+
+// pickDiscovered asks which discovered projects to register, all selected by default
+func pickDiscovered(candidates []utils.DiscoveredProject) ([]utils.DiscoveredProject, error) {
+	options := make([]string, len(candidates))
+	for i, project := range candidates {
+		options[i] = fmt.Sprintf("%s (%s, %s) %s", project.Name, langToShort(project.Language), project.PackageManager, utils.ShortPath(project.Path))
+	}
+
+	picked, err := utils.MultiSelect("Select projects to register:", options, options)
+	if errors.Is(err, utils.ErrNonInteractive) {
+		return nil, fmt.Errorf("%w: pass --all to register every new project, or --dry-run to only list them", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]utils.DiscoveredProject, len(picked))
+	for i, index := range picked {
+		selected[i] = candidates[index]
+	}
+	return selected, nil
+}
+
+// trackedProject is a project registered by trackProject, with what was synced for it
+type trackedProject struct {
+	Project    *db.Project
+	DepCount   int
+	Members    []*db.Project
+	Components []*db.Component
+}
+
+// trackProject registers a project folder under id, then syncs its dependencies,
+// workspace members and the other languages of a polyglot project. Every command
+// that starts tracking a folder goes through it.
+func trackProject(id, name, path, language, packageManager string) (*trackedProject, error) {
+	project, err := db.CreateProject(id, name, path, language, packageManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add project to database: %w", err)
+	}
+
+	// Remember the origin remote so the project can be found again if it moves
+	recordGitRemote(project)
+
+	tracked := &trackedProject{Project: project}
+	if tracked.DepCount, err = utils.SyncProjectDependencies(project.ID, path, language); err != nil {
+		output.Warnf("%s: %v", name, err)
+	}
+
+	// Track workspace members as sub-projects
+	if tracked.Members, err = utils.SyncWorkspaceMembers(project); err != nil {
+		output.Warnf("%s: failed to sync workspace members: %v", name, err)
+	}
+
+	// Record the other languages of a polyglot project
+	tracked.Components = syncComponents(project)
+	return tracked, nil
+}
+
+// registerDiscovered tracks a discovered project in place
+func registerDiscovered(found utils.DiscoveredProject) error {
+	tracked, err := trackProject(utils.GenerateID(), found.Name, found.Path, found.Language, found.PackageManager)
+	if err != nil {
+		return err
+	}
+
+	project := tracked.Project
+	details := fmt.Sprintf("%s, %s, %d deps", langToShort(project.Language), project.PackageManager, tracked.DepCount)
+	if len(tracked.Members) > 0 {
+		details += fmt.Sprintf(", %d workspace members", len(tracked.Members))
+	}
+	if len(tracked.Components) > 0 {
+		details += ", components: " + componentSummary(tracked.Components)
+	}
+	fmt.Printf("✓ %s (%s)\n", project.Name, details)
+
+	return nil
+}
+
+func init() {
+	discoverCmd.Flags().BoolVarP(&discoverAll, "all", "a", false, "Register every new project without asking")
+	discoverCmd.Flags().BoolVar(&discoverDryRun, "dry-run", false, "Only list the projects that were found")
+	discoverCmd.Flags().IntVarP(&discoverDepth, "depth", "d", 4, "Maximum directory depth to search (0 for no limit)")
+	rootCmd.AddCommand(discoverCmd)
+}
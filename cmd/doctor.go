@@ -0,0 +1,682 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/genesix/pkt/internal/ai"
+	"github.com/genesix/pkt/internal/config"
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/lang"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/pm"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorFix    bool
+	doctorDryRun bool
+)
+
+// relocationSearchDepth is how deep workspace roots are searched for moved projects
+const relocationSearchDepth = 4
+
+// installHints tells how to install each package manager
+var installHints = map[string]string{
+	"npm":    "Install Node.js from https://nodejs.org",
+	"pnpm":   "Install pnpm with 'npm install -g pnpm'",
+	"bun":    "Install bun from https://bun.sh",
+	"uv":     "Install uv from https://docs.astral.sh/uv",
+	"pip":    "Install Python 3 from https://python.org",
+	"poetry": "Install poetry from https://python-poetry.org/docs/#installation",
+	"go":     "Install Go from https://go.dev/dl",
+	"cargo":  "Install Rust from https://rustup.rs",
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that pkt's environment is healthy",
+	Long: `Check that pkt's environment is healthy and print a fix for every problem:
+
+  • Config: projects_root and workspace roots exist, the editor is in PATH,
+    the AI provider has an API key
+  • Database: SQLite integrity check, rows left behind by deleted projects
+  • Package managers: which ones are installed and their versions, and whether
+    every project's package manager is still installed
+  • Projects: folders that moved or vanished, broken Python virtual environments
+
+Exits with a non-zero status when a check fails, so it can run in onboarding scripts.
+Warnings do not fail the run.
+
+Examples:
+  pkt doctor            # Run every check
+  pkt doctor --fix      # Also apply safe fixes (create missing roots, remove orphaned rows, record git remotes)
+  pkt doctor projects   # Relocate, re-detect or drop stale projects`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report := &doctorReport{}
+
+		fmt.Println("🩺 Checking pkt environment...")
+		checkConfig(report)
+		projects := checkDatabase(report)
+		checkPackageManagers(report, projects)
+		checkProjects(report, projects)
+
+		fmt.Println()
+		if report.failures > 0 {
+			return fmt.Errorf("%d check(s) failed, %d warning(s)", report.failures, report.warnings)
+		}
+		if report.warnings > 0 {
+			fmt.Printf("✓ No failures, %d warning(s)\n", report.warnings)
+			return nil
+		}
+		fmt.Println("✓ Everything looks good")
+		return nil
+	},
+}
+
+var doctorProjectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Find and repair stale or moved project paths",
+	Long: `Check every tracked project for a folder that no longer exists or whose
+language no longer matches its manifest files, and offer to repair each one:
+
+  relocate   Point the project at its new folder. Candidates are found by folder
+             name or git remote under the workspace roots; a path can also be typed.
+  re-detect  Update the language and package manager from the manifest files.
+  remove     Stop tracking the project. Nothing is deleted from disk.
+
+Workspace members move along with their root. The git remote of every healthy
+project is recorded, so it can be found by remote if it moves later.
+
+With --output json, yaml or csv the problems are only listed, as with --dry-run.
+
+Examples:
+  pkt doctor projects             # Check and repair interactively
+  pkt doctor projects --dry-run   # Only list problems`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projects, err := db.ListAllProjects()
+		if err != nil {
+			return fmt.Errorf("failed to list projects: %w", err)
+		}
+
+		issues := findProjectIssues(projects)
+		if output.Structured() {
+			records := make([]projectIssueRecord, 0, len(issues))
+			for _, issue := range issues {
+				records = append(records, newProjectIssueRecord(issue))
+			}
+			return output.Print(records)
+		}
+
+		if !doctorDryRun {
+			recordGitRemotes(projects, issues)
+		}
+
+		if len(issues) == 0 {
+			fmt.Printf("✓ All %d tracked projects look healthy\n", len(projects))
+			return nil
+		}
+
+		fmt.Printf("Found %d project(s) with problems:\n\n", len(issues))
+		table := output.NewTable("NAME", "PROBLEM", "PATH")
+		for _, issue := range issues {
+			table.Add(issue.Project.Name, issue.describe(), utils.ShortPath(issue.Project.Path))
+		}
+		if err := table.Write(os.Stdout); err != nil {
+			return err
+		}
+
+		if doctorDryRun {
+			return nil
+		}
+		if utils.NonInteractive() {
+			return fmt.Errorf("repairing projects needs an answer for each one; run it interactively, or use --dry-run to only list problems")
+		}
+
+		roots := relocationRoots()
+		fixed := 0
+		for _, issue := range issues {
+			ok, err := repairProject(issue, roots)
+			if err != nil {
+				fmt.Printf("❌ %s: %v\n", issue.Project.Name, err)
+				continue
+			}
+			if ok {
+				fixed++
+			}
+		}
+
+		fmt.Printf("\n✓ Repaired %d of %d project(s)\n", fixed, len(issues))
+		return nil
+	},
+}
+
+// projectIssue is a problem found with a tracked project
+type projectIssue struct {
+	Project  *db.Project
+	Missing  bool          // The project folder no longer exists
+	Detected lang.Language // Language found in the folder, nil when there is no manifest
+}
+
+// describe explains the problem in a few words
+func (i projectIssue) describe() string {
+	switch {
+	case i.Missing:
+		return "folder not found"
+	case i.Detected == nil:
+		return fmt.Sprintf("no %s manifest found", langToShort(i.Project.Language))
+	default:
+		return fmt.Sprintf("tracked as %s, detected %s", langToShort(i.Project.Language), langToShort(i.Detected.Name()))
+	}
+}
+
+// projectIssueRecord is a project problem in --output json, yaml and csv
+type projectIssueRecord struct {
+	ID       string `json:"id" yaml:"id"`
+	Project  string `json:"project" yaml:"project"`
+	Path     string `json:"path" yaml:"path"`
+	Problem  string `json:"problem" yaml:"problem"`
+	Missing  bool   `json:"missing" yaml:"missing"`
+	Language string `json:"language" yaml:"language"` // Tracked language
+	Detected string `json:"detected" yaml:"detected"` // Language found in the folder, empty when missing or without a manifest
+}
+
+// newProjectIssueRecord builds the machine-readable record of a project problem
+func newProjectIssueRecord(issue projectIssue) projectIssueRecord {
+	record := projectIssueRecord{
+		ID:       issue.Project.ID,
+		Project:  issue.Project.Name,
+		Path:     issue.Project.Path,
+		Problem:  issue.describe(),
+		Missing:  issue.Missing,
+		Language: issue.Project.Language,
+	}
+	if issue.Detected != nil {
+		record.Detected = issue.Detected.Name()
+	}
+	return record
+}
+
+// findProjectIssues checks each project's folder and language without changing
+// anything. Members of a missing workspace root are not reported on their own since
+// they move with the root.
+func findProjectIssues(projects []*db.Project) []projectIssue {
+	missing := make(map[string]bool)
+	var issues []projectIssue
+
+	for _, project := range projects {
+		// Archived projects have no folder; checkProjects verifies their archive
+		if project.IsArchived() {
+			continue
+		}
+		if _, err := os.Stat(project.Path); os.IsNotExist(err) {
+			missing[project.ID] = true
+			issues = append(issues, projectIssue{Project: project, Missing: true})
+			continue
+		}
+
+		// Polyglot projects match as long as their language is still present
+		detected := lang.DetectAll(project.Path)
+		matches := slices.ContainsFunc(detected, func(l lang.Language) bool { return l.Name() == project.Language })
+		if !matches {
+			issue := projectIssue{Project: project}
+			if len(detected) > 0 {
+				issue.Detected = detected[0]
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	return slices.DeleteFunc(issues, func(issue projectIssue) bool {
+		return issue.Project.IsMember() && missing[issue.Project.ParentID]
+	})
+}
+
+// recordGitRemotes records the origin remote of every healthy, unarchived project
+func recordGitRemotes(projects []*db.Project, issues []projectIssue) {
+	stale := make(map[string]bool)
+	for _, issue := range issues {
+		stale[issue.Project.ID] = true
+	}
+	for _, project := range projects {
+		if !stale[project.ID] && !project.IsArchived() {
+			recordGitRemote(project)
+		}
+	}
+}
+
+// recordGitRemote stores the origin remote of a project so it can be found again if its folder moves
+func recordGitRemote(project *db.Project) {
+	remote := utils.GitRemote(project.Path)
+	if remote == "" || remote == project.GitRemote {
+		return
+	}
+	if err := db.SetProjectRemote(project.ID, remote); err == nil {
+		project.GitRemote = remote
+	}
+}
+
+// relocationRoots returns the directories searched for moved projects: every workspace root
+func relocationRoots() []string {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+
+	var roots []string
+	for _, name := range cfg.RootNames() {
+		if root, err := workspaceRoot(cfg, name); err == nil {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// repairProject asks how to fix one issue and applies the choice, reporting whether it was fixed
+func repairProject(issue projectIssue, roots []string) (bool, error) {
+	project := issue.Project
+	fmt.Printf("\n⚠️  %s: %s (%s)\n", project.Name, issue.describe(), utils.ShortPath(project.Path))
+
+	const (
+		enterPath = "Enter the new path"
+		remove    = "Remove from pkt (files are not touched)"
+		skip      = "Skip"
+	)
+
+	var options []string
+	relocations := make(map[string]string)
+	redetect := ""
+
+	if issue.Missing {
+		// The parent folder is searched too, which finds renamed siblings
+		searchRoots := append(slices.Clone(roots), filepath.Dir(project.Path))
+		names := []string{filepath.Base(project.Path), project.Name}
+		candidates, err := utils.FindRelocationCandidates(searchRoots, names, project.GitRemote, relocationSearchDepth)
+		if err != nil {
+			output.Warnf("failed to search for %s: %v", project.Name, err)
+		}
+
+		for _, candidate := range candidates {
+			if _, err := db.GetProjectByPath(candidate.Path); err == nil {
+				continue // already tracked
+			}
+			reason := "same folder name"
+			if candidate.MatchRemote {
+				reason = "same git remote"
+			}
+			option := fmt.Sprintf("Relocate to %s (%s)", utils.ShortPath(candidate.Path), reason)
+			relocations[option] = candidate.Path
+			options = append(options, option)
+		}
+		options = append(options, enterPath)
+	} else if issue.Detected != nil {
+		redetect = fmt.Sprintf("Re-detect as %s (%s)", issue.Detected.DisplayName(), issue.Detected.DetectPackageManager(project.Path))
+		options = append(options, redetect)
+	}
+	options = append(options, remove, skip)
+
+	choice, err := utils.Select("How should this project be fixed?", options, options[0])
+	if err != nil {
+		return false, err
+	}
+
+	switch choice {
+	case skip:
+		return false, nil
+
+	case remove:
+		if err := db.DeleteProject(project.ID); err != nil {
+			return false, err
+		}
+		fmt.Printf("✓ Removed %s from pkt\n", project.Name)
+		return true, nil
+
+	case redetect:
+		return true, redetectProject(project, issue.Detected)
+
+	case enterPath:
+		input, err := utils.Input("New path:", "")
+		if err != nil {
+			return false, err
+		}
+		expanded, err := utils.ExpandPath(input)
+		if err != nil {
+			return false, fmt.Errorf("failed to expand path: %w", err)
+		}
+		newPath, err := filepath.Abs(expanded)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve path: %w", err)
+		}
+		return true, relocateProject(project, newPath)
+
+	default:
+		return true, relocateProject(project, relocations[choice])
+	}
+}
+
+// relocateProject points a project at its new folder, re-detecting the language if it
+// changed, and resyncs its dependencies
+func relocateProject(project *db.Project, newPath string) error {
+	if info, err := os.Stat(newPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", newPath)
+	}
+	if other, err := db.GetProjectByPath(newPath); err == nil {
+		return fmt.Errorf("%s is already tracked as %s", utils.ShortPath(newPath), other.Name)
+	}
+
+	if err := db.UpdateProjectPath(project.ID, newPath); err != nil {
+		return err
+	}
+	project.Path = newPath
+	fmt.Printf("✓ Relocated %s to %s\n", project.Name, utils.ShortPath(newPath))
+	recordGitRemote(project)
+
+	detected := lang.DetectAll(newPath)
+	if len(detected) > 0 && !slices.ContainsFunc(detected, func(l lang.Language) bool { return l.Name() == project.Language }) {
+		return redetectProject(project, detected[0])
+	}
+
+	if _, err := utils.SyncProjectDependencies(project.ID, project.Path, project.Language); err != nil {
+		output.Warnf("%v", err)
+	}
+	return nil
+}
+
+// redetectProject updates a project's language and package manager and resyncs its dependencies
+func redetectProject(project *db.Project, detected lang.Language) error {
+	packageManager := detected.DetectPackageManager(project.Path)
+	if err := db.UpdateProjectLanguage(project.ID, detected.Name(), packageManager); err != nil {
+		return err
+	}
+	project.Language, project.PackageManager = detected.Name(), packageManager
+	fmt.Printf("✓ %s is now tracked as %s (%s)\n", project.Name, detected.DisplayName(), packageManager)
+
+	if _, err := utils.SyncProjectDependencies(project.ID, project.Path, project.Language); err != nil {
+		output.Warnf("%v", err)
+	}
+	return nil
+}
+
+// doctorReport prints the results of pkt doctor's checks and counts the problems
+type doctorReport struct {
+	failures int
+	warnings int
+}
+
+// section starts a group of checks
+func (r *doctorReport) section(title string) {
+	fmt.Printf("\n%s\n", title)
+}
+
+// ok reports a passing check
+func (r *doctorReport) ok(message string) {
+	fmt.Printf("  ✓ %s\n", message)
+}
+
+// info reports something worth knowing that is not a problem
+func (r *doctorReport) info(message string) {
+	fmt.Printf("  - %s\n", message)
+}
+
+// warn reports a problem that does not fail the run, with how to fix it
+func (r *doctorReport) warn(message, fix string) {
+	r.warnings++
+	fmt.Printf("  ⚠️  %s\n", message)
+	if fix != "" {
+		fmt.Printf("     → %s\n", fix)
+	}
+}
+
+// fail reports a failing check, with how to fix it
+func (r *doctorReport) fail(message, fix string) {
+	r.failures++
+	fmt.Printf("  ❌ %s\n", message)
+	if fix != "" {
+		fmt.Printf("     → %s\n", fix)
+	}
+}
+
+// checkConfig verifies the workspace roots, the editor and the AI provider
+func checkConfig(report *doctorReport) {
+	report.section("Config")
+
+	cfg, err := config.Load()
+	if err != nil {
+		report.fail(err.Error(), "Run 'pkt start'")
+		return
+	}
+	if !cfg.Initialized {
+		report.fail("pkt has not been initialized", "Run 'pkt start'")
+		return
+	}
+
+	for _, name := range cfg.RootNames() {
+		label := "projects_root"
+		fix := "Run 'mkdir -p %s', 'pkt config projects_root <path>' or 'pkt doctor --fix'"
+		if name != config.DefaultRoot {
+			label = "root " + name
+			fix = "Run 'mkdir -p %s', 'pkt config remove-root " + name + "' or 'pkt doctor --fix'"
+		}
+
+		root, err := workspaceRoot(cfg, name)
+		if err != nil {
+			report.fail(fmt.Sprintf("%s: %v", label, err), "")
+			continue
+		}
+		info, err := os.Stat(root)
+		switch {
+		case err == nil && info.IsDir():
+			report.ok(fmt.Sprintf("%s %s", label, utils.ShortPath(root)))
+		case err == nil:
+			report.fail(fmt.Sprintf("%s %s is not a directory", label, utils.ShortPath(root)), "Point it at a directory with 'pkt config'")
+		case doctorFix:
+			if err := os.MkdirAll(root, 0755); err != nil {
+				report.fail(fmt.Sprintf("failed to create %s %s: %v", label, utils.ShortPath(root), err), "")
+				continue
+			}
+			report.ok(fmt.Sprintf("%s %s created", label, utils.ShortPath(root)))
+		case name == config.DefaultRoot:
+			report.fail(fmt.Sprintf("%s %s does not exist", label, utils.ShortPath(root)), fmt.Sprintf(fix, root))
+		default:
+			report.warn(fmt.Sprintf("%s %s does not exist", label, utils.ShortPath(root)), fmt.Sprintf(fix, root))
+		}
+	}
+
+	switch {
+	case cfg.EditorCommand == "":
+		report.warn("no editor configured", "Run 'pkt config editor <command>'")
+	case !pm.CheckAvailability(cfg.EditorCommand):
+		report.fail(fmt.Sprintf("editor %q not found in PATH", cfg.EditorCommand), "Install it or run 'pkt config editor <command>'")
+	default:
+		report.ok(fmt.Sprintf("editor %s", cfg.EditorCommand))
+	}
+
+	provider := cfg.AIProvider
+	switch {
+	case provider == "":
+		report.info("no AI provider configured (optional, see 'pkt config ai')")
+	case ai.RequiresAPIKey(provider) && cfg.AIProviders[provider].APIKey == "":
+		report.fail(fmt.Sprintf("AI provider %s has no API key", provider), fmt.Sprintf("Run 'pkt config set-ai %s <api-key>'", provider))
+	default:
+		report.ok(fmt.Sprintf("AI provider %s", provider))
+	}
+}
+
+// checkDatabase connects to the database and verifies its integrity, returning the
+// tracked projects, or nil when the database cannot be used
+func checkDatabase(report *doctorReport) []*db.Project {
+	report.section("Database")
+
+	backups := "Restore a backup from ~/.pkt/backups with 'pkt restore <file>'"
+	if err := db.Connect(); err != nil {
+		report.fail(err.Error(), backups)
+		return nil
+	}
+
+	problems, err := db.IntegrityCheck()
+	switch {
+	case err != nil:
+		report.fail(err.Error(), backups)
+	case len(problems) > 0:
+		for _, problem := range problems {
+			report.fail("integrity check: "+problem, backups)
+		}
+	default:
+		report.ok("integrity check passed")
+	}
+
+	orphans, err := db.OrphanCounts()
+	switch {
+	case err != nil:
+		report.fail(err.Error(), "")
+	case len(orphans) == 0:
+		report.ok("no orphaned rows")
+	case doctorFix:
+		removed, err := db.DeleteOrphans()
+		if err != nil {
+			report.fail(err.Error(), "")
+		} else {
+			report.ok(fmt.Sprintf("removed %d orphaned row(s)", removed))
+		}
+	default:
+		tables := make([]string, 0, len(orphans))
+		for table := range orphans {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+		for _, table := range tables {
+			report.fail(fmt.Sprintf("%d %s row(s) belong to projects that no longer exist", orphans[table], table), "Run 'pkt doctor --fix'")
+		}
+	}
+
+	projects, err := db.ListAllProjects()
+	if err != nil {
+		report.fail(fmt.Sprintf("failed to list projects: %v", err), "")
+		return nil
+	}
+	report.ok(fmt.Sprintf("%d projects tracked", len(projects)))
+	return projects
+}
+
+// checkPackageManagers lists the installed package managers and verifies that every
+// project's package manager is among them
+func checkPackageManagers(report *doctorReport, projects []*db.Project) {
+	report.section("Package managers")
+
+	usedBy := make(map[string][]string)
+	for _, project := range projects {
+		if _, err := pm.Get(project.Language, project.PackageManager); err != nil {
+			report.fail(fmt.Sprintf("%s: %v", project.Name, err), fmt.Sprintf("Run 'pkt pm set <pm> %s'", project.Name))
+			continue
+		}
+		usedBy[project.PackageManager] = append(usedBy[project.PackageManager], project.Name)
+	}
+
+	type pmStatus struct {
+		available bool
+		version   string
+		err       error
+	}
+	managers := pm.Registered()
+	statuses := utils.ParallelMap(managers, 0, func(manager pm.PackageManager) pmStatus {
+		if !manager.IsAvailable() {
+			return pmStatus{}
+		}
+		version, err := pm.Version(manager.Name())
+		return pmStatus{available: true, version: version, err: err}
+	})
+
+	for i, manager := range managers {
+		name, status, users := manager.Name(), statuses[i], usedBy[manager.Name()]
+		switch {
+		case status.available && status.err != nil:
+			report.warn(fmt.Sprintf("%s is installed but %v", name, status.err), "")
+		case status.available:
+			report.ok(fmt.Sprintf("%s %s", name, status.version))
+		case len(users) > 0:
+			report.fail(fmt.Sprintf("%s is not installed, used by %s", name, projectList(users)), installHints[name])
+		default:
+			report.info(fmt.Sprintf("%s not installed", name))
+		}
+	}
+}
+
+// checkProjects reports stale project folders and the health of Python virtual environments
+func checkProjects(report *doctorReport, projects []*db.Project) {
+	report.section("Projects")
+	if projects == nil {
+		report.info("skipped, the database is not available")
+		return
+	}
+
+	issues := findProjectIssues(projects)
+	if doctorFix {
+		recordGitRemotes(projects, issues)
+	}
+	stale := make(map[string]bool)
+	for _, issue := range issues {
+		stale[issue.Project.ID] = true
+		report.warn(fmt.Sprintf("%s: %s (%s)", issue.Project.Name, issue.describe(), utils.ShortPath(issue.Project.Path)), "Run 'pkt doctor projects'")
+	}
+	if len(issues) == 0 {
+		report.ok("all project folders found")
+	}
+
+	for _, project := range projects {
+		if !project.IsArchived() || project.IsMember() {
+			continue
+		}
+		if _, err := os.Stat(project.ArchivePath); err != nil {
+			report.fail(fmt.Sprintf("%s: archive %s not found", project.Name, utils.ShortPath(project.ArchivePath)),
+				fmt.Sprintf("Restore the archive, or drop the project with 'pkt delete %s --purge'", project.Name))
+		}
+	}
+
+	pythonProjects := 0
+	for _, project := range projects {
+		if project.Language != "python" || stale[project.ID] || project.IsArchived() {
+			continue
+		}
+		pythonProjects++
+
+		venv := utils.CheckVenv(project.Path)
+		switch {
+		case venv == nil && project.PackageManager == "pip":
+			report.warn(fmt.Sprintf("%s: no virtual environment", project.Name), fmt.Sprintf("Run 'pkt add .' in %s", utils.ShortPath(project.Path)))
+		case venv == nil:
+			report.info(fmt.Sprintf("%s: no virtual environment yet (%s creates one on demand)", project.Name, project.PackageManager))
+		case venv.Err != nil:
+			report.fail(fmt.Sprintf("%s: broken virtual environment %s: %v", project.Name, utils.ShortPath(venv.Dir), venv.Err),
+				fmt.Sprintf("Delete %s and run 'pkt add .' in %s", utils.ShortPath(venv.Dir), utils.ShortPath(project.Path)))
+		default:
+			report.ok(fmt.Sprintf("%s: %s (Python %s)", project.Name, filepath.Base(venv.Dir), venv.Version))
+		}
+	}
+	if pythonProjects == 0 {
+		report.info("no Python projects to check")
+	}
+}
+
+// projectList joins project names, shortening long lists
+func projectList(names []string) string {
+	const shown = 3
+	if len(names) <= shown {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:shown], ", "), len(names)-shown)
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Apply safe fixes: create missing workspace roots, remove orphaned rows and record git remotes")
+	doctorProjectsCmd.Flags().BoolVar(&doctorDryRun, "dry-run", false, "Only list problems, without repairing them")
+	doctorCmd.AddCommand(doctorProjectsCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -37,6 +37,9 @@ Examples:
 		if err != nil {
 			return err
 		}
+		if err := utils.CheckNotArchived(project); err != nil {
+			return err
+		}
 
 		fmt.Printf("📂 Executing in %s (%s)...\n\n", project.Name, project.Path)
 
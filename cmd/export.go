@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/genesix/pkt/internal/config"
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write a portable manifest of every tracked project",
+	Long: `Write a JSON manifest of every tracked project that 'pkt import' can use to
+reproduce the workspace on another machine.
+
+Each project is recorded with its ID, name, language, package manager, path
+relative to its workspace root, git remote, current branch and tags. The
+manifest is written to stdout unless a file is given.
+
+Examples:
+  pkt export > workspace.json
+  pkt export ~/Dropbox/workspace.json`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		roots := make(map[string]string)
+		export := &utils.WorkspaceExport{
+			Version:    utils.ExportVersion,
+			ExportedAt: time.Now().UTC().Truncate(time.Second),
+			Roots:      make(map[string]string),
+			Projects:   []utils.ExportedProject{},
+		}
+		for _, name := range cfg.RootNames() {
+			dir, err := workspaceRoot(cfg, name)
+			if err != nil {
+				return err
+			}
+			roots[name] = dir
+			export.Roots[name] = utils.ShortPath(dir)
+		}
+
+		projects, err := db.ListProjects(db.ProjectFilter{IncludeArchived: true})
+		if err != nil {
+			return fmt.Errorf("failed to list projects: %w", err)
+		}
+
+		// Workspace roots come before their members so they are imported first
+		for _, p := range groupWorkspaceMembers(projects) {
+			export.Projects = append(export.Projects, exportProject(p, roots))
+		}
+
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode export: %w", err)
+		}
+		data = append(data, '\n')
+
+		if len(args) == 0 {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(args[0], data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", args[0], err)
+		}
+		fmt.Printf("✓ Exported %d projects to %s\n", len(export.Projects), args[0])
+		return nil
+	},
+}
+
+// exportProject describes a project relative to the workspace root containing it
+func exportProject(p *db.Project, roots map[string]string) utils.ExportedProject {
+	root, path := utils.RelativeToRoot(p.Path, roots)
+	if root == "" {
+		path = utils.ShortPath(p.Path)
+	}
+
+	exported := utils.ExportedProject{
+		ID:             p.ID,
+		Name:           p.Name,
+		Language:       p.Language,
+		PackageManager: p.PackageManager,
+		Root:           root,
+		Path:           path,
+		Parent:         p.ParentID,
+		GitRemote:      p.GitRemote,
+		Archived:       p.IsArchived(),
+	}
+	exported.Tags, _ = db.GetProjectTags(p.ID)
+
+	if !p.IsArchived() && !p.IsMember() && utils.IsGitRepo(p.Path) {
+		if remote := utils.GitRemote(p.Path); remote != "" {
+			exported.GitRemote = remote
+		}
+		exported.Branch = utils.GitBranch(p.Path)
+	}
+	return exported
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fetchLangFilter string
+	fetchTagFilter  string
+	fetchJobs       int
+)
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Fetch every tracked git repository",
+	Long: `Run 'git fetch' in every tracked git repository, or in a --lang/--tag subset,
+concurrently, then report how each branch compares to its upstream.
+
+Fetching never touches the working tree, so repositories with uncommitted
+changes are fetched too. Bring branches up to date with 'pkt pull'.
+
+Examples:
+  pkt fetch
+  pkt fetch -t client-acme
+  pkt fetch -l go --jobs 4`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repos, err := selectGitRepos(fetchLangFilter, fetchTagFilter)
+		if err != nil {
+			return err
+		}
+		if len(repos) == 0 && !output.Structured() {
+			fmt.Println("No git repositories found in tracked projects.")
+			return nil
+		}
+
+		if !output.Structured() {
+			fmt.Printf("🔄 Fetching %d repositories...\n\n", len(repos))
+		}
+
+		records := utils.ParallelMap(repos, fetchJobs, fetchProject)
+		return printGitSync(records, []string{"behind", "diverged", "current", "fetched", "failed"})
+	},
+}
+
+// gitSyncRecord is the result of fetching or pulling a repository, also used for
+// --output json, yaml and csv
+type gitSyncRecord struct {
+	Project    string `json:"project" yaml:"project"`
+	Path       string `json:"path" yaml:"path"`
+	Branch     string `json:"branch" yaml:"branch"`
+	Result     string `json:"result" yaml:"result"` // updated, current, behind, diverged, fetched, skipped or failed
+	Ahead      int    `json:"ahead" yaml:"ahead"`
+	Behind     int    `json:"behind" yaml:"behind"`
+	Pulled     int    `json:"pulled" yaml:"pulled"` // Commits pulled
+	DepsSynced bool   `json:"deps_synced" yaml:"deps_synced"`
+	Message    string `json:"message" yaml:"message"` // Why it was skipped or failed
+
+	resync bool // A pull changed a manifest or lockfile
+}
+
+// fetchProject fetches a repository and compares its branch to the upstream
+func fetchProject(p *db.Project) gitSyncRecord {
+	record := gitSyncRecord{Project: p.Name, Path: p.Path}
+
+	if err := utils.GitFetch(p.Path); err != nil {
+		return record.failed(err)
+	}
+	status, err := utils.ReadGitStatus(p.Path)
+	if err != nil {
+		return record.failed(err)
+	}
+	record.setStatus(status)
+
+	switch {
+	case status.Detached:
+		record.Result, record.Message = "fetched", "detached HEAD"
+	case status.Upstream == "":
+		record.Result, record.Message = "fetched", "no upstream"
+	case status.UpstreamGone:
+		record.Result, record.Message = "fetched", "upstream gone"
+	case status.Ahead > 0 && status.Behind > 0:
+		record.Result = "diverged"
+	case status.Behind > 0:
+		record.Result = "behind"
+	default:
+		record.Result = "current"
+	}
+	return record
+}
+
+// setStatus records the branch and its position against the upstream
+func (r *gitSyncRecord) setStatus(status *utils.GitStatus) {
+	r.Branch = status.Branch
+	r.Ahead = status.Ahead
+	r.Behind = status.Behind
+}
+
+// failed marks the record as failed with err
+func (r gitSyncRecord) failed(err error) gitSyncRecord {
+	r.Result = "failed"
+	r.Message = err.Error()
+	return r
+}
+
+// skipped marks the record as skipped for reason
+func (r gitSyncRecord) skipped(reason string) gitSyncRecord {
+	r.Result = "skipped"
+	r.Message = reason
+	return r
+}
+
+// details describes the record for the table
+func (r gitSyncRecord) details() string {
+	var parts []string
+	if r.Pulled > 0 {
+		parts = append(parts, fmt.Sprintf("%d new commit(s)", r.Pulled))
+	}
+	if r.DepsSynced {
+		parts = append(parts, "dependencies resynced")
+	}
+	if r.Result == "behind" || r.Result == "diverged" || (r.Result == "current" && r.Ahead > 0) {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%s %s", countLabel("↑", r.Ahead), countLabel("↓", r.Behind))))
+	}
+	if r.Message != "" {
+		parts = append(parts, r.Message)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// countLabel formats a non-zero count with a prefix, e.g. "↑2"
+func countLabel(prefix string, n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s%d", prefix, n)
+}
+
+// gitSyncLabel colours a fetch or pull result
+func gitSyncLabel(result string) string {
+	switch result {
+	case "updated", "current":
+		return output.Color("32", result)
+	case "behind", "fetched", "skipped":
+		return output.Color("33", result)
+	default:
+		return output.Color("31", result)
+	}
+}
+
+// selectGitRepos returns the root projects matching --lang and --tag that are git repositories
+func selectGitRepos(langFilter, tagFilter string) ([]*db.Project, error) {
+	projects, err := selectRootProjects(langFilter, tagFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []*db.Project
+	for _, p := range projects {
+		if utils.IsGitRepo(p.Path) {
+			repos = append(repos, p)
+		}
+	}
+	return repos, nil
+}
+
+// printGitSync prints fetch or pull results with a summary counting each result in
+// order, and fails when any repository failed
+func printGitSync(records []gitSyncRecord, order []string) error {
+	counts := make(map[string]int)
+	for _, record := range records {
+		counts[record.Result]++
+	}
+
+	if output.Structured() {
+		if err := output.Print(records); err != nil {
+			return err
+		}
+	} else {
+		table := output.NewTable("PROJECT", "RESULT", "BRANCH", "DETAILS")
+		for _, record := range records {
+			table.Add(record.Project, gitSyncLabel(record.Result), orDash(record.Branch), record.details())
+		}
+		if err := table.Write(os.Stdout); err != nil {
+			return err
+		}
+
+		var summary []string
+		for _, result := range order {
+			if counts[result] > 0 {
+				summary = append(summary, fmt.Sprintf("%d %s", counts[result], result))
+			}
+		}
+		fmt.Printf("\n✓ %s\n", strings.Join(summary, ", "))
+	}
+
+	if counts["failed"] > 0 {
+		return fmt.Errorf("%d of %d repositories failed", counts["failed"], len(records))
+	}
+	return nil
+}
+
+func init() {
+	fetchCmd.Flags().StringVarP(&fetchLangFilter, "lang", "l", "", "Filter by language (js, py, go, rs)")
+	fetchCmd.Flags().StringVarP(&fetchTagFilter, "tag", "t", "", "Filter by tag")
+	fetchCmd.Flags().IntVarP(&fetchJobs, "jobs", "j", 0, "Number of repositories to fetch concurrently (default: number of CPUs)")
+	rootCmd.AddCommand(fetchCmd)
+}
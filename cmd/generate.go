@@ -7,6 +7,7 @@ import (
 
 	"github.com/genesix/pkt/internal/ai"
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -42,7 +43,7 @@ var generateCmd = &cobra.Command{
 			return fmt.Errorf("failed to Ask AI: %w", err)
 		}
 
-		fmt.Printf("\033[32m%s\033[0m\n\n", resp)
+		fmt.Printf("%s\n\n", output.Color("32", resp))
 		return nil
 	},
 }
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/genesix/pkt/internal/config"
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/lang"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/pm"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importInstall bool
+	importDryRun  bool
+	importJobs    int
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Reproduce a workspace from a 'pkt export' manifest",
+	Long: `Recreate the projects of a manifest written by 'pkt export', e.g. on a new machine.
+
+For each project of the manifest:
+  - a folder that already exists at its path is registered in place
+  - a missing folder is cloned from its git remote, on the exported branch
+  - a project without a git remote and without a folder is skipped
+
+Projects keep their ID and tags. Workspace roots unknown to this machine are
+added to the config with the directory recorded in the manifest. Workspace
+members are registered along with their workspace root, and archived projects
+are skipped.
+
+Examples:
+  pkt import workspace.json --dry-run
+  pkt import workspace.json --install --yes`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		export, err := utils.ReadExport(args[0])
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		roots, err := importRoots(cfg, export)
+		if err != nil {
+			return err
+		}
+
+		records := planImport(export, roots)
+
+		if importDryRun {
+			return printImport(records, "Dry run: nothing was cloned or registered.")
+		}
+
+		// Clone concurrently, then register one by one to keep database writes sequential
+		var clones []*importRecord
+		for _, record := range records {
+			if record.Result == "clone" {
+				clones = append(clones, record)
+			}
+		}
+		if len(clones) > 0 && !output.Structured() {
+			fmt.Printf("📥 Cloning %d repositories...\n", len(clones))
+		}
+		utils.ParallelMap(clones, importJobs, cloneImported)
+
+		for _, record := range records {
+			if record.Result == "clone" || record.Result == "register" {
+				registerImported(record, export, roots)
+			}
+		}
+
+		return printImport(records, "")
+	},
+}
+
+// importRecord is the plan and outcome for one project of a manifest, also used for
+// --output json, yaml and csv
+type importRecord struct {
+	ID      string `json:"id" yaml:"id"`
+	Project string `json:"project" yaml:"project"`
+	Path    string `json:"path" yaml:"path"`
+	Result  string `json:"result" yaml:"result"`   // cloned, registered, tracked, skipped or failed; clone or register in a dry run
+	Message string `json:"message" yaml:"message"` // Why it was skipped or failed
+
+	exported utils.ExportedProject
+}
+
+// importRoots returns the directory of every workspace root the manifest uses,
+// adding roots this machine does not know yet to the config
+func importRoots(cfg *config.Config, export *utils.WorkspaceExport) (map[string]string, error) {
+	roots := make(map[string]string)
+	added := false
+
+	for _, p := range export.Projects {
+		if p.Root == "" || roots[p.Root] != "" {
+			continue
+		}
+		if dir, err := workspaceRoot(cfg, p.Root); err == nil {
+			roots[p.Root] = dir
+			continue
+		}
+
+		exported, ok := export.Roots[p.Root]
+		if !ok {
+			return nil, fmt.Errorf("project %s uses workspace root %s, which the manifest does not define", p.Name, p.Root)
+		}
+		dir, err := utils.ExpandPath(exported)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand workspace root %s: %w", exported, err)
+		}
+		roots[p.Root] = dir
+
+		if importDryRun {
+			fmt.Printf("Would add workspace root '%s': %s\n", p.Root, dir)
+			continue
+		}
+		if cfg.Roots == nil {
+			cfg.Roots = make(map[string]string)
+		}
+		cfg.Roots[p.Root] = dir
+		added = true
+		fmt.Printf("✓ Workspace root '%s' set to: %s\n", p.Root, dir)
+	}
+
+	if added {
+		if err := config.Save(cfg); err != nil {
+			return nil, fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+	return roots, nil
+}
+
+// importPath returns where an exported project lives on this machine
+func importPath(p utils.ExportedProject, roots map[string]string) (string, error) {
+	if p.Root != "" {
+		return filepath.Join(roots[p.Root], filepath.FromSlash(p.Path)), nil
+	}
+	return utils.ExpandPath(p.Path)
+}
+
+// planImport decides what to do with each top-level project of the manifest
+func planImport(export *utils.WorkspaceExport, roots map[string]string) []*importRecord {
+	var records []*importRecord
+	for _, p := range export.Projects {
+		if p.Parent != "" {
+			continue
+		}
+
+		record := &importRecord{ID: p.ID, Project: p.Name, exported: p}
+		records = append(records, record)
+
+		path, err := importPath(p, roots)
+		if err != nil {
+			record.Result, record.Message = "failed", err.Error()
+			continue
+		}
+		record.Path = path
+
+		if existing, err := db.GetProjectByID(p.ID); p.ID != "" && err == nil {
+			record.Result, record.Message = "tracked", "already tracked at "+utils.ShortPath(existing.Path)
+			continue
+		}
+		if existing, err := db.GetProjectByPath(path); err == nil {
+			record.Result, record.Message = "tracked", "already tracked as "+existing.Name
+			continue
+		}
+
+		switch {
+		case p.Archived:
+			record.Result, record.Message = "skipped", "archived"
+		case isDir(path):
+			record.Result = "register"
+		case p.GitRemote != "":
+			record.Result = "clone"
+		default:
+			record.Result, record.Message = "skipped", "folder not found and no git remote to clone"
+		}
+	}
+	return records
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// cloneImported clones a project's repository, on its exported branch when it still
+// exists on the remote, and records a failure on the record
+func cloneImported(record *importRecord) *importRecord {
+	p := record.exported
+	if err := os.MkdirAll(filepath.Dir(record.Path), 0755); err != nil {
+		record.Result, record.Message = "failed", err.Error()
+		return record
+	}
+
+	err := utils.GitClone(p.GitRemote, record.Path, p.Branch)
+	if err != nil && p.Branch != "" {
+		// The branch may only have existed locally
+		_ = os.RemoveAll(record.Path)
+		if err = utils.GitClone(p.GitRemote, record.Path, ""); err == nil {
+			record.Message = fmt.Sprintf("branch %s not found, cloned the default branch", p.Branch)
+		}
+	}
+	if err != nil {
+		_ = os.RemoveAll(record.Path)
+		record.Result, record.Message = "failed", err.Error()
+	}
+	return record
+}
+
+// registerImported tracks a cloned or existing project with its exported ID and tags,
+// tags its workspace members and optionally installs its dependencies
+func registerImported(record *importRecord, export *utils.WorkspaceExport, roots map[string]string) {
+	p := record.exported
+	cloned := record.Result == "clone"
+
+	language := p.Language
+	if _, err := lang.Get(language); err != nil {
+		detected, err := lang.Detect(record.Path)
+		if err != nil {
+			record.Result, record.Message = "failed", fmt.Sprintf("unknown language %s", p.Language)
+			return
+		}
+		language = detected.Name()
+	}
+	packageManager := p.PackageManager
+	if packageManager == "" {
+		l, _ := lang.Get(language)
+		packageManager = l.DetectPackageManager(record.Path)
+	}
+
+	id := p.ID
+	if id == "" {
+		id = utils.GenerateID()
+	}
+	tracked, err := trackProject(id, p.Name, record.Path, language, packageManager)
+	if err != nil {
+		record.Result, record.Message = "failed", err.Error()
+		return
+	}
+	project := tracked.Project
+	if cloned {
+		record.Result = "cloned"
+	} else {
+		record.Result = "registered"
+	}
+
+	if len(p.Tags) > 0 {
+		if err := db.AddTags(project.ID, p.Tags...); err != nil {
+			output.Warnf("%s: failed to add tags: %v", project.Name, err)
+		}
+	}
+	tagImportedMembers(p, tracked.Members, export, roots)
+
+	if importInstall {
+		fmt.Printf("📦 Installing dependencies of %s...\n", project.Name)
+		manager, err := pm.Get(project.Language, project.PackageManager)
+		if err == nil {
+			err = manager.Install(project.Path)
+		}
+		if err != nil {
+			output.Warnf("%s: failed to install dependencies: %v", project.Name, err)
+		}
+	}
+}
+
+// tagImportedMembers copies the tags of exported workspace members to the members
+// found again in the imported workspace root, matched by path
+func tagImportedMembers(root utils.ExportedProject, members []*db.Project, export *utils.WorkspaceExport, roots map[string]string) {
+	byPath := make(map[string]*db.Project, len(members))
+	for _, member := range members {
+		byPath[member.Path] = member
+	}
+
+	for _, p := range export.Projects {
+		if p.Parent != root.ID || len(p.Tags) == 0 {
+			continue
+		}
+		path, err := importPath(p, roots)
+		if err != nil {
+			continue
+		}
+		if member, ok := byPath[path]; ok {
+			if err := db.AddTags(member.ID, p.Tags...); err != nil {
+				output.Warnf("%s: failed to add tags: %v", member.Name, err)
+			}
+		}
+	}
+}
+
+// printImport prints the import results with a summary, and fails when any project failed
+func printImport(records []*importRecord, note string) error {
+	counts := make(map[string]int)
+	for _, record := range records {
+		counts[record.Result]++
+	}
+
+	if output.Structured() {
+		if err := output.Print(records); err != nil {
+			return err
+		}
+	} else {
+		if len(records) == 0 {
+			fmt.Println("The manifest has no projects.")
+			return nil
+		}
+
+		table := output.NewTable("PROJECT", "RESULT", "PATH", "DETAILS")
+		for _, record := range records {
+			table.Add(record.Project, importLabel(record.Result), utils.ShortPath(record.Path), orDash(record.Message))
+		}
+		fmt.Println()
+		if err := table.Write(os.Stdout); err != nil {
+			return err
+		}
+
+		var summary []string
+		for _, result := range []string{"clone", "register", "cloned", "registered", "tracked", "skipped", "failed"} {
+			if counts[result] > 0 {
+				summary = append(summary, fmt.Sprintf("%d %s", counts[result], result))
+			}
+		}
+		fmt.Printf("\n✓ %s\n", strings.Join(summary, ", "))
+		if note != "" {
+			fmt.Println(note)
+		}
+	}
+
+	if counts["failed"] > 0 {
+		return fmt.Errorf("%d of %d projects could not be imported", counts["failed"], len(records))
+	}
+	return nil
+}
+
+// importLabel colours an import result
+func importLabel(result string) string {
+	switch result {
+	case "cloned", "registered", "tracked":
+		return output.Color("32", result)
+	case "failed":
+		return output.Color("31", result)
+	default:
+		return output.Color("33", result)
+	}
+}
+
+func init() {
+	importCmd.Flags().BoolVarP(&importInstall, "install", "i", false, "Install dependencies of every cloned or registered project")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Only show what would be cloned and registered")
+	importCmd.Flags().IntVarP(&importJobs, "jobs", "j", 0, "Number of repositories to clone concurrently (default: number of CPUs)")
+	rootCmd.AddCommand(importCmd)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/dustin/go-humanize"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -24,8 +25,17 @@ var infoCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if err := utils.CheckNotArchived(project); err != nil {
+			return err
+		}
 
 		infoText := extractProjectInfo(project.Path)
+		if output.Structured() {
+			size, _ := utils.GetDirSize(project.Path)
+			record := infoRecord{ID: project.ID, Name: project.Name, Path: project.Path, SizeBytes: size, Info: infoText}
+			return output.PrintDocument(record, []infoRecord{record})
+		}
+
 		if infoText == "" {
 			fmt.Println("No info for this project.")
 			return nil
@@ -39,13 +49,24 @@ var infoCmd = &cobra.Command{
 		fmt.Printf("Size: %s\n", sizeStr)
 		fmt.Printf("Path: %s\n", utils.ShortPath(project.Path))
 		fmt.Println(strings.Repeat("-", 40))
-		fmt.Println(infoText)
+		fmt.Println(prettifyMarkdown(infoText))
 		fmt.Println(strings.Repeat("-", 40))
 
 		return nil
 	},
 }
 
+// infoRecord is a project's description in --output json, yaml and csv
+type infoRecord struct {
+	ID        string `json:"id" yaml:"id"`
+	Name      string `json:"name" yaml:"name"`
+	Path      string `json:"path" yaml:"path"`
+	SizeBytes int64  `json:"size_bytes" yaml:"size_bytes"`
+	Info      string `json:"info" yaml:"info"` // Opening paragraphs of the README or release notes, as markdown
+}
+
+// extractProjectInfo returns the opening paragraphs of a project's README, release notes
+// or first markdown file
 func extractProjectInfo(projectPath string) string {
 	entries, err := os.ReadDir(projectPath)
 	if err != nil {
@@ -136,10 +157,15 @@ func extractProjectInfo(projectPath string) string {
 		}
 	}
 
-	return prettifyMarkdown(strings.TrimSpace(strings.Join(capturedLines, "\n")))
+	return strings.TrimSpace(strings.Join(capturedLines, "\n"))
 }
 
+// prettifyMarkdown renders headings, emphasis and code with ANSI colours, when colour is enabled
 func prettifyMarkdown(text string) string {
+	if !output.ColorEnabled() {
+		return text
+	}
+
 	lines := strings.Split(text, "\n")
 	for i, line := range lines {
 		if strings.HasPrefix(line, "# ") {
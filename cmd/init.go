@@ -2,13 +2,11 @@ package cmd
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/genesix/pkt/internal/config"
 	"github.com/genesix/pkt/internal/db"
 	"github.com/genesix/pkt/internal/lang"
@@ -24,12 +22,18 @@ This command takes an existing project folder and adds it to pkt's tracking syst
 
 Supports: JavaScript, Python, Go, Rust projects.
 
-If the project is outside pkt's projects folder, it will be moved there.
-The project language is auto-detected from manifest files.
+If the project is outside every workspace root, it will be moved into the
+projects folder, or into the root chosen with --root. Use --in-place (or
+'pkt config in_place true') to register it where it is without moving it.
+The project language is auto-detected from manifest files; when there are
+none, it is asked for, or taken from --lang.
 
 Examples:
   pkt init .                        # Initialize current directory
-  pkt init /path/to/my-project      # Initialize a specific project`,
+  pkt init /path/to/my-project      # Initialize a specific project
+  pkt init . --in-place             # Track without moving the folder
+  pkt init ~/Downloads/lib --root oss  # Move into the "oss" workspace root
+  pkt init ./scripts --lang py      # Language for a folder without manifest`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get the path (default to current directory)
@@ -58,35 +62,24 @@ Examples:
 
 		// Auto-detect project language
 		detectedLang, err := lang.Detect(absPath)
-		if err != nil {
+		if err != nil && initLang != "" {
+			detectedLang, err = lang.Get(initLang)
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			if utils.NonInteractive() {
+				return fmt.Errorf("could not detect the project language (no manifest file found), pass it with --lang (js, py, go, rs)")
+			}
+
 			// Detection failed, prompt user to select language
 			fmt.Println("⚠️  Could not auto-detect project type.")
 			fmt.Println("   No manifest file found (package.json, requirements.txt, go.mod, Cargo.toml)")
 			fmt.Println()
 
-			langOptions := []string{
-				"js - JavaScript/Node.js",
-				"py - Python",
-				"go - Go",
-				"rs - Rust",
-			}
-			var selected string
-			prompt := &survey.Select{
-				Message: "Select project language:",
-				Options: langOptions,
-				Default: langOptions[0],
-			}
-			if err := survey.AskOne(prompt, &selected); err != nil {
-				return fmt.Errorf("cancelled: %w", err)
-			}
-
-			// Extract language code from selection
-			var langCode string
-			for i := 0; i < len(selected); i++ {
-				if selected[i] == ' ' {
-					langCode = selected[:i]
-					break
-				}
+			langCode, err := promptLanguage()
+			if err != nil {
+				return err
 			}
 
 			detectedLang, err = lang.Get(langCode)
@@ -119,21 +112,29 @@ Examples:
 		// Determine final path
 		finalPath := absPath
 
-		// Check if project is outside pkt's projects folder
-		projectsRoot, err := utils.ExpandPath(cfg.ProjectsRoot)
-		if err != nil {
-			return fmt.Errorf("failed to expand projects root: %w", err)
+		if initInPlace && initRoot != "" {
+			return fmt.Errorf("cannot use --in-place together with --root")
 		}
 
-		// Ensure both paths are clean for proper comparison
-		projectsRoot = filepath.Clean(projectsRoot)
+		// Check if project is outside pkt's projects folder, or the root chosen with --root
+		projectsRoot, err := workspaceRoot(cfg, initRoot)
+		if err != nil {
+			return err
+		}
 		cleanAbsPath := filepath.Clean(absPath)
 
-		// Check if already inside workspace (add trailing separator for proper prefix check)
-		insideWorkspace := strings.HasPrefix(cleanAbsPath, projectsRoot+string(filepath.Separator)) ||
-			cleanAbsPath == projectsRoot
+		// A project inside any workspace root stays there unless --root picks another one
+		insideWorkspace := isInsideDir(cleanAbsPath, projectsRoot)
+		if initRoot == "" {
+			insideWorkspace = insideWorkspaceRoot(cfg, cleanAbsPath)
+		}
+
+		// In place: track the folder where it is, without moving or renaming it
+		inPlace := initInPlace || (cfg.InitInPlace && initRoot == "")
 
-		if !insideWorkspace {
+		if inPlace {
+			fmt.Printf("📌 Registering in place: %s\n", utils.ShortPath(finalPath))
+		} else if !insideWorkspace {
 			fmt.Printf("📦 Project is outside pkt workspace, moving to %s...\n", projectsRoot)
 
 			// Use custom name if provided, otherwise use original directory name
@@ -150,7 +151,7 @@ Examples:
 			}
 
 			// Move the project
-			if err := moveProject(absPath, targetPath); err != nil {
+			if err := utils.MoveDir(absPath, targetPath); err != nil {
 				return fmt.Errorf("failed to move project: %w", err)
 			}
 
@@ -171,27 +172,11 @@ Examples:
 			fmt.Printf("✓ Renamed to: %s\n", finalPath)
 		}
 
-		// Generate project ID
-		projectID := utils.GenerateID()
-
-		// Insert project into database
-		project, err := db.CreateProject(projectID, projectName, finalPath, detectedLang.Name(), packageManager)
-		if err != nil {
-			return fmt.Errorf("failed to add project to database: %w", err)
-		}
-
-		// Sync dependencies based on language
-		depCount := 0
-		deps, err := utils.ParseDependencies(finalPath, detectedLang.Name())
+		tracked, err := trackProject(utils.GenerateID(), projectName, finalPath, detectedLang.Name(), packageManager)
 		if err != nil {
-			fmt.Printf("⚠️  Warning: failed to parse dependencies: %v\n", err)
-		} else if len(deps) > 0 {
-			if err := db.SyncDependencies(project.ID, deps); err != nil {
-				fmt.Printf("⚠️  Warning: failed to sync dependencies: %v\n", err)
-			} else {
-				depCount = len(deps)
-			}
+			return err
 		}
+		project := tracked.Project
 
 		fmt.Println()
 		fmt.Printf("✓ Initialized %s project: %s\n", detectedLang.DisplayName(), projectName)
@@ -199,8 +184,14 @@ Examples:
 		fmt.Printf("  Path: %s\n", project.Path)
 		fmt.Printf("  Language: %s\n", detectedLang.DisplayName())
 		fmt.Printf("  Package Manager: %s\n", project.PackageManager)
-		if depCount > 0 {
-			fmt.Printf("  Dependencies: %d synced\n", depCount)
+		if tracked.DepCount > 0 {
+			fmt.Printf("  Dependencies: %d synced\n", tracked.DepCount)
+		}
+		if len(tracked.Members) > 0 {
+			fmt.Printf("  Workspace members: %d tracked\n", len(tracked.Members))
+		}
+		if len(tracked.Components) > 0 {
+			fmt.Printf("  Components: %s\n", componentSummary(tracked.Components))
 		}
 
 		if initOpen && cfg.EditorCommand != "" {
@@ -216,6 +207,24 @@ Examples:
 	},
 }
 
+// promptLanguage asks for a project language and returns its short code
+func promptLanguage() (string, error) {
+	langOptions := []string{
+		"js - JavaScript/Node.js",
+		"py - Python",
+		"go - Go",
+		"rs - Rust",
+	}
+	selected, err := utils.Select("Select project language:", langOptions, langOptions[0])
+	if err != nil {
+		return "", err
+	}
+
+	// Extract language code from selection
+	code, _, _ := strings.Cut(selected, " ")
+	return code, nil
+}
+
 // getUniqueFolder returns a unique folder path, appending numbers if needed
 func getUniqueFolder(basePath string) (string, error) {
 	path := basePath
@@ -228,89 +237,49 @@ func getUniqueFolder(basePath string) (string, error) {
 	return "", fmt.Errorf("could not find unique folder name after 100 attempts")
 }
 
-// moveProject moves a project directory to a new location
-func moveProject(src, dst string) error {
-	// Try simple rename first (works if on same filesystem)
-	if err := os.Rename(src, dst); err == nil {
-		return nil
-	}
-
-	// If rename fails, do a copy then delete
-	if err := copyDir(src, dst); err != nil {
-		return fmt.Errorf("failed to copy directory: %w", err)
-	}
-
-	if err := os.RemoveAll(src); err != nil {
-		return fmt.Errorf("failed to remove source directory: %w", err)
-	}
-
-	return nil
-}
-
-// copyDir recursively copies a directory
-func copyDir(src, dst string) error {
-	srcInfo, err := os.Stat(src)
+// workspaceRoot returns the expanded directory of a workspace root by name;
+// an empty name selects projects_root
+func workspaceRoot(cfg *config.Config, name string) (string, error) {
+	dir, err := cfg.Root(name)
 	if err != nil {
-		return err
+		return "", err
 	}
-
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
-		return err
-	}
-
-	entries, err := os.ReadDir(src)
+	expanded, err := utils.ExpandPath(dir)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("failed to expand workspace root %s: %w", dir, err)
 	}
+	return filepath.Clean(expanded), nil
+}
 
-	for _, entry := range entries {
-		srcPath := filepath.Join(src, entry.Name())
-		dstPath := filepath.Join(dst, entry.Name())
-
-		if entry.IsDir() {
-			if err := copyDir(srcPath, dstPath); err != nil {
-				return err
-			}
-		} else {
-			if err := copyFile(srcPath, dstPath); err != nil {
-				return err
-			}
+// insideWorkspaceRoot reports whether path is inside any configured workspace root
+func insideWorkspaceRoot(cfg *config.Config, path string) bool {
+	for _, name := range cfg.RootNames() {
+		if root, err := workspaceRoot(cfg, name); err == nil && isInsideDir(path, root) {
+			return true
 		}
 	}
-
-	return nil
+	return false
 }
 
-// copyFile copies a single file
-func copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = srcFile.Close() }()
-
-	srcInfo, err := srcFile.Stat()
-	if err != nil {
-		return err
-	}
-
-	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, srcInfo.Mode())
-	if err != nil {
-		return err
-	}
-	defer func() { _ = dstFile.Close() }()
-
-	_, err = io.Copy(dstFile, srcFile)
-	return err
+// isInsideDir reports whether path is dir or one of its descendants
+func isInsideDir(path, dir string) bool {
+	// Add trailing separator for proper prefix check
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
 }
 
 var (
-	initName string
-	initOpen bool
+	initName    string
+	initOpen    bool
+	initInPlace bool
+	initRoot    string
+	initLang    string
 )
 
 func init() {
 	initCmd.Flags().StringVarP(&initName, "name", "n", "", "Custom project name (default: directory name)")
 	initCmd.Flags().BoolVarP(&initOpen, "open", "o", false, "Open project in editor after initialization")
+	initCmd.Flags().BoolVar(&initInPlace, "in-place", false, "Register the project where it is instead of moving it")
+	initCmd.Flags().StringVarP(&initRoot, "root", "r", "", "Workspace root to move the project into (see 'pkt config')")
+	initCmd.Flags().StringVarP(&initLang, "lang", "l", "", "Language to use when it cannot be detected (js, py, go, rs)")
 	rootCmd.AddCommand(initCmd)
 }
@@ -4,19 +4,22 @@ import (
 	"fmt"
 	"os"
 	"regexp"
-	"text/tabwriter"
+	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/genesix/pkt/internal/db"
-	"github.com/genesix/pkt/internal/lang"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var (
 	listLangFilter string
+	listTagFilter  string
 	listNameFilter string
 	listAllFlag    bool
+	listArchived   bool
 )
 
 var listCmd = &cobra.Command{
@@ -24,25 +27,25 @@ var listCmd = &cobra.Command{
 	Short: "List all tracked projects",
 	Long: `List all projects tracked by pkt with their details.
 
-Use --lang to filter by language (js, py, go, rs).
+Use --lang to filter by language (js, py, go, rs) and --tag to filter by tag.
+Archived projects are hidden unless --archived is given.
+Use --output json, yaml or csv for machine-readable output.
 
 Examples:
   pkt list            # All projects
   pkt list -l js      # JavaScript projects only
-  pkt list -l py      # Python projects only`,
+  pkt list -l py      # Python projects only
+  pkt list -t infra   # Projects tagged "infra"
+  pkt list --archived # Include archived projects
+  pkt list --output json | jq -r '.[].path'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var projects []*db.Project
-		var err error
-
-		// Filter by language if specified
-		if listLangFilter != "" {
-			// Normalize short codes to full names (js -> javascript)
-			fullName := lang.NormalizeName(listLangFilter)
-			projects, err = db.GetProjectsByLanguage(fullName)
-		} else {
-			projects, err = db.ListAllProjects()
+		// Filter by language and tag if specified
+		filter, err := projectFilter(listLangFilter, listTagFilter)
+		if err != nil {
+			return err
 		}
-
+		filter.IncludeArchived = listArchived
+		projects, err := db.ListProjects(filter)
 		if err != nil {
 			return fmt.Errorf("failed to list projects: %w", err)
 		}
@@ -61,8 +64,18 @@ Examples:
 			projects = filtered
 		}
 
+		if output.Structured() {
+			records := make([]projectRecord, 0, len(projects))
+			for _, project := range groupWorkspaceMembers(projects) {
+				records = append(records, newProjectRecord(project, listAllFlag))
+			}
+			return output.Print(records)
+		}
+
 		if len(projects) == 0 {
-			if listLangFilter != "" {
+			if listTagFilter != "" {
+				fmt.Printf("No projects tagged %q found.\n", listTagFilter)
+			} else if listLangFilter != "" {
 				fmt.Printf("No %s projects found.\n", listLangFilter)
 			} else {
 				fmt.Println("No projects found.")
@@ -71,47 +84,121 @@ Examples:
 			return nil
 		}
 
-		// Create table writer
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-
+		table := output.NewTable("NAME", "LANG", "PATH")
 		if listAllFlag {
-			_, _ = fmt.Fprintln(w, "NAME\tLANG\tPM\tID\tSIZE\tPATH")
-			_, _ = fmt.Fprintln(w, "----\t----\t--\t--\t----\t----")
-		} else {
-			_, _ = fmt.Fprintln(w, "NAME\tLANG\tPATH")
-			_, _ = fmt.Fprintln(w, "----\t----\t----")
+			table = output.NewTable("NAME", "LANG", "PM", "ID", "SIZE", "TAGS", "PATH")
 		}
 
-		for _, project := range projects {
+		for _, project := range groupWorkspaceMembers(projects) {
 			// Convert full language name to short code for display
 			shortLang := langToShort(project.Language)
 
+			// Members listed under their workspace root are indented
+			name := project.Name
+			if project.IsMember() {
+				name = "  └ " + name
+			}
+			path := project.Path
+			if project.IsArchived() {
+				name += output.Color("90", " (archived)")
+				path = project.ArchivePath
+			}
+
 			if listAllFlag {
-				sizeBytes, _ := utils.GetDirSize(project.Path)
-				sizeStr := humanize.Bytes(uint64(sizeBytes))
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-					project.Name,
-					shortLang,
-					project.PackageManager,
-					project.ID,
-					sizeStr,
-					utils.ShortPath(project.Path),
-				)
+				sizeStr := humanize.Bytes(uint64(projectSize(project)))
+				tags, _ := db.GetProjectTags(project.ID)
+				tagsStr := strings.Join(tags, ",")
+				if tagsStr == "" {
+					tagsStr = "-"
+				}
+				table.Add(name, shortLang, project.PackageManager, project.ID, sizeStr, tagsStr, utils.ShortPath(path))
 			} else {
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n",
-					project.Name,
-					shortLang,
-					utils.ShortPath(project.Path),
-				)
+				table.Add(name, shortLang, utils.ShortPath(path))
 			}
 		}
 
-		_ = w.Flush()
-
-		return nil
+		return table.Write(os.Stdout)
 	},
 }
 
+// projectRecord is a tracked project in --output json, yaml and csv
+type projectRecord struct {
+	ID             string    `json:"id" yaml:"id"`
+	Name           string    `json:"name" yaml:"name"`
+	Language       string    `json:"language" yaml:"language"`
+	PackageManager string    `json:"package_manager" yaml:"package_manager"`
+	Path           string    `json:"path" yaml:"path"`
+	ParentID       string    `json:"parent_id" yaml:"parent_id"` // Workspace root, empty for top-level projects
+	GitRemote      string    `json:"git_remote" yaml:"git_remote"`
+	ArchivePath    string    `json:"archive_path" yaml:"archive_path"` // Empty unless the project is archived
+	Tags           []string  `json:"tags" yaml:"tags"`
+	SizeBytes      *int64    `json:"size_bytes" yaml:"size_bytes"` // null unless asked for, it walks the folder
+	CreatedAt      time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// newProjectRecord builds the machine-readable record of a project, measuring its size if withSize is set
+func newProjectRecord(project *db.Project, withSize bool) projectRecord {
+	tags, _ := db.GetProjectTags(project.ID)
+	if tags == nil {
+		tags = []string{}
+	}
+	record := projectRecord{
+		ID:             project.ID,
+		Name:           project.Name,
+		Language:       project.Language,
+		PackageManager: project.PackageManager,
+		Path:           project.Path,
+		ParentID:       project.ParentID,
+		GitRemote:      project.GitRemote,
+		ArchivePath:    project.ArchivePath,
+		Tags:           tags,
+		CreatedAt:      project.CreatedAt,
+	}
+	if withSize {
+		size := projectSize(project)
+		record.SizeBytes = &size
+	}
+	return record
+}
+
+// projectSize returns the size of a project's folder, or of its archive while it is archived
+func projectSize(project *db.Project) int64 {
+	if project.IsArchived() {
+		if info, err := os.Stat(project.ArchivePath); err == nil {
+			return info.Size()
+		}
+		return 0
+	}
+	size, _ := utils.GetDirSize(project.Path)
+	return size
+}
+
+// groupWorkspaceMembers moves each workspace member right after its root, keeping
+// the order of top-level projects. Members whose root is not listed stay in place.
+func groupWorkspaceMembers(projects []*db.Project) []*db.Project {
+	listed := make(map[string]bool)
+	for _, project := range projects {
+		listed[project.ID] = true
+	}
+
+	members := make(map[string][]*db.Project)
+	for _, project := range projects {
+		if project.IsMember() && listed[project.ParentID] {
+			members[project.ParentID] = append(members[project.ParentID], project)
+		}
+	}
+
+	grouped := make([]*db.Project, 0, len(projects))
+	for _, project := range projects {
+		if project.IsMember() && listed[project.ParentID] {
+			continue
+		}
+		grouped = append(grouped, project)
+		grouped = append(grouped, members[project.ID]...)
+	}
+	return grouped
+}
+
 // langToShort converts full language name to short code
 func langToShort(language string) string {
 	switch language {
@@ -128,6 +215,8 @@ func langToShort(language string) string {
 
 func init() {
 	listCmd.Flags().StringVarP(&listLangFilter, "lang", "l", "", "Filter by language (js, py, go, rs)")
+	listCmd.Flags().StringVarP(&listTagFilter, "tag", "t", "", "Filter by tag")
 	listCmd.Flags().StringVarP(&listNameFilter, "filter", "f", "", "Filter projects by regex on their name")
 	listCmd.Flags().BoolVarP(&listAllFlag, "all", "a", false, "Show all details including ID and Package Manager")
+	listCmd.Flags().BoolVar(&listArchived, "archived", false, "Include archived projects")
 }
@@ -23,6 +23,9 @@ You can specify the project by name, ID, or use "." for the current directory.`,
 		if err != nil {
 			return err
 		}
+		if err := utils.CheckNotArchived(project); err != nil {
+			return err
+		}
 
 		// Load config for editor command
 		cfg, err := config.Load()
@@ -1,31 +1,110 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
 	"strings"
-	"text/tabwriter"
 
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/pm"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	outdatedAll        bool
+	outdatedTagFilter  string
+	outdatedLangFilter string
+	outdatedJobs       int
+	outdatedJSON       bool
+	outdatedComponent  string
+)
+
+// outdatedEntry is an outdated dependency of one project in a workspace-wide report
+type outdatedEntry struct {
+	Project        string `json:"project" yaml:"project"`
+	pm.OutdatedDep `yaml:",inline"`
+}
+
+// outdatedFailure records a project whose outdated check failed
+type outdatedFailure struct {
+	Project string `json:"project" yaml:"project"`
+	Path    string `json:"path" yaml:"path"`
+	Error   string `json:"error" yaml:"error"`
+}
+
+// outdatedReport is the aggregated result of checking several projects
+type outdatedReport struct {
+	Outdated []outdatedEntry   `json:"outdated" yaml:"outdated"`
+	Failures []outdatedFailure `json:"failures" yaml:"failures"`
+}
+
+// outdatedTarget is a directory checked with one package manager: a project's
+// primary language or one of its components
+type outdatedTarget struct {
+	Name           string // Project name, suffixed with "/<component>" for components
+	Path           string
+	Language       string
+	PackageManager string
+}
+
+// projectTarget returns the outdated target for a project's primary language
+func projectTarget(project *db.Project, path string) outdatedTarget {
+	return outdatedTarget{Name: project.Name, Path: path, Language: project.Language, PackageManager: project.PackageManager}
+}
+
+// componentTarget returns the outdated target for a component of a polyglot project
+func componentTarget(project *db.Project, component *db.Component) outdatedTarget {
+	return outdatedTarget{
+		Name:           project.Name + "/" + component.Name,
+		Path:           component.Dir(project.Path),
+		Language:       component.Language,
+		PackageManager: component.PackageManager,
+	}
+}
+
 var outdatedCmd = &cobra.Command{
 	Use:   "outdated [project]",
 	Short: "Check for outdated dependencies",
 	Long: `Check for outdated dependencies in a project.
 If no project is specified, uses the current directory.
 
-Supports: JavaScript (npm/pnpm), Python (pip), Go, Rust
+With --all, --tag or --lang, every matching project is checked concurrently and
+the results are merged into one report. Projects that fail to check are listed
+in a summary instead of aborting the run. Components of polyglot projects are
+checked too; --component restricts the check to components with that name.
+
+Every package manager reports the same columns, and each update is classified
+as major, minor or patch. Major updates are listed first.
+
+Supports: JavaScript (npm/pnpm/bun), Python (pip/poetry/uv), Go, Rust
 
 Examples:
-  pkt outdated           # Check current project
-  pkt outdated my-app    # Check specific project`,
+  pkt outdated             # Check current project
+  pkt outdated my-app      # Check specific project
+  pkt outdated --json      # Machine-readable output (same as --output json)
+  pkt outdated --all       # Check every tracked project
+  pkt outdated --tag infra # Check every project tagged "infra"
+  pkt outdated -l go -j 8  # Check Go projects, 8 at a time
+  pkt outdated -c web      # Check the web/ component of the current project`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// --json predates --output and is kept as a shorthand
+		if outdatedJSON {
+			if err := output.SetFormat(string(output.FormatJSON)); err != nil {
+				return err
+			}
+		}
+
+		if outdatedAll || outdatedTagFilter != "" || outdatedLangFilter != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("cannot specify a project when using --all, --tag or --lang")
+			}
+			return checkOutdatedProjects()
+		}
+
 		var project *db.Project
 		var err error
 		var projectPath string
@@ -48,178 +127,201 @@ Examples:
 			if err != nil {
 				return err
 			}
+			if err := utils.CheckNotArchived(project); err != nil {
+				return err
+			}
 			projectPath = project.Path
 		}
 
-		fmt.Printf("📦 Checking outdated dependencies for %s...\n\n", project.Name)
+		target := projectTarget(project, projectPath)
+		if outdatedComponent != "" {
+			component, err := resolveComponent(project, outdatedComponent)
+			if err != nil {
+				return err
+			}
+			target = componentTarget(project, component)
+		}
 
-		// Run language-specific outdated check
-		switch project.Language {
-		case "javascript":
-			return checkOutdatedJS(projectPath, project.PackageManager)
-		case "python":
-			return checkOutdatedPython(projectPath, project.PackageManager)
-		case "go":
-			return checkOutdatedGo(projectPath)
-		case "rust":
-			return checkOutdatedRust(projectPath)
-		default:
-			return fmt.Errorf("outdated check not supported for %s", project.Language)
+		if !output.Structured() {
+			fmt.Printf("📦 Checking outdated dependencies for %s...\n\n", target.Name)
 		}
-	},
-}
 
-// JavaScript outdated check
-func checkOutdatedJS(workDir, pm string) error {
-	var cmd *exec.Cmd
-	switch pm {
-	case "npm":
-		cmd = exec.Command("npm", "outdated")
-	case "pnpm":
-		cmd = exec.Command("pnpm", "outdated")
-	case "bun":
-		// Bun doesn't have outdated, use npm
-		cmd = exec.Command("npm", "outdated")
-	default:
-		cmd = exec.Command("npm", "outdated")
-	}
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	// npm outdated returns exit code 1 if there are outdated packages
-	_ = cmd.Run()
-	return nil
-}
+		deps, err := checkOutdated(target)
+		if err != nil {
+			return err
+		}
 
-// Python outdated check
-func checkOutdatedPython(workDir, pm string) error {
-	var cmd *exec.Cmd
-	switch pm {
-	case "pip":
-		// Use venv pip if available
-		venvPip := workDir + "/venv/bin/pip"
-		if _, err := os.Stat(venvPip); err == nil {
-			cmd = exec.Command(venvPip, "list", "--outdated", "--format=json")
-		} else {
-			cmd = exec.Command("pip", "list", "--outdated", "--format=json")
-		}
-	case "poetry":
-		cmd = exec.Command("poetry", "show", "--outdated")
-		cmd.Dir = workDir
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
-	case "uv":
-		// uv doesn't have a direct outdated command yet
-		fmt.Println("Note: uv doesn't have a built-in outdated command.")
-		fmt.Println("Consider using: pip list --outdated")
+		if output.Structured() {
+			return output.Print(deps)
+		}
+		printOutdated(deps)
 		return nil
-	default:
-		cmd = exec.Command("pip", "list", "--outdated", "--format=json")
-	}
+	},
+}
 
-	cmd.Dir = workDir
-	output, err := cmd.Output()
+// checkOutdatedProjects checks every selected project concurrently and prints one report
+func checkOutdatedProjects() error {
+	projects, err := selectProjects(outdatedLangFilter, outdatedTagFilter)
 	if err != nil {
-		return fmt.Errorf("failed to check outdated: %w", err)
+		return err
 	}
 
-	// Parse JSON output
-	var outdated []struct {
-		Name           string `json:"name"`
-		Version        string `json:"version"`
-		LatestVersion  string `json:"latest_version"`
-		LatestFiletype string `json:"latest_filetype"`
-	}
-	if err := json.Unmarshal(output, &outdated); err != nil {
-		return fmt.Errorf("failed to parse outdated output: %w", err)
+	// Each project is checked along with the components of polyglot projects
+	var targets []outdatedTarget
+	for _, project := range projects {
+		if outdatedComponent == "" {
+			targets = append(targets, projectTarget(project, project.Path))
+		}
+		components, err := db.GetComponents(project.ID)
+		if err != nil {
+			return err
+		}
+		for _, component := range components {
+			if outdatedComponent == "" || component.Name == outdatedComponent {
+				targets = append(targets, componentTarget(project, component))
+			}
+		}
 	}
 
-	if len(outdated) == 0 {
-		fmt.Println("All packages are up to date! ✓")
+	if len(targets) == 0 {
+		if output.Structured() {
+			return output.PrintDocument(outdatedReport{Outdated: []outdatedEntry{}, Failures: []outdatedFailure{}}, []outdatedEntry{})
+		}
+		fmt.Println("No matching projects found.")
 		return nil
 	}
 
-	// Display in table format
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tCURRENT\tLATEST\tTYPE")
-	_, _ = fmt.Fprintln(w, "----\t-------\t------\t----")
-	for _, pkg := range outdated {
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\tprod\n", pkg.Name, pkg.Version, pkg.LatestVersion)
+	if !output.Structured() {
+		fmt.Printf("📦 Checking outdated dependencies for %d projects...\n\n", len(targets))
 	}
-	_ = w.Flush()
 
-	return nil
-}
-
-// Go outdated check
-func checkOutdatedGo(workDir string) error {
-	cmd := exec.Command("go", "list", "-u", "-m", "all")
-	cmd.Dir = workDir
-	output, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("failed to check outdated: %w", err)
+	type result struct {
+		deps []pm.OutdatedDep
+		err  error
 	}
+	results := utils.ParallelMap(targets, outdatedJobs, func(target outdatedTarget) result {
+		deps, err := checkOutdated(target)
+		return result{deps: deps, err: err}
+	})
 
-	lines := strings.Split(string(output), "\n")
-	hasOutdated := false
-
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tCURRENT\tLATEST")
-	_, _ = fmt.Fprintln(w, "----\t-------\t------")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	report := outdatedReport{Outdated: []outdatedEntry{}, Failures: []outdatedFailure{}}
+	for i, target := range targets {
+		if results[i].err != nil {
+			report.Failures = append(report.Failures, outdatedFailure{
+				Project: target.Name,
+				Path:    target.Path,
+				Error:   results[i].err.Error(),
+			})
 			continue
 		}
+		for _, dep := range results[i].deps {
+			report.Outdated = append(report.Outdated, outdatedEntry{Project: target.Name, OutdatedDep: dep})
+		}
+	}
+
+	sort.SliceStable(report.Outdated, func(i, j int) bool {
+		a, b := report.Outdated[i], report.Outdated[j]
+		if a.UpdateType.Severity() != b.UpdateType.Severity() {
+			return a.UpdateType.Severity() > b.UpdateType.Severity()
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Name < b.Name
+	})
 
-		// Look for lines with [version] which indicate updates available
-		if strings.Contains(line, "[") {
-			parts := strings.Fields(line)
-			if len(parts) >= 3 {
-				name := parts[0]
-				current := parts[1]
-				latest := strings.Trim(parts[2], "[]")
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", name, current, latest)
-				hasOutdated = true
+	if output.Structured() {
+		// CSV only holds the outdated rows, so failures are reported on stderr
+		if output.Current() == output.FormatCSV {
+			for _, failure := range report.Failures {
+				fmt.Fprintf(os.Stderr, "⚠️  Warning: %s could not be checked: %s\n", failure.Project, summarizeError(failure.Error))
 			}
 		}
+		return output.PrintDocument(report, report.Outdated)
 	}
 
-	if !hasOutdated {
+	if len(report.Outdated) == 0 {
 		fmt.Println("All packages are up to date! ✓")
-		return nil
+	} else {
+		table := output.NewTable("PROJECT", "NAME", "CURRENT", "WANTED", "LATEST", "UPDATE", "TYPE")
+		counts := make(map[pm.UpdateType]int)
+		for _, entry := range report.Outdated {
+			counts[entry.UpdateType]++
+			table.Add(entry.Project, entry.Name, orDash(entry.Current), orDash(entry.Wanted), entry.Latest, string(entry.UpdateType), entry.DepType)
+		}
+		_ = table.Write(os.Stdout)
+
+		fmt.Printf("\n%d outdated packages (%d major, %d minor, %d patch)\n",
+			len(report.Outdated), counts[pm.UpdateMajor], counts[pm.UpdateMinor], counts[pm.UpdatePatch])
+	}
+
+	fmt.Printf("✓ Checked %d of %d projects\n", len(targets)-len(report.Failures), len(targets))
+	if len(report.Failures) > 0 {
+		fmt.Printf("\n⚠️  Warning: %d project(s) could not be checked:\n", len(report.Failures))
+		for _, failure := range report.Failures {
+			fmt.Printf("  %s: %s\n", failure.Project, summarizeError(failure.Error))
+		}
 	}
 
-	_ = w.Flush()
 	return nil
 }
 
-// Rust outdated check
-func checkOutdatedRust(workDir string) error {
-	// Check if cargo-outdated is installed
-	if _, err := exec.LookPath("cargo-outdated"); err != nil {
-		fmt.Println("Note: Install cargo-outdated for better output:")
-		fmt.Println("  cargo install cargo-outdated")
-		fmt.Println()
-		fmt.Println("Using 'cargo update --dry-run' instead:")
-		fmt.Println()
-
-		cmd := exec.Command("cargo", "update", "--dry-run")
-		cmd.Dir = workDir
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
-	}
-
-	cmd := exec.Command("cargo", "outdated", "-R")
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// summarizeError shortens a package manager error to one line, preferring the
+// first line of the tool's own output over the bare exit status
+func summarizeError(message string) string {
+	message, output, found := strings.Cut(message, "\nOutput: ")
+	if found {
+		if line, _, _ := strings.Cut(strings.TrimSpace(output), "\n"); line != "" {
+			return line
+		}
+	}
+	message, _, _ = strings.Cut(message, "\n")
+	return message
+}
+
+// checkOutdated asks the target's package manager for outdated dependencies,
+// sorted with the most severe updates first
+func checkOutdated(target outdatedTarget) ([]pm.OutdatedDep, error) {
+	packageManager, err := pm.Get(target.Language, target.PackageManager)
+	if err != nil {
+		return nil, fmt.Errorf("outdated check not supported: %w", err)
+	}
+	if !packageManager.IsAvailable() {
+		return nil, fmt.Errorf("%s is not installed", packageManager.Name())
+	}
+
+	deps, err := packageManager.Outdated(target.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check outdated: %w", err)
+	}
+	if deps == nil {
+		deps = []pm.OutdatedDep{}
+	}
+
+	pm.SortOutdated(deps)
+	return deps, nil
+}
+
+// printOutdated renders outdated dependencies as a table
+func printOutdated(deps []pm.OutdatedDep) {
+	if len(deps) == 0 {
+		fmt.Println("All packages are up to date! ✓")
+		return
+	}
+
+	table := output.NewTable("NAME", "CURRENT", "WANTED", "LATEST", "UPDATE", "TYPE")
+	for _, dep := range deps {
+		table.Add(dep.Name, orDash(dep.Current), orDash(dep.Wanted), dep.Latest, string(dep.UpdateType), dep.DepType)
+	}
+	_ = table.Write(os.Stdout)
 }
 
 func init() {
+	outdatedCmd.Flags().BoolVarP(&outdatedAll, "all", "a", false, "Check every tracked project")
+	outdatedCmd.Flags().StringVarP(&outdatedTagFilter, "tag", "t", "", "Check every project with this tag")
+	outdatedCmd.Flags().StringVarP(&outdatedLangFilter, "lang", "l", "", "Check every project of this language")
+	outdatedCmd.Flags().IntVarP(&outdatedJobs, "jobs", "j", 0, "Number of projects to check concurrently (default: number of CPUs)")
+	outdatedCmd.Flags().BoolVar(&outdatedJSON, "json", false, "Output results as JSON (same as --output json)")
+	outdatedCmd.Flags().StringVarP(&outdatedComponent, "component", "c", "", "Check a component of a polyglot project")
 	rootCmd.AddCommand(outdatedCmd)
 }
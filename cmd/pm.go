@@ -40,6 +40,9 @@ Available package managers: pnpm, npm, bun`,
 		if err != nil {
 			return err
 		}
+		if err := utils.CheckNotArchived(project); err != nil {
+			return err
+		}
 
 		// Check if already using this PM
 		if project.PackageManager == newPM {
@@ -47,6 +50,10 @@ Available package managers: pnpm, npm, bun`,
 			return nil
 		}
 
+		if _, err := utils.AutoBackup("pm-set"); err != nil {
+			fmt.Printf("⚠️  Warning: failed to back up before changing the package manager: %v\n", err)
+		}
+
 		// Update database
 		if err := db.UpdateProjectPM(project.ID, newPM); err != nil {
 			return fmt.Errorf("failed to update package manager: %w", err)
@@ -59,14 +66,9 @@ Available package managers: pnpm, npm, bun`,
 		}
 
 		// Re-sync dependencies
-		deps, err := utils.ParsePackageJSON(project.Path)
-		if err != nil {
+		if _, err := utils.SyncProjectDependencies(project.ID, project.Path, project.Language); err != nil {
 			// Log warning but don't fail
-			fmt.Printf("Warning: failed to sync dependencies: %v\n", err)
-		} else {
-			if err := db.SyncDependencies(project.ID, deps); err != nil {
-				fmt.Printf("Warning: failed to sync dependencies: %v\n", err)
-			}
+			fmt.Printf("Warning: %v\n", err)
 		}
 
 		fmt.Printf("✓ Updated package manager for %s: %s → %s\n",
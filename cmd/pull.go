@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pullLangFilter string
+	pullTagFilter  string
+	pullJobs       int
+	pullFFOnly     bool
+)
+
+var pullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Pull every tracked git repository",
+	Long: `Fetch and update the current branch of every tracked git repository, or of a
+--lang/--tag subset, concurrently.
+
+Repositories with uncommitted changes to tracked files, a detached HEAD or no
+upstream are skipped; untracked files don't block a pull, and git refuses one
+that would overwrite them. Branches are only fast-forwarded; one that has diverged from its
+upstream is reported and left alone, unless --ff-only=false lets pkt merge it
+(a merge with conflicts is aborted). When a pull changes a manifest or lockfile
+the project's dependencies are synced again.
+
+Examples:
+  pkt pull
+  pkt pull --ff-only -t client-acme
+  pkt pull -l js --output json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repos, err := selectGitRepos(pullLangFilter, pullTagFilter)
+		if err != nil {
+			return err
+		}
+		if len(repos) == 0 && !output.Structured() {
+			fmt.Println("No git repositories found in tracked projects.")
+			return nil
+		}
+
+		if !output.Structured() {
+			fmt.Printf("🔄 Pulling %d repositories...\n\n", len(repos))
+		}
+
+		records := utils.ParallelMap(repos, pullJobs, func(p *db.Project) gitSyncRecord {
+			return pullProject(p, pullFFOnly)
+		})
+
+		// Database writes stay sequential
+		for i, record := range records {
+			if record.resync {
+				records[i].DepsSynced = resyncProject(repos[i])
+			}
+		}
+
+		return printGitSync(records, []string{"updated", "current", "diverged", "skipped", "failed"})
+	},
+}
+
+// pullProject fetches a clean repository and merges its upstream into the current branch
+func pullProject(p *db.Project, ffOnly bool) gitSyncRecord {
+	record := gitSyncRecord{Project: p.Name, Path: p.Path}
+
+	status, err := utils.ReadGitStatus(p.Path)
+	if err != nil {
+		return record.failed(err)
+	}
+	record.setStatus(status)
+
+	switch {
+	case status.Detached:
+		return record.skipped("detached HEAD")
+	case status.HasTrackedChanges():
+		return record.skipped("uncommitted changes")
+	case status.Upstream == "":
+		return record.skipped("no upstream")
+	}
+
+	if err := utils.GitFetch(p.Path); err != nil {
+		return record.failed(err)
+	}
+	if status, err = utils.ReadGitStatus(p.Path); err != nil {
+		return record.failed(err)
+	}
+	record.setStatus(status)
+
+	switch {
+	case status.UpstreamGone:
+		return record.skipped("upstream gone")
+	case status.Behind == 0:
+		record.Result = "current"
+		return record
+	case status.Ahead > 0 && ffOnly:
+		record.Result = "diverged"
+		return record
+	}
+
+	before, err := utils.GitHead(p.Path)
+	if err != nil {
+		return record.failed(err)
+	}
+	if err := utils.GitMerge(p.Path, ffOnly); err != nil {
+		return record.failed(err)
+	}
+	after, err := utils.GitHead(p.Path)
+	if err != nil {
+		return record.failed(err)
+	}
+
+	record.Result = "updated"
+	record.Pulled = status.Behind
+	record.Behind = 0
+
+	changed, err := utils.GitChangedFiles(p.Path, before, after)
+	if err != nil {
+		record.Message = fmt.Sprintf("could not list changed files: %v", err)
+	}
+	record.resync = slices.ContainsFunc(changed, utils.IsDependencyFile)
+	return record
+}
+
+// resyncProject syncs the dependencies, workspace members and components of a project
+// again, reporting whether its dependencies were synced
+func resyncProject(project *db.Project) bool {
+	if _, err := utils.SyncProjectDependencies(project.ID, project.Path, project.Language); err != nil {
+		output.Warnf("%s: %v", project.Name, err)
+		return false
+	}
+	if _, err := utils.SyncWorkspaceMembers(project); err != nil {
+		output.Warnf("%s: failed to sync workspace members: %v", project.Name, err)
+	}
+	syncComponents(project)
+	return true
+}
+
+func init() {
+	pullCmd.Flags().StringVarP(&pullLangFilter, "lang", "l", "", "Filter by language (js, py, go, rs)")
+	pullCmd.Flags().StringVarP(&pullTagFilter, "tag", "t", "", "Filter by tag")
+	pullCmd.Flags().IntVarP(&pullJobs, "jobs", "j", 0, "Number of repositories to pull concurrently (default: number of CPUs)")
+	pullCmd.Flags().BoolVar(&pullFFOnly, "ff-only", true, "Only fast-forward; use --ff-only=false to merge diverged branches")
+	rootCmd.AddCommand(pullCmd)
+}
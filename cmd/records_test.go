@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/genesix/pkt/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+// recordKeys encodes a record as JSON and YAML and returns the keys of each
+func recordKeys(t *testing.T, record any) (jsonKeys, yamlKeys []string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := output.Encode(&buf, output.FormatJSON, record, nil); err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	var fromJSON map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &fromJSON); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+
+	buf.Reset()
+	if err := output.Encode(&buf, output.FormatYAML, record, nil); err != nil {
+		t.Fatalf("Failed to encode YAML: %v", err)
+	}
+	var fromYAML map[string]any
+	if err := yaml.Unmarshal(buf.Bytes(), &fromYAML); err != nil {
+		t.Fatalf("Failed to decode YAML: %v", err)
+	}
+
+	for key := range fromJSON {
+		jsonKeys = append(jsonKeys, key)
+	}
+	for key := range fromYAML {
+		yamlKeys = append(yamlKeys, key)
+	}
+	sort.Strings(jsonKeys)
+	sort.Strings(yamlKeys)
+	return jsonKeys, yamlKeys
+}
+
+func TestRecordKeysAreStable(t *testing.T) {
+	tests := []struct {
+		name   string
+		record any
+		want   []string
+	}{
+		{
+			name:   "list",
+			record: projectRecord{},
+			want: []string{"archive_path", "created_at", "git_remote", "id", "language", "name",
+				"package_manager", "parent_id", "path", "size_bytes", "tags"},
+		},
+		{
+			name:   "status",
+			record: statusRecord{Status: "clean"},
+			want: []string{"ahead", "behind", "branch", "conflicts", "detached", "error", "last_commit",
+				"path", "project", "staged", "stashes", "status", "unstaged", "untracked", "upstream", "upstream_gone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonKeys, yamlKeys := recordKeys(t, tt.record)
+			for format, keys := range map[string][]string{"JSON": jsonKeys, "YAML": yamlKeys} {
+				if len(keys) != len(tt.want) {
+					t.Errorf("%s: expected keys %v, got %v", format, tt.want, keys)
+					continue
+				}
+				for i := range keys {
+					if keys[i] != tt.want[i] {
+						t.Errorf("%s: expected keys %v, got %v", format, tt.want, keys)
+						break
+					}
+				}
+			}
+		})
+	}
+}
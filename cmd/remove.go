@@ -47,13 +47,8 @@ Must be run inside a tracked project folder.`,
 		}
 
 		// Sync dependencies to database for all languages
-		deps, err := utils.ParseDependencies(cwd, project.Language)
-		if err != nil {
-			fmt.Printf("⚠️  Warning: failed to parse dependencies: %v\n", err)
-		} else {
-			if err := db.SyncDependencies(project.ID, deps); err != nil {
-				fmt.Printf("⚠️  Warning: failed to sync dependencies: %v\n", err)
-			}
+		if _, err := utils.SyncProjectDependencies(project.ID, cwd, project.Language); err != nil {
+			fmt.Printf("⚠️  Warning: %v\n", err)
 		}
 
 		if len(packages) == 1 {
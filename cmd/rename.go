@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +33,9 @@ This updates both the project name in the database and renames the folder.`,
 		// If multiple projects have the same name, use the first one
 		// (most recent by created_at DESC)
 		project := projects[0]
+		if err := utils.CheckNotArchived(project); err != nil {
+			return err
+		}
 
 		// Check if new name already exists in database
 		existingProjects, err := db.GetProjectsByName(newName)
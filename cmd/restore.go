@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var restoreTo string
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <id | name | file>",
+	Short: "Restore a deleted project from the trash, or a backup",
+	Long: `Move a project deleted with 'pkt delete' back out of the trash and track it again,
+with its tags, components, dependencies and workspace members.
+
+The argument is a trash ID from 'pkt trash ls', the project's ID or its name.
+The project returns to its original folder unless --to gives another one.
+
+Given a backup file instead, either a .tar.gz written by 'pkt backup' or a
+.db file from ~/.pkt/backups, the database and config are replaced with its
+contents. The current state is backed up to ~/.pkt/backups first. This also
+works when the config or database is too damaged for pkt to start.
+
+Examples:
+  pkt restore 01JB8ZQ4W3T6M2YV5N7K9C0D1E
+  pkt restore old-app --to ~/projects/old-app
+  pkt restore ~/.pkt/backups/pkt-20250101-120000.tar.gz`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if utils.IsBackupFile(args[0]) {
+			return restoreBackup(args[0])
+		}
+
+		entry, err := utils.FindTrashEntry(args[0])
+		if err != nil {
+			return err
+		}
+
+		target := ""
+		if restoreTo != "" {
+			if target, err = utils.ExpandPath(restoreTo); err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", restoreTo, err)
+			}
+		}
+
+		project, err := utils.RestoreFromTrash(entry, target)
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w", entry.Project().Name, err)
+		}
+
+		if project.IsArchived() {
+			fmt.Printf("✓ Restored %s, still archived in %s\n", project.Name, utils.ShortPath(project.ArchivePath))
+			return nil
+		}
+		fmt.Printf("✓ Restored %s to %s\n", project.Name, utils.ShortPath(project.Path))
+		return nil
+	},
+}
+
+// restoreBackup replaces the database and config with a backup. It runs without the
+// usual config and database checks, since those may be what needs restoring.
+func restoreBackup(path string) error {
+	if restoreTo != "" {
+		return fmt.Errorf("--to only applies to projects restored from the trash")
+	}
+
+	confirm, err := utils.Confirm(fmt.Sprintf("Replace the pkt database and config with %s?", utils.ShortPath(path)), false)
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		fmt.Println("Restore cancelled.")
+		return nil
+	}
+
+	// Keep the current state, unless the database is too damaged to copy
+	if err := db.Open(); err == nil {
+		if backup, err := utils.AutoBackup("restore"); err != nil {
+			fmt.Printf("⚠️  Warning: failed to back up the current state: %v\n", err)
+		} else {
+			fmt.Printf("✓ Backed up the current state to %s\n", utils.ShortPath(backup))
+		}
+	}
+
+	restored, err := utils.RestoreBackup(path)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", path, err)
+	}
+
+	// Bring an older backup up to the current schema
+	if err := db.Connect(); err != nil {
+		return fmt.Errorf("restored the database, but failed to migrate it: %w", err)
+	}
+
+	if restored.Config {
+		fmt.Printf("✓ Restored config and database (schema version %d) from %s\n", restored.SchemaVersion, utils.ShortPath(path))
+	} else {
+		fmt.Printf("✓ Restored database (schema version %d) from %s\n", restored.SchemaVersion, utils.ShortPath(path))
+	}
+	return nil
+}
+
+func init() {
+	restoreCmd.Flags().StringVar(&restoreTo, "to", "", "Restore the project into this folder instead of its original one")
+	rootCmd.AddCommand(restoreCmd)
+}
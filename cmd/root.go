@@ -5,6 +5,8 @@ import (
 
 	"github.com/genesix/pkt/internal/config"
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -34,11 +36,26 @@ Quick Start:
   pkt run dev            Run scripts
   pkt clone <url>        Clone and track repo`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.SetFormat(outputFormat); err != nil {
+			return err
+		}
+		utils.SetNonInteractive(nonInteractive)
+
 		// Skip config check for start command
 		if cmd.Name() == "start" {
 			return nil
 		}
 
+		// pkt doctor loads the config and database itself so it can report problems with them
+		if cmd == doctorCmd {
+			return nil
+		}
+
+		// Restoring a backup must work even when the config or database is broken
+		if cmd == restoreCmd && len(args) == 1 && utils.IsBackupFile(args[0]) {
+			return nil
+		}
+
 		// Check if config exists and is initialized
 		cfg, err := config.Load()
 		if err != nil || !cfg.Initialized {
@@ -48,6 +65,14 @@ Quick Start:
 		// Set database configuration
 		db.SetConfig(cfg)
 
+		// Database maintenance commands manage migrations themselves
+		if cmd.HasParent() && cmd.Parent() == dbCmd {
+			if err := db.Open(); err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			return nil
+		}
+
 		// Connect to database
 		if err := db.Connect(); err != nil {
 			return fmt.Errorf("failed to connect to database: %w", err)
@@ -61,12 +86,21 @@ Quick Start:
 	},
 }
 
+var (
+	outputFormat   string // --output, shared by every listing command
+	nonInteractive bool   // --yes/--non-interactive
+)
+
 // Execute runs the root command
 func Execute() error {
 	return rootCmd.Execute()
 }
 
 func init() {
+	rootCmd.PersistentFlags().BoolVarP(&nonInteractive, "yes", "y", false, "Never prompt: accept confirmations and fail when an answer is needed (or set "+utils.NonInteractiveEnv+"=1)")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Same as --yes")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", string(output.FormatTable), "Output format of listing commands: table, json, yaml or csv")
+
 	// Add all subcommands
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(createCmd)
@@ -9,6 +9,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var runFilter string
+
 var runCmd = &cobra.Command{
 	Use:   "run <script> [args...]",
 	Short: "Run a script in the current project",
@@ -26,7 +28,8 @@ Examples:
   pkt run test             # Run tests for any language
   pkt run build            # Build project
   pkt run main.py          # Run Python file
-  pkt run test -- -v       # Pass args to test command`,
+  pkt run test -- -v       # Pass args to test command
+  pkt run -F web dev       # Run a script in a workspace member`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		script := args[0]
@@ -44,6 +47,15 @@ Examples:
 			return fmt.Errorf("not in a tracked project. Run 'pkt init .' first")
 		}
 
+		// Run in a workspace member instead of the current project
+		if runFilter != "" {
+			project, err = resolveWorkspaceMember(project, runFilter)
+			if err != nil {
+				return err
+			}
+			cwd = project.Path
+		}
+
 		// Get package manager
 		packageManager, err := pm.Get(project.Language, project.PackageManager)
 		if err != nil {
@@ -56,5 +68,6 @@ Examples:
 }
 
 func init() {
+	runCmd.Flags().StringVarP(&runFilter, "filter", "F", "", "Run in a workspace member (name, directory or glob)")
 	rootCmd.AddCommand(runCmd)
 }
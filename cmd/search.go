@@ -4,23 +4,32 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	searchLangFilter string
+	searchTagFilter  string
+)
+
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search through tracked projects",
 	Long: `Search through tracked projects by name or path.
-Supports partial and case-insensitive matching.`,
+Supports partial and case-insensitive matching.
+
+Examples:
+  pkt search api              # Projects whose name or path contains "api"
+  pkt search api -t infra     # Only among projects tagged "infra"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := strings.ToLower(args[0])
 
-		// Get all projects
-		projects, err := db.ListAllProjects()
+		// Get all projects matching the selectors
+		projects, err := selectProjects(searchLangFilter, searchTagFilter)
 		if err != nil {
 			return fmt.Errorf("failed to list projects: %w", err)
 		}
@@ -36,6 +45,14 @@ Supports partial and case-insensitive matching.`,
 			}
 		}
 
+		if output.Structured() {
+			records := make([]projectRecord, 0, len(matches))
+			for _, project := range matches {
+				records = append(records, newProjectRecord(project, false))
+			}
+			return output.Print(records)
+		}
+
 		if len(matches) == 0 {
 			fmt.Printf("No projects found matching '%s'.\n", args[0])
 			return nil
@@ -44,21 +61,15 @@ Supports partial and case-insensitive matching.`,
 		// Display matches in the same format as list
 		fmt.Printf("Found %d project(s) matching '%s':\n\n", len(matches), args[0])
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		_, _ = fmt.Fprintln(w, "NAME\tID\tPACKAGE MANAGER\tPATH")
-		_, _ = fmt.Fprintln(w, "----\t--\t---------------\t----")
-
+		table := output.NewTable("NAME", "ID", "PACKAGE MANAGER", "PATH")
 		for _, project := range matches {
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-				project.Name,
-				project.ID,
-				project.PackageManager,
-				project.Path,
-			)
+			table.Add(project.Name, project.ID, project.PackageManager, project.Path)
 		}
-
-		_ = w.Flush()
-
-		return nil
+		return table.Write(os.Stdout)
 	},
 }
+
+func init() {
+	searchCmd.Flags().StringVarP(&searchLangFilter, "lang", "l", "", "Filter by language (js, py, go, rs)")
+	searchCmd.Flags().StringVarP(&searchTagFilter, "tag", "t", "", "Filter by tag")
+}
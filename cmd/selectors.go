@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/lang"
+	"github.com/genesix/pkt/internal/utils"
+)
+
+// selectProjects returns the tracked projects matching the --lang and --tag selectors.
+// Empty selectors match every project; archived projects are left out.
+func selectProjects(langFilter, tagFilter string) ([]*db.Project, error) {
+	filter, err := projectFilter(langFilter, tagFilter)
+	if err != nil {
+		return nil, err
+	}
+	return db.ListProjects(filter)
+}
+
+// selectRootProjects is selectProjects without the workspace members whose root is
+// also selected, for commands that walk project directories (a member lives inside
+// its workspace root). A member matching --lang or --tag on its own is kept.
+func selectRootProjects(langFilter, tagFilter string) ([]*db.Project, error) {
+	projects, err := selectProjects(langFilter, tagFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(projects))
+	for _, project := range projects {
+		selected[project.ID] = true
+	}
+	roots := projects[:0]
+	for _, project := range projects {
+		if !project.IsMember() || !selected[project.ParentID] {
+			roots = append(roots, project)
+		}
+	}
+	return roots, nil
+}
+
+// projectFilter builds a project filter from the --lang and --tag selectors
+func projectFilter(langFilter, tagFilter string) (db.ProjectFilter, error) {
+	filter := db.ProjectFilter{}
+
+	if langFilter != "" {
+		// Normalize short codes to full names (js -> javascript)
+		filter.Language = lang.NormalizeName(langFilter)
+	}
+
+	if tagFilter != "" {
+		tag, err := db.NormalizeTag(tagFilter)
+		if err != nil {
+			return filter, err
+		}
+		filter.Tag = tag
+	}
+
+	return filter, nil
+}
+
+// resolveWorkspaceMember finds the member of project's workspace selected by --filter.
+// The filter matches a member's name, directory name or path relative to the
+// workspace root, and may be a glob such as "@acme/*".
+func resolveWorkspaceMember(project *db.Project, filter string) (*db.Project, error) {
+	root := project
+	if project.IsMember() {
+		parent, err := db.GetProjectByID(project.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace root: %w", err)
+		}
+		root = parent
+	}
+
+	members, err := db.GetWorkspaceMembers(root.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*db.Project
+	for _, member := range members {
+		rel, _ := filepath.Rel(root.Path, member.Path)
+		for _, candidate := range []string{member.Name, filepath.Base(member.Path), filepath.ToSlash(rel)} {
+			if ok, _ := path.Match(filter, candidate); ok || candidate == filter {
+				matches = append(matches, member)
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no workspace member of %s matches %q", root.Name, filter)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, member := range matches {
+			names[i] = member.Name
+		}
+		return nil, fmt.Errorf("%q matches several workspace members: %s", filter, strings.Join(names, ", "))
+	}
+}
+
+// resolveComponent finds the component of a polyglot project selected by --component.
+// Components are rediscovered first so a freshly added sub-directory is found.
+func resolveComponent(project *db.Project, name string) (*db.Component, error) {
+	components, err := utils.SyncComponents(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover components: %w", err)
+	}
+
+	for _, component := range components {
+		if component.Name == name {
+			return component, nil
+		}
+	}
+
+	if len(components) == 0 {
+		return nil, fmt.Errorf("%s has no components besides its primary language (%s)", project.Name, project.Language)
+	}
+	names := make([]string, len(components))
+	for i, component := range components {
+		names[i] = component.Name
+	}
+	return nil, fmt.Errorf("%s has no component %q (available: %s)", project.Name, name, strings.Join(names, ", "))
+}
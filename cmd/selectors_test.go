@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+func TestSelectRootProjectsKeepsTaggedMembers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := db.Connect(); err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	_, _ = db.CreateProject("SEL001", "mono", "/tmp/sel-mono", "javascript", "pnpm")
+	_, _ = db.CreateProject("SEL002", "web", "/tmp/sel-mono/apps/web", "javascript", "pnpm")
+	if err := db.SetProjectParent("SEL002", "SEL001"); err != nil {
+		t.Fatalf("Failed to set parent: %v", err)
+	}
+	if err := db.AddTags("SEL002", "frontend"); err != nil {
+		t.Fatalf("Failed to tag member: %v", err)
+	}
+
+	names := func(projects []*db.Project) []string {
+		var names []string
+		for _, project := range projects {
+			names = append(names, project.Name)
+		}
+		return names
+	}
+
+	// Without selectors the member is covered by its root
+	projects, err := selectRootProjects("", "")
+	if err != nil {
+		t.Fatalf("Failed to select projects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "mono" {
+		t.Errorf("Expected only the workspace root, got %v", names(projects))
+	}
+
+	// A member carrying the tag is selected even though its root is not
+	projects, err = selectRootProjects("", "frontend")
+	if err != nil {
+		t.Fatalf("Failed to select projects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "web" {
+		t.Errorf("Expected the tagged member, got %v", names(projects))
+	}
+
+	// Once the root is tagged too, walking the root covers the member
+	if err := db.AddTags("SEL001", "frontend"); err != nil {
+		t.Fatalf("Failed to tag root: %v", err)
+	}
+	projects, err = selectRootProjects("", "frontend")
+	if err != nil {
+		t.Fatalf("Failed to select projects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "mono" {
+		t.Errorf("Expected only the workspace root, got %v", names(projects))
+	}
+}
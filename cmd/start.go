@@ -5,20 +5,36 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/genesix/pkt/internal/config"
 	"github.com/genesix/pkt/internal/db"
 	"github.com/genesix/pkt/internal/pm"
+	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	startRoot   string
+	startPM     string
+	startEditor string
+)
+
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Initialize pkt configuration",
 	Long: `Initialize pkt by setting up configuration and database.
-This must be run before using any other pkt commands.`,
+This must be run before using any other pkt commands.
+
+Values passed with --root, --pm and --editor are not asked for. In
+non-interactive mode (--yes or PKT_NONINTERACTIVE=1) the remaining
+values use their defaults and pnpm is not installed.
+
+Examples:
+  pkt start
+  pkt start --root ~/code --pm pnpm --editor cursor
+  pkt start --yes                   # Accept every default`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Println("Starting pkt initialization...")
 		fmt.Println()
@@ -143,14 +159,12 @@ This must be run before using any other pkt commands.`,
 		fmt.Println()
 
 		// Offer to install pnpm if npm is available but pnpm isn't
-		if npmAvailable && !pnpmAvailable {
-			var shouldInstall bool
-			prompt := &survey.Confirm{
-				Message: "pnpm is the recommended JS package manager. Install it now?",
-				Default: true,
-			}
-			if err := survey.AskOne(prompt, &shouldInstall); err != nil {
-				return fmt.Errorf("cancelled: %w", err)
+		if npmAvailable && !pnpmAvailable && utils.NonInteractive() {
+			fmt.Println("pnpm is the recommended JS package manager. Install it with: npm install -g pnpm")
+		} else if npmAvailable && !pnpmAvailable {
+			shouldInstall, err := utils.Confirm("pnpm is the recommended JS package manager. Install it now?", true)
+			if err != nil {
+				return err
 			}
 
 			if shouldInstall {
@@ -165,10 +179,10 @@ This must be run before using any other pkt commands.`,
 			}
 		}
 
-		// Prompt for configuration
-		var projectsRoot string
-		var defaultPM string
-		var editorCmd string
+		// Prompt for configuration, unless given by flag
+		projectsRoot := startRoot
+		defaultPM := startPM
+		editorCmd := startEditor
 
 		// Get home directory for default
 		home, err := os.UserHomeDir()
@@ -181,12 +195,13 @@ This must be run before using any other pkt commands.`,
 		fmt.Println("\nConfiguration setup...")
 
 		// Projects root
-		promptRoot := &survey.Input{
-			Message: "Projects root folder:",
-			Default: defaultRoot,
-		}
-		if err := survey.AskOne(promptRoot, &projectsRoot); err != nil {
-			return fmt.Errorf("cancelled: %w", err)
+		if projectsRoot == "" && utils.NonInteractive() {
+			projectsRoot = defaultRoot
+		} else if projectsRoot == "" {
+			projectsRoot, err = utils.Input("Projects root folder:", defaultRoot)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Expand ~ if present, or prepend home/Documents directory for relative paths
@@ -219,23 +234,26 @@ This must be run before using any other pkt commands.`,
 			defaultPMOption = available[0]
 		}
 
-		promptPM := &survey.Select{
-			Message: "Default package manager (for JavaScript):",
-			Options: available,
-			Default: defaultPMOption,
-		}
-		if err := survey.AskOne(promptPM, &defaultPM); err != nil {
-			return fmt.Errorf("cancelled: %w", err)
+		if defaultPM != "" && !slices.Contains(available, defaultPM) {
+			return fmt.Errorf("package manager %s is not installed (available: %s)", defaultPM, strings.Join(available, ", "))
+		} else if defaultPM == "" && utils.NonInteractive() {
+			defaultPM = defaultPMOption
+		} else if defaultPM == "" {
+			defaultPM, err = utils.Select("Default package manager (for JavaScript):", available, defaultPMOption)
+			if err != nil {
+				return err
+			}
 		}
 		fmt.Printf("  Default package manager: %s\n", defaultPM)
 
 		// Editor command
-		promptEditor := &survey.Input{
-			Message: "Editor command (e.g., code, cursor, vim):",
-			Default: "code",
-		}
-		if err := survey.AskOne(promptEditor, &editorCmd); err != nil {
-			return fmt.Errorf("cancelled: %w", err)
+		if editorCmd == "" && utils.NonInteractive() {
+			editorCmd = "code"
+		} else if editorCmd == "" {
+			editorCmd, err = utils.Input("Editor command (e.g., code, cursor, vim):", "code")
+			if err != nil {
+				return err
+			}
 		}
 
 		// Verify editor command exists
@@ -325,3 +343,9 @@ func checkTool(name string) bool {
 	_, err := exec.LookPath(name)
 	return err == nil
 }
+
+func init() {
+	startCmd.Flags().StringVar(&startRoot, "root", "", "Projects root folder (default: ~/Documents/workspace)")
+	startCmd.Flags().StringVar(&startPM, "pm", "", "Default package manager for JavaScript")
+	startCmd.Flags().StringVar(&startEditor, "editor", "", "Editor command (default: code)")
+}
@@ -3,59 +3,94 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"sort"
+	"strconv"
 
 	"github.com/dustin/go-humanize"
-	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
 	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	statsLangFilter string
+	statsTagFilter  string
+)
+
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show project statistics",
 	Long:  "Calculate and display statistics for all tracked projects, such as disk space usage and language distribution.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		projects, err := db.ListAllProjects()
+		projects, err := selectRootProjects(statsLangFilter, statsTagFilter)
 		if err != nil {
 			return err
 		}
 
-		if len(projects) == 0 {
+		if len(projects) == 0 && !output.Structured() {
 			fmt.Println("No projects tracked. Run 'pkt create' or 'pkt init' to get started.")
 			return nil
 		}
 
-		fmt.Println("📊 Calculating statistics...")
-
-		var totalSize int64
-		langCounts := make(map[string]int)
-		langSize := make(map[string]int64)
+		if !output.Structured() {
+			fmt.Println("📊 Calculating statistics...")
+		}
 
+		report := statsReport{Languages: []languageStats{}}
+		byLang := make(map[string]*languageStats)
 		for _, p := range projects {
-			langCounts[p.Language]++
 			size, _ := utils.GetDirSize(p.Path)
-			totalSize += size
-			langSize[p.Language] += size
-		}
+			report.TotalProjects++
+			report.TotalSizeBytes += size
 
-		fmt.Println("\n\033[1mWorkspace Summary:\033[0m")
-		fmt.Printf("  Total Projects: %d\n", len(projects))
-		fmt.Printf("  Total Space: \033[36m%s\033[0m\n", humanize.Bytes(uint64(totalSize)))
+			entry, ok := byLang[p.Language]
+			if !ok {
+				entry = &languageStats{Language: p.Language}
+				byLang[p.Language] = entry
+			}
+			entry.Count++
+			entry.SizeBytes += size
+		}
+		for _, entry := range byLang {
+			report.Languages = append(report.Languages, *entry)
+		}
+		sort.Slice(report.Languages, func(i, j int) bool {
+			return report.Languages[i].Language < report.Languages[j].Language
+		})
 
-		fmt.Println("\n\033[1mLanguage Breakdown:\033[0m")
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintln(w, "LANGUAGE\tCOUNT\tSIZE")
-		fmt.Fprintln(w, "--------\t-----\t----")
-		for lang, count := range langCounts {
-			fmt.Fprintf(w, "%s\t%d\t%s\n", lang, count, humanize.Bytes(uint64(langSize[lang])))
+		if output.Structured() {
+			return output.PrintDocument(report, report.Languages)
 		}
-		w.Flush()
 
-		return nil
+		fmt.Println("\n" + output.Color("1", "Workspace Summary:"))
+		fmt.Printf("  Total Projects: %d\n", report.TotalProjects)
+		fmt.Printf("  Total Space: %s\n", output.Color("36", humanize.Bytes(uint64(report.TotalSizeBytes))))
+
+		fmt.Println("\n" + output.Color("1", "Language Breakdown:"))
+		table := output.NewTable("LANGUAGE", "COUNT", "SIZE")
+		for _, entry := range report.Languages {
+			table.Add(entry.Language, strconv.Itoa(entry.Count), humanize.Bytes(uint64(entry.SizeBytes)))
+		}
+		return table.Write(os.Stdout)
 	},
 }
 
+// statsReport is the workspace summary in --output json and yaml; csv lists the languages
+type statsReport struct {
+	TotalProjects  int             `json:"total_projects" yaml:"total_projects"`
+	TotalSizeBytes int64           `json:"total_size_bytes" yaml:"total_size_bytes"`
+	Languages      []languageStats `json:"languages" yaml:"languages"`
+}
+
+// languageStats is the number and disk usage of the projects of one language
+type languageStats struct {
+	Language  string `json:"language" yaml:"language"`
+	Count     int    `json:"count" yaml:"count"`
+	SizeBytes int64  `json:"size_bytes" yaml:"size_bytes"`
+}
+
 func init() {
+	statsCmd.Flags().StringVarP(&statsLangFilter, "lang", "l", "", "Filter by language (js, py, go, rs)")
+	statsCmd.Flags().StringVarP(&statsTagFilter, "tag", "t", "", "Filter by tag")
 	rootCmd.AddCommand(statsCmd)
 }
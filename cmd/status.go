@@ -3,79 +3,255 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	statusLangFilter string
+	statusTagFilter  string
+	statusDirty      bool
+	statusUnpushed   bool
+	statusBehind     bool
+	statusJobs       int
+)
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check global git status",
-	Long:  "Check the git status of all tracked projects to find uncommitted changes or missing pushes.",
+	Long: `Check the git status of all tracked projects to find uncommitted changes or missing pushes.
+
+Repositories are checked concurrently. For each one the branch (or a detached
+HEAD), commits ahead of and behind its upstream, staged, unstaged and
+untracked changes, stashes and the age of the last commit are shown. Run
+'pkt fetch' first for up-to-date behind counts.
+
+With --dirty, --unpushed or --behind only the projects matching at least one
+of the given filters are listed. A branch without an upstream counts as unpushed.
+
+Examples:
+  pkt status
+  pkt status --dirty --unpushed     # Work that is not on the remote yet
+  pkt status --behind -l go
+  pkt status --output json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		projects, err := db.ListAllProjects()
+		projects, err := selectRootProjects(statusLangFilter, statusTagFilter)
 		if err != nil {
 			return err
 		}
 
-		if len(projects) == 0 {
+		if len(projects) == 0 && !output.Structured() {
 			fmt.Println("No projects tracked.")
 			return nil
 		}
 
-		fmt.Println("🔍 Scanning repositories...")
-		fmt.Println()
-
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintln(w, "PROJECT\tSTATUS\tBRANCH")
-		fmt.Fprintln(w, "-------\t------\t------")
+		if !output.Structured() {
+			fmt.Println("🔍 Scanning repositories...")
+			fmt.Println()
+		}
 
-		var hasRepos bool
+		var repos []*db.Project
 		for _, p := range projects {
-			// Check if .git exists
-			gitDir := filepath.Join(p.Path, ".git")
-			if _, err := os.Stat(gitDir); os.IsNotExist(err) {
-				continue
-			}
-			hasRepos = true
-
-			// Check git status
-			gitStatusCmd := exec.Command("git", "status", "--porcelain")
-			gitStatusCmd.Dir = p.Path
-			out, err := gitStatusCmd.Output()
-			statusStr := "\033[32mClean\033[0m"
-			if err != nil {
-				statusStr = "\033[31mError\033[0m"
-			} else if len(strings.TrimSpace(string(out))) > 0 {
-				statusStr = "\033[33mModified\033[0m"
+			if utils.IsGitRepo(p.Path) {
+				repos = append(repos, p)
 			}
+		}
+
+		all := utils.ParallelMap(repos, statusJobs, newStatusRecord)
 
-			// Check git branch
-			gitBranchCmd := exec.Command("git", "branch", "--show-current")
-			gitBranchCmd.Dir = p.Path
-			branchOut, _ := gitBranchCmd.Output()
-			branchStr := strings.TrimSpace(string(branchOut))
-			if branchStr == "" {
-				branchStr = "unknown"
+		filtered := statusDirty || statusUnpushed || statusBehind
+		records := []statusRecord{}
+		for _, record := range all {
+			if !filtered || record.matches(statusDirty, statusUnpushed, statusBehind) {
+				records = append(records, record)
 			}
+		}
 
-			fmt.Fprintf(w, "%s\t%s\t%s\n", p.Name, statusStr, branchStr)
+		if output.Structured() {
+			return output.Print(records)
 		}
 
-		if !hasRepos {
+		if len(all) == 0 {
 			fmt.Println("No git repositories found in tracked projects.")
 			return nil
 		}
+		if len(records) == 0 {
+			fmt.Printf("✓ None of %d repositories match\n", len(all))
+			return nil
+		}
 
-		w.Flush()
-		return nil
+		table := output.NewTable("PROJECT", "STATUS", "BRANCH", "SYNC", "CHANGES", "STASH", "LAST COMMIT")
+		for _, record := range records {
+			stashes := "-"
+			if record.Stashes > 0 {
+				stashes = strconv.Itoa(record.Stashes)
+			}
+			lastCommit := "-"
+			if record.LastCommit != nil {
+				lastCommit = humanize.Time(*record.LastCommit)
+			}
+			table.Add(record.Project, statusLabel(record.Status), record.branchLabel(), record.syncLabel(), record.changesLabel(), stashes, lastCommit)
+		}
+		return table.Write(os.Stdout)
 	},
 }
 
+// statusRecord is the git status of a project in --output json, yaml and csv
+type statusRecord struct {
+	Project      string     `json:"project" yaml:"project"`
+	Path         string     `json:"path" yaml:"path"`
+	Status       string     `json:"status" yaml:"status"` // clean, modified or error
+	Error        string     `json:"error" yaml:"error"`   // Empty unless the status is error
+	Branch       string     `json:"branch" yaml:"branch"` // empty when HEAD is detached
+	Detached     bool       `json:"detached" yaml:"detached"`
+	Upstream     string     `json:"upstream" yaml:"upstream"` // empty when none is set
+	UpstreamGone bool       `json:"upstream_gone" yaml:"upstream_gone"`
+	Ahead        int        `json:"ahead" yaml:"ahead"`
+	Behind       int        `json:"behind" yaml:"behind"`
+	Staged       int        `json:"staged" yaml:"staged"`
+	Unstaged     int        `json:"unstaged" yaml:"unstaged"`
+	Untracked    int        `json:"untracked" yaml:"untracked"`
+	Conflicts    int        `json:"conflicts" yaml:"conflicts"`
+	Stashes      int        `json:"stashes" yaml:"stashes"`
+	LastCommit   *time.Time `json:"last_commit" yaml:"last_commit"` // null for a repository without commits
+}
+
+// newStatusRecord reads the git status of a project
+func newStatusRecord(p *db.Project) statusRecord {
+	record := statusRecord{Project: p.Name, Path: p.Path, Status: "clean"}
+
+	status, err := utils.ReadGitStatus(p.Path)
+	if err != nil {
+		record.Status = "error"
+		record.Error = err.Error()
+		return record
+	}
+	if status.Dirty() {
+		record.Status = "modified"
+	}
+
+	record.Branch = status.Branch
+	record.Detached = status.Detached
+	record.Upstream = status.Upstream
+	record.UpstreamGone = status.UpstreamGone
+	record.Ahead = status.Ahead
+	record.Behind = status.Behind
+	record.Staged = status.Staged
+	record.Unstaged = status.Unstaged
+	record.Untracked = status.Untracked
+	record.Conflicts = status.Conflicts
+	record.Stashes = status.Stashes
+	if !status.LastCommit.IsZero() {
+		record.LastCommit = &status.LastCommit
+	}
+	return record
+}
+
+// matches reports whether the record passes any of the enabled filters
+func (r statusRecord) matches(dirty, unpushed, behind bool) bool {
+	if r.Status == "error" {
+		return false
+	}
+	return (dirty && r.Status == "modified") ||
+		(unpushed && r.unpushed()) ||
+		(behind && r.Behind > 0)
+}
+
+// unpushed reports whether the branch has commits that are not on a remote
+func (r statusRecord) unpushed() bool {
+	if r.Detached || r.LastCommit == nil {
+		return false
+	}
+	return r.Ahead > 0 || r.Upstream == "" || r.UpstreamGone
+}
+
+// branchLabel is the branch name, or the detached state
+func (r statusRecord) branchLabel() string {
+	switch {
+	case r.Status == "error":
+		return "-"
+	case r.Detached:
+		return output.Color("33", "(detached)")
+	case r.Branch == "":
+		return "unknown"
+	}
+	return r.Branch
+}
+
+// syncLabel summarizes the branch against its upstream, e.g. "↑2 ↓1"
+func (r statusRecord) syncLabel() string {
+	switch {
+	case r.Status == "error" || r.Detached:
+		return "-"
+	case r.Upstream == "":
+		return output.Color("33", "no upstream")
+	case r.UpstreamGone:
+		return output.Color("31", "upstream gone")
+	case r.Ahead == 0 && r.Behind == 0:
+		return "up to date"
+	}
+
+	var parts []string
+	if r.Ahead > 0 {
+		parts = append(parts, output.Color("33", fmt.Sprintf("↑%d", r.Ahead)))
+	}
+	if r.Behind > 0 {
+		parts = append(parts, output.Color("36", fmt.Sprintf("↓%d", r.Behind)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// changesLabel summarizes the working tree changes, e.g. "2 staged, 1 untracked"
+func (r statusRecord) changesLabel() string {
+	if r.Status == "error" {
+		return r.Error
+	}
+
+	var parts []string
+	for _, count := range []struct {
+		n     int
+		label string
+	}{
+		{r.Conflicts, "conflicted"},
+		{r.Staged, "staged"},
+		{r.Unstaged, "unstaged"},
+		{r.Untracked, "untracked"},
+	} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.label))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// statusLabel capitalizes a git status and colours it when colour is enabled
+func statusLabel(status string) string {
+	switch status {
+	case "clean":
+		return output.Color("32", "Clean")
+	case "modified":
+		return output.Color("33", "Modified")
+	default:
+		return output.Color("31", "Error")
+	}
+}
+
 func init() {
+	statusCmd.Flags().StringVarP(&statusLangFilter, "lang", "l", "", "Filter by language (js, py, go, rs)")
+	statusCmd.Flags().StringVarP(&statusTagFilter, "tag", "t", "", "Filter by tag")
+	statusCmd.Flags().BoolVar(&statusDirty, "dirty", false, "Only show repositories with uncommitted changes")
+	statusCmd.Flags().BoolVar(&statusUnpushed, "unpushed", false, "Only show repositories with commits not pushed to a remote")
+	statusCmd.Flags().BoolVar(&statusBehind, "behind", false, "Only show repositories behind their upstream")
+	statusCmd.Flags().IntVarP(&statusJobs, "jobs", "j", 0, "Number of repositories to check concurrently (default: number of CPUs)")
 	rootCmd.AddCommand(statusCmd)
 }
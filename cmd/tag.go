@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage project tags",
+	Long: `Group projects with free-form tags (e.g. "client-acme", "infra").
+
+Tags can be used as a selector in workspace-wide commands:
+  pkt list --tag infra
+  pkt status --tag client-acme
+  pkt clean --tag infra`,
+}
+
+var tagAddCmd = &cobra.Command{
+	Use:   "add <project | id | .> <tag>...",
+	Short: "Add tags to a project",
+	Long: `Add one or more tags to a project.
+
+Examples:
+  pkt tag add api client-acme infra
+  pkt tag add . oss`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, err := utils.ResolveProject(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := db.AddTags(project.ID, args[1:]...); err != nil {
+			return fmt.Errorf("failed to add tags: %w", err)
+		}
+
+		tags, err := db.GetProjectTags(project.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get tags: %w", err)
+		}
+
+		fmt.Printf("✓ Tagged %s: %s\n", project.Name, strings.Join(tags, ", "))
+		return nil
+	},
+}
+
+var tagRemoveCmd = &cobra.Command{
+	Use:     "remove <project | id | .> <tag>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove tags from a project",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, err := utils.ResolveProject(args[0])
+		if err != nil {
+			return err
+		}
+
+		removed, err := db.RemoveTags(project.ID, args[1:]...)
+		if err != nil {
+			return fmt.Errorf("failed to remove tags: %w", err)
+		}
+
+		if removed == 0 {
+			fmt.Printf("Project %s has none of those tags\n", project.Name)
+			return nil
+		}
+
+		fmt.Printf("✓ Removed %d tag(s) from %s\n", removed, project.Name)
+		return nil
+	},
+}
+
+var tagLsCmd = &cobra.Command{
+	Use:     "ls [project | id | .]",
+	Aliases: []string{"list"},
+	Short:   "List tags",
+	Long: `List all tags in use with their project counts,
+or the tags of a single project when one is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			project, err := utils.ResolveProject(args[0])
+			if err != nil {
+				return err
+			}
+
+			tags, err := db.GetProjectTags(project.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get tags: %w", err)
+			}
+
+			if len(tags) == 0 {
+				fmt.Printf("Project %s has no tags.\n", project.Name)
+				return nil
+			}
+
+			for _, tag := range tags {
+				fmt.Println(tag)
+			}
+			return nil
+		}
+
+		counts, err := db.ListTags()
+		if err != nil {
+			return fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		if len(counts) == 0 {
+			fmt.Println("No tags found.")
+			fmt.Println("Add one with: pkt tag add <project> <tag>")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TAG\tPROJECTS")
+		_, _ = fmt.Fprintln(w, "---\t--------")
+		for _, tc := range counts {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", tc.Tag, tc.Count)
+		}
+		_ = w.Flush()
+
+		return nil
+	},
+}
+
+func init() {
+	tagCmd.AddCommand(tagAddCmd)
+	tagCmd.AddCommand(tagRemoveCmd)
+	tagCmd.AddCommand(tagLsCmd)
+	rootCmd.AddCommand(tagCmd)
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/genesix/pkt/internal/output"
+	"github.com/genesix/pkt/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var trashOlderThan string
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage deleted projects",
+	Long: `'pkt delete' moves projects to the trash in ~/.pkt/trash, keeping their folder and
+everything pkt recorded about them (tags, components, dependencies, workspace members).
+
+Examples:
+  pkt trash ls
+  pkt restore <id>
+  pkt trash empty --older-than 7d`,
+}
+
+// trashRecord is a deleted project in --output json, yaml and csv
+type trashRecord struct {
+	ID        string    `json:"id" yaml:"id"` // Trash ID, accepted by 'pkt restore'
+	ProjectID string    `json:"project_id" yaml:"project_id"`
+	Name      string    `json:"name" yaml:"name"`
+	Language  string    `json:"language" yaml:"language"`
+	Path      string    `json:"path" yaml:"path"` // Where it is restored to
+	HasFiles  bool      `json:"has_files" yaml:"has_files"`
+	SizeBytes int64     `json:"size_bytes" yaml:"size_bytes"`
+	DeletedAt time.Time `json:"deleted_at" yaml:"deleted_at"`
+}
+
+var trashLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List deleted projects",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := utils.ListTrash()
+		if err != nil {
+			return err
+		}
+
+		records := make([]trashRecord, 0, len(entries))
+		for _, entry := range entries {
+			project := entry.Project()
+			dir, err := entry.Dir()
+			if err != nil {
+				return err
+			}
+			size, _ := utils.GetDirSize(dir)
+			records = append(records, trashRecord{
+				ID:        entry.ID,
+				ProjectID: project.ID,
+				Name:      project.Name,
+				Language:  project.Language,
+				Path:      project.Path,
+				HasFiles:  entry.HasFiles,
+				SizeBytes: size,
+				DeletedAt: entry.DeletedAt,
+			})
+		}
+
+		if output.Structured() {
+			return output.Print(records)
+		}
+
+		if len(records) == 0 {
+			fmt.Println("The trash is empty.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NAME", "LANG", "DELETED", "SIZE", "PATH")
+		for _, r := range records {
+			size := humanize.Bytes(uint64(r.SizeBytes))
+			if !r.HasFiles {
+				size = "no files"
+			}
+			table.Add(r.ID, r.Name, langToShort(r.Language), humanize.Time(r.DeletedAt), size, utils.ShortPath(r.Path))
+		}
+		if err := table.Write(os.Stdout); err != nil {
+			return err
+		}
+
+		fmt.Println("\nRestore a project with: pkt restore <id>")
+		return nil
+	},
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently delete projects in the trash",
+	Long: `Permanently delete every project in the trash, or only those deleted longer ago
+than --older-than. This cannot be undone!
+
+Examples:
+  pkt trash empty
+  pkt trash empty --older-than 7d --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var olderThan time.Duration
+		if trashOlderThan != "" {
+			var err error
+			if olderThan, err = utils.ParseAge(trashOlderThan); err != nil {
+				return err
+			}
+		}
+
+		entries, err := utils.ListTrash()
+		if err != nil {
+			return err
+		}
+
+		cutoff := time.Now().Add(-olderThan)
+		var expired []*utils.TrashEntry
+		for _, entry := range entries {
+			if entry.DeletedAt.Before(cutoff) {
+				expired = append(expired, entry)
+			}
+		}
+
+		if len(expired) == 0 {
+			if trashOlderThan != "" {
+				fmt.Printf("Nothing in the trash is older than %s.\n", trashOlderThan)
+			} else {
+				fmt.Println("The trash is already empty.")
+			}
+			return nil
+		}
+
+		confirm, err := utils.Confirm(fmt.Sprintf("Permanently delete %d project(s) from the trash?", len(expired)), false)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println("Skipping empty.")
+			return nil
+		}
+
+		purged := 0
+		for _, entry := range expired {
+			if err := utils.PurgeTrashEntry(entry); err != nil {
+				fmt.Printf("⚠️  Warning: %v\n", err)
+				continue
+			}
+			purged++
+		}
+
+		fmt.Printf("✓ Permanently deleted %d project(s)\n", purged)
+		return nil
+	},
+}
+
+func init() {
+	trashEmptyCmd.Flags().StringVar(&trashOlderThan, "older-than", "", "Only delete projects trashed longer ago than this (e.g. 7d, 2w)")
+	trashCmd.AddCommand(trashLsCmd)
+	trashCmd.AddCommand(trashEmptyCmd)
+	rootCmd.AddCommand(trashCmd)
+}
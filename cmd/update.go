@@ -54,13 +54,8 @@ Examples:
 		}
 
 		// Sync dependencies to database
-		deps, err := utils.ParseDependencies(cwd, project.Language)
-		if err != nil {
-			fmt.Printf("⚠️  Warning: failed to parse dependencies: %v\n", err)
-		} else if len(deps) > 0 {
-			if err := db.SyncDependencies(project.ID, deps); err != nil {
-				fmt.Printf("⚠️  Warning: failed to sync dependencies: %v\n", err)
-			}
+		if _, err := utils.SyncProjectDependencies(project.ID, cwd, project.Language); err != nil {
+			fmt.Printf("⚠️  Warning: %v\n", err)
 		}
 
 		fmt.Println("✓ Dependencies updated")
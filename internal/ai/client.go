@@ -76,6 +76,11 @@ var localProviders = map[string]bool{
 	"local":  true,
 }
 
+// RequiresAPIKey reports whether a provider needs an API key; local providers do not
+func RequiresAPIKey(provider string) bool {
+	return !localProviders[provider]
+}
+
 func AskAI(systemPrompt, userPrompt, preferredProvider string) (string, error) {
 	messages := []Message{
 		{Role: "system", Content: systemPrompt},
@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// DefaultRoot is the name of the workspace root configured by projects_root
+const DefaultRoot = "default"
+
 // ProviderConfig holds the settings for one AI provider.
 // Cloud providers set APIKey; local providers set BaseURL instead.
 type ProviderConfig struct {
@@ -18,6 +23,10 @@ type ProviderConfig struct {
 // Config represents the pkt configuration
 type Config struct {
 	ProjectsRoot  string                    `json:"projects_root"`
+	Roots         map[string]string         `json:"roots,omitempty"`          // additional named workspace roots, e.g. "oss": "~/oss"
+	InitInPlace   bool                      `json:"init_in_place,omitempty"`  // pkt init registers projects where they are instead of moving them
+	CleanPatterns map[string][]string       `json:"clean_patterns,omitempty"` // folders pkt clean prunes per language, replacing the defaults
+	ArchiveDir    string                    `json:"archive_dir,omitempty"`    // where pkt archive writes tarballs, ~/.pkt/archive by default
 	DefaultPM     string                    `json:"default_pm"`
 	EditorCommand string                    `json:"editor"`
 	Initialized   bool                      `json:"initialized"`
@@ -30,8 +39,41 @@ type Config struct {
 	AIModels map[string]string `json:"ai_models,omitempty"`
 }
 
-// configPath returns the path to the config file
-func configPath() (string, error) {
+// Root returns the directory of a workspace root by name, unexpanded. An empty
+// name or DefaultRoot selects projects_root.
+func (c *Config) Root(name string) (string, error) {
+	if name == "" || name == DefaultRoot {
+		return c.ProjectsRoot, nil
+	}
+	if dir, ok := c.Roots[name]; ok {
+		return dir, nil
+	}
+	return "", fmt.Errorf("unknown workspace root: %s (available: %s)", name, strings.Join(c.RootNames(), ", "))
+}
+
+// DefaultArchiveDir is where pkt archive writes tarballs unless archive_dir is set
+const DefaultArchiveDir = "~/.pkt/archive"
+
+// ArchiveDirectory returns the directory for project archives, unexpanded
+func (c *Config) ArchiveDirectory() string {
+	if c.ArchiveDir != "" {
+		return c.ArchiveDir
+	}
+	return DefaultArchiveDir
+}
+
+// RootNames returns the names of every workspace root, DefaultRoot first and the others sorted
+func (c *Config) RootNames() []string {
+	names := make([]string, 0, len(c.Roots))
+	for name := range c.Roots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultRoot}, names...)
+}
+
+// Path returns the path to the config file
+func Path() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
@@ -41,7 +83,7 @@ func configPath() (string, error) {
 
 // Exists checks if the config file exists
 func Exists() (bool, error) {
-	path, err := configPath()
+	path, err := Path()
 	if err != nil {
 		return false, err
 	}
@@ -59,7 +101,7 @@ func Exists() (bool, error) {
 
 // Load reads the configuration from ~/.pkt/config.json
 func Load() (*Config, error) {
-	path, err := configPath()
+	path, err := Path()
 	if err != nil {
 		return nil, err
 	}
@@ -113,9 +155,10 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save writes the configuration to ~/.pkt/config.json
+// Save writes the configuration to ~/.pkt/config.json. The file is written next to
+// it first and renamed into place, so a crash never leaves a truncated config.
 func Save(cfg *Config) error {
-	path, err := configPath()
+	path, err := Path()
 	if err != nil {
 		return err
 	}
@@ -130,7 +173,27 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	tmp, err := os.CreateTemp(dir, "config-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
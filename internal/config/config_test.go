@@ -170,3 +170,67 @@ func TestLegacyMigration(t *testing.T) {
 		t.Errorf("Migration failed: expected model 'llama-3.1-8b-instant', got '%s'", cfg.AIProviders["groq"].Model)
 	}
 }
+
+func TestConfigRoots(t *testing.T) {
+	cfg := &Config{
+		ProjectsRoot: "~/Documents/workspace",
+		Roots:        map[string]string{"work": "~/work", "oss": "~/oss"},
+	}
+
+	names := cfg.RootNames()
+	want := []string{DefaultRoot, "oss", "work"}
+	if len(names) != len(want) {
+		t.Fatalf("Expected roots %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Expected root %d to be %s, got %s", i, want[i], names[i])
+		}
+	}
+
+	for name, dir := range map[string]string{"": "~/Documents/workspace", DefaultRoot: "~/Documents/workspace", "oss": "~/oss"} {
+		got, err := cfg.Root(name)
+		if err != nil {
+			t.Errorf("Unexpected error for root %q: %v", name, err)
+		}
+		if got != dir {
+			t.Errorf("Expected root %q to be %s, got %s", name, dir, got)
+		}
+	}
+
+	if _, err := cfg.Root("missing"); err == nil {
+		t.Error("Expected error for unknown root")
+	}
+}
+
+func TestSaveReplacesConfigAtomically(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if err := Save(&Config{ProjectsRoot: "~/one", Initialized: true}); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	if err := Save(&Config{ProjectsRoot: "~/two", Initialized: true}); err != nil {
+		t.Fatalf("Failed to save config again: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.ProjectsRoot != "~/two" {
+		t.Errorf("Expected ProjectsRoot ~/two, got %s", cfg.ProjectsRoot)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(home, ".pkt"))
+	if err != nil {
+		t.Fatalf("Failed to read config directory: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "config.json" {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("Expected only config.json to be left, got %v", names)
+	}
+}
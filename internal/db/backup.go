@@ -0,0 +1,166 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// BackupKeep is how many automatic backups, and how many migration backups, are kept
+// in the backup directory
+const BackupKeep = 10
+
+// BackupTo writes a consistent copy of the open database to dest with VACUUM INTO.
+// The copy only appears at dest once it is complete.
+func BackupTo(dest string) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	tmp := dest + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear %s: %w", tmp, err)
+	}
+	if _, err := DB.Exec("VACUUM INTO ?", tmp); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	return nil
+}
+
+// PruneBackups removes the oldest files of the backup directory matching pattern,
+// keeping the newest keep of them
+func PruneBackups(pattern string, keep int) error {
+	dir, err := BackupDir()
+	if err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return fmt.Errorf("invalid backup pattern %s: %w", pattern, err)
+	}
+	if len(paths) <= keep {
+		return nil
+	}
+
+	type backup struct {
+		path string
+		info os.FileInfo
+	}
+	var backups []backup
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		backups = append(backups, backup{path, info})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].info.ModTime().Equal(backups[j].info.ModTime()) {
+			return backups[i].info.ModTime().After(backups[j].info.ModTime())
+		}
+		return backups[i].path > backups[j].path
+	})
+
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].path); err != nil {
+			return fmt.Errorf("failed to remove old backup %s: %w", backups[i].path, err)
+		}
+	}
+	return nil
+}
+
+// InspectBackup checks that path is an intact pkt database this version of pkt can
+// use and returns its schema version
+func InspectBackup(path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	backup, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = backup.Close() }()
+
+	var result string
+	if err := backup.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return 0, fmt.Errorf("%s is not a readable SQLite database: %w", path, err)
+	}
+	if result != "ok" {
+		return 0, fmt.Errorf("%s failed the integrity check: %s", path, result)
+	}
+
+	var version sql.NullInt64
+	if err := backup.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("%s is not a pkt database", path)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	if latest := migrations[len(migrations)-1].Version; int(version.Int64) > latest {
+		return 0, fmt.Errorf("%s has schema version %d, newer than this version of pkt supports (%d)", path, version.Int64, latest)
+	}
+	return int(version.Int64), nil
+}
+
+// RestoreDatabase replaces the database file with a copy of src, closing the open
+// connection first. Call Connect afterwards to use the restored database.
+func RestoreDatabase(src string) error {
+	dest, err := dbPath()
+	if err != nil {
+		return err
+	}
+	if err := Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+	DB = nil
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	defer func() { _ = in.Close() }()
+
+	// Copy next to the database and rename, so a failed copy leaves the old file intact
+	tmp := dest + ".restore"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	// A leftover rollback journal belongs to the replaced database
+	_ = os.Remove(dest + "-journal")
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupAndRestoreDatabase(t *testing.T) {
+	setupTestDB(t)
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := CreateProject("TEST001", "kept", "/tmp/kept", "go", "go"); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	backup := filepath.Join(t.TempDir(), "backup.db")
+	if err := BackupTo(backup); err != nil {
+		t.Fatalf("Failed to back up database: %v", err)
+	}
+	if _, err := os.Stat(backup + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected the temporary backup file to be gone")
+	}
+
+	version, err := InspectBackup(backup)
+	if err != nil {
+		t.Fatalf("Failed to inspect backup: %v", err)
+	}
+	current, _ := SchemaVersion()
+	if version != current {
+		t.Errorf("Expected backup schema version %d, got %d", current, version)
+	}
+
+	if err := RestoreDatabase(backup); err != nil {
+		t.Fatalf("Failed to restore database: %v", err)
+	}
+	if err := Connect(); err != nil {
+		t.Fatalf("Failed to connect to restored database: %v", err)
+	}
+	defer func() { _ = Close() }()
+	if _, err := GetProjectByID("TEST001"); err != nil {
+		t.Errorf("Expected restored database to hold the project: %v", err)
+	}
+}
+
+func TestInspectBackupRejectsOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.db")
+	if err := os.WriteFile(path, []byte("not a database"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InspectBackup(path); err == nil {
+		t.Error("Expected an error for a file that is not a database")
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir, err := BackupDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for i, name := range []string{"auto-a.tar.gz", "auto-b.tar.gz", "auto-c.tar.gz", "manual.tar.gz"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		// auto-a is the oldest
+		modTime := now.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := PruneBackups("auto-*.tar.gz", 2); err != nil {
+		t.Fatalf("Failed to prune backups: %v", err)
+	}
+
+	for name, kept := range map[string]bool{"auto-a.tar.gz": false, "auto-b.tar.gz": true, "auto-c.tar.gz": true, "manual.tar.gz": true} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if kept && err != nil {
+			t.Errorf("Expected %s to be kept", name)
+		}
+		if !kept && err == nil {
+			t.Errorf("Expected %s to be removed", name)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Component is an additional language and package manager inside a polyglot project,
+// such as a JavaScript frontend in the web/ folder of a Go backend. The project's own
+// language and package manager are its primary component and are not stored here.
+type Component struct {
+	ID             int
+	ProjectID      string
+	Name           string
+	Path           string // Directory relative to the project root ("." for the root itself)
+	Language       string
+	PackageManager string
+	CreatedAt      time.Time
+}
+
+// Dir returns the component's directory inside the project at projectPath
+func (c *Component) Dir(projectPath string) string {
+	return filepath.Join(projectPath, filepath.FromSlash(c.Path))
+}
+
+// componentColumns is the column list shared by every component query
+const componentColumns = `id, project_id, name, path, language, package_manager, created_at`
+
+// componentDest returns scan destinations matching componentColumns
+func componentDest(c *Component) []any {
+	return []any{&c.ID, &c.ProjectID, &c.Name, &c.Path, &c.Language, &c.PackageManager, &c.CreatedAt}
+}
+
+// SyncComponents replaces the components of a project with a new set. Dependencies
+// and directives of components that are no longer present are removed.
+func SyncComponents(projectID string, components []*Component) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM project_components WHERE project_id = ?", projectID); err != nil {
+		return fmt.Errorf("failed to delete existing components: %w", err)
+	}
+
+	query := `
+		INSERT INTO project_components (project_id, name, path, language, package_manager, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	for _, c := range components {
+		if c.Name == "" {
+			return fmt.Errorf("component name cannot be empty")
+		}
+		if _, err := tx.Exec(query, projectID, c.Name, c.Path, c.Language, c.PackageManager, time.Now()); err != nil {
+			return fmt.Errorf("failed to insert component %s: %w", c.Name, err)
+		}
+	}
+
+	// Drop data recorded for components that no longer exist
+	for _, table := range []string{"dependencies", "module_directives"} {
+		_, err := tx.Exec(`
+			DELETE FROM `+table+`
+			WHERE project_id = ? AND component != ''
+			AND component NOT IN (SELECT name FROM project_components WHERE project_id = ?)
+		`, projectID, projectID)
+		if err != nil {
+			return fmt.Errorf("failed to delete stale component %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetComponents retrieves the components of a project ordered by name
+func GetComponents(projectID string) ([]*Component, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	query := `SELECT ` + componentColumns + ` FROM project_components WHERE project_id = ? ORDER BY name`
+
+	rows, err := DB.Query(query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query components: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var components []*Component
+	for rows.Next() {
+		c := &Component{}
+		if err := rows.Scan(componentDest(c)...); err != nil {
+			return nil, fmt.Errorf("failed to scan component: %w", err)
+		}
+		components = append(components, c)
+	}
+
+	return components, rows.Err()
+}
+
+// GetComponent retrieves a project's component by name
+func GetComponent(projectID, name string) (*Component, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	query := `SELECT ` + componentColumns + ` FROM project_components WHERE project_id = ? AND name = ?`
+
+	c := &Component{}
+	err := DB.QueryRow(query, projectID, name).Scan(componentDest(c)...)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("component not found: %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSyncComponents(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("CMP001", "app", "/tmp/cmp-app", "go", "go")
+
+	err := SyncComponents("CMP001", []*Component{
+		{Name: "web", Path: "web", Language: "javascript", PackageManager: "pnpm"},
+		{Name: "python", Path: ".", Language: "python", PackageManager: "uv"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to sync components: %v", err)
+	}
+
+	components, err := GetComponents("CMP001")
+	if err != nil {
+		t.Fatalf("Failed to get components: %v", err)
+	}
+	if len(components) != 2 || components[0].Name != "python" || components[1].Name != "web" {
+		t.Fatalf("Expected components python and web, got %+v", components)
+	}
+
+	web, err := GetComponent("CMP001", "web")
+	if err != nil {
+		t.Fatalf("Failed to get component: %v", err)
+	}
+	if web.Language != "javascript" || web.PackageManager != "pnpm" {
+		t.Errorf("Unexpected component: %+v", web)
+	}
+	if got := web.Dir("/tmp/cmp-app"); got != filepath.Join("/tmp/cmp-app", "web") {
+		t.Errorf("Expected component dir /tmp/cmp-app/web, got %s", got)
+	}
+
+	if _, err := GetComponent("CMP001", "missing"); err == nil {
+		t.Error("Expected error for unknown component")
+	}
+}
+
+func TestComponentDependencies(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("CMP002", "app", "/tmp/cmp-deps", "go", "go")
+	_ = SyncComponents("CMP002", []*Component{
+		{Name: "web", Path: "web", Language: "javascript", PackageManager: "npm"},
+	})
+
+	// The same package name may appear once per component
+	_ = SyncDependencies("CMP002", map[string]*Dependency{
+		"yaml": {Name: "yaml", Version: "v3.0.1", DepType: "prod"},
+	})
+	_ = SyncComponentDependencies("CMP002", "web", map[string]*Dependency{
+		"yaml":  {Name: "yaml", Version: "^2.4.0", DepType: "prod"},
+		"react": {Name: "react", Version: "^18.2.0", DepType: "prod"},
+	})
+	_ = SyncComponentDirectives("CMP002", "web", []*Directive{
+		{Kind: DirectiveEngine, Path: "node", Version: ">=20"},
+	})
+
+	primary, _ := GetDependencies("CMP002")
+	if len(primary) != 1 || primary[0].Version != "v3.0.1" {
+		t.Errorf("Expected only the primary yaml dependency, got %+v", primary)
+	}
+
+	web, _ := GetComponentDependencies("CMP002", "web")
+	if len(web) != 2 {
+		t.Fatalf("Expected 2 web dependencies, got %d", len(web))
+	}
+	for _, dep := range web {
+		if dep.Component != "web" {
+			t.Errorf("Expected %s to belong to web, got %q", dep.Name, dep.Component)
+		}
+	}
+
+	// Syncing the primary set leaves components untouched
+	_ = SyncDependencies("CMP002", nil)
+	if web, _ := GetComponentDependencies("CMP002", "web"); len(web) != 2 {
+		t.Errorf("Expected web dependencies to be kept, got %d", len(web))
+	}
+
+	// Removing a component drops its dependencies and directives
+	if err := SyncComponents("CMP002", nil); err != nil {
+		t.Fatalf("Failed to clear components: %v", err)
+	}
+	if web, _ := GetComponentDependencies("CMP002", "web"); len(web) != 0 {
+		t.Errorf("Expected web dependencies to be removed, got %d", len(web))
+	}
+	if directives, _ := GetComponentDirectives("CMP002", "web"); len(directives) != 0 {
+		t.Errorf("Expected web directives to be removed, got %d", len(directives))
+	}
+}
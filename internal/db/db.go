@@ -2,17 +2,14 @@ package db
 
 import (
 	"database/sql"
-	_ "embed"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
 
-//go:embed schema.sql
-var schemaSQL string
-
 var DB *sql.DB
 
 // dbPath returns the path to the SQLite database file
@@ -24,8 +21,31 @@ func dbPath() (string, error) {
 	return filepath.Join(home, ".pkt", "pkt2.db"), nil
 }
 
-// Connect establishes a connection to the SQLite database
+// BackupDir returns the directory holding database backups
+func BackupDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".pkt", "backups"), nil
+}
+
+// Connect establishes a connection to the SQLite database and applies pending migrations
 func Connect() error {
+	if err := Open(); err != nil {
+		return err
+	}
+
+	// Run migrations to ensure the schema is current
+	if _, err := Migrate(); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return nil
+}
+
+// Open establishes a connection to the SQLite database without running migrations
+func Open() error {
 	path, err := dbPath()
 	if err != nil {
 		return err
@@ -37,8 +57,8 @@ func Connect() error {
 		return fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	// Open database connection
-	DB, err = sql.Open("sqlite", path)
+	// Open database connection; the DSN pragma applies to every pooled connection
+	DB, err = sql.Open("sqlite", path+"?_pragma=foreign_keys(1)")
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -53,12 +73,78 @@ func Connect() error {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Run migrations to ensure tables exist
+	return nil
+}
+
+// Migrate applies pending migrations, backing up the database file first when it
+// already holds data. It returns the backup path, or "" if no backup was needed.
+func Migrate() (string, error) {
+	pending, err := PendingMigrations()
+	if err != nil {
+		return "", err
+	}
+	if len(pending) == 0 {
+		return "", nil
+	}
+
+	var backupPath string
+	hasData, err := hasUserTables()
+	if err != nil {
+		return "", err
+	}
+	if hasData {
+		backupPath, err = backupDatabase()
+		if err != nil {
+			return "", fmt.Errorf("failed to back up database before migrating: %w", err)
+		}
+	}
+
 	if err := RunMigrations(); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+		return backupPath, err
 	}
 
-	return nil
+	return backupPath, nil
+}
+
+// hasUserTables reports whether the database contains any tables besides
+// schema_version, i.e. whether a migration could touch existing data
+func hasUserTables() (bool, error) {
+	var count int
+	err := DB.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_version'
+	`).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect database: %w", err)
+	}
+	return count > 0, nil
+}
+
+// backupDatabase snapshots the database into the backup directory, keeping the
+// newest BackupKeep migration backups
+func backupDatabase() (string, error) {
+	dir, err := BackupDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	version, err := SchemaVersion()
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("pkt2-v%d-%s.db", version, time.Now().Format("20060102-150405"))
+	dst := filepath.Join(dir, name)
+	if err := BackupTo(dst); err != nil {
+		return "", err
+	}
+
+	// Old backups left behind must not block the migration
+	_ = PruneBackups("pkt2-v*.db", BackupKeep)
+	return dst, nil
 }
 
 // InitDB creates the database file and runs migrations
@@ -72,20 +158,6 @@ func InitDB() error {
 	return RunMigrations()
 }
 
-// RunMigrations executes the schema SQL to create tables and indexes
-func RunMigrations() error {
-	if DB == nil {
-		return fmt.Errorf("database connection not established")
-	}
-
-	_, err := DB.Exec(schemaSQL)
-	if err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	return nil
-}
-
 // Close closes the database connection
 func Close() error {
 	if DB != nil {
@@ -9,14 +9,50 @@ import (
 type Dependency struct {
 	ID        int
 	ProjectID string
+	Component string // Component of a polyglot project; empty for the project's primary language
 	Name      string
-	Version   string
+	Version   string // Range declared in the manifest (empty for indirect dependencies)
+	Resolved  string // Version pinned by the lockfile, if any
 	DepType   string // "prod" or "dev"
+	Group     string // Manifest group, e.g. "main", "docs" or an extra name (empty when the ecosystem has none)
+	Indirect  bool   // Transitive dependency not declared in the manifest
 	CreatedAt time.Time
 }
 
-// SyncDependencies replaces all dependencies for a project with a new set
+// EffectiveVersion returns the resolved version when known, otherwise the declared one
+func (d *Dependency) EffectiveVersion() string {
+	if d.Resolved != "" {
+		return d.Resolved
+	}
+	return d.Version
+}
+
+// dependencyColumns is the column list shared by every dependency query
+const dependencyColumns = `id, project_id, component, name, version, resolved_version, dep_type, dep_group, indirect, created_at`
+
+// dependencyDest returns scan destinations matching dependencyColumns
+func dependencyDest(dep *Dependency) []any {
+	return []any{
+		&dep.ID,
+		&dep.ProjectID,
+		&dep.Component,
+		&dep.Name,
+		&dep.Version,
+		&dep.Resolved,
+		&dep.DepType,
+		&dep.Group,
+		&dep.Indirect,
+		&dep.CreatedAt,
+	}
+}
+
+// SyncDependencies replaces all dependencies of a project's primary language with a new set
 func SyncDependencies(projectID string, deps map[string]*Dependency) error {
+	return SyncComponentDependencies(projectID, "", deps)
+}
+
+// SyncComponentDependencies replaces the dependencies of one component of a project with a new set
+func SyncComponentDependencies(projectID, component string, deps map[string]*Dependency) error {
 	if DB == nil {
 		return fmt.Errorf("database not connected")
 	}
@@ -29,19 +65,19 @@ func SyncDependencies(projectID string, deps map[string]*Dependency) error {
 	defer func() { _ = tx.Rollback() }()
 
 	// Delete existing dependencies
-	_, err = tx.Exec("DELETE FROM dependencies WHERE project_id = ?", projectID)
+	_, err = tx.Exec("DELETE FROM dependencies WHERE project_id = ? AND component = ?", projectID, component)
 	if err != nil {
 		return fmt.Errorf("failed to delete existing dependencies: %w", err)
 	}
 
 	// Insert new dependencies
 	query := `
-		INSERT INTO dependencies (project_id, name, version, dep_type, created_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO dependencies (project_id, component, name, version, resolved_version, dep_type, dep_group, indirect, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	for _, dep := range deps {
-		_, err := tx.Exec(query, projectID, dep.Name, dep.Version, dep.DepType, time.Now())
+		_, err := tx.Exec(query, projectID, component, dep.Name, dep.Version, dep.Resolved, dep.DepType, dep.Group, dep.Indirect, time.Now())
 		if err != nil {
 			return fmt.Errorf("failed to insert dependency: %w", err)
 		}
@@ -55,20 +91,25 @@ func SyncDependencies(projectID string, deps map[string]*Dependency) error {
 	return nil
 }
 
-// GetDependencies retrieves all dependencies for a project
+// GetDependencies retrieves the dependencies of a project's primary language
 func GetDependencies(projectID string) ([]*Dependency, error) {
+	return GetComponentDependencies(projectID, "")
+}
+
+// GetComponentDependencies retrieves the dependencies of one component of a project
+func GetComponentDependencies(projectID, component string) ([]*Dependency, error) {
 	if DB == nil {
 		return nil, fmt.Errorf("database not connected")
 	}
 
 	query := `
-		SELECT id, project_id, name, version, dep_type, created_at
+		SELECT ` + dependencyColumns + `
 		FROM dependencies
-		WHERE project_id = ?
-		ORDER BY dep_type, name
+		WHERE project_id = ? AND component = ?
+		ORDER BY indirect, dep_type, name
 	`
 
-	rows, err := DB.Query(query, projectID)
+	rows, err := DB.Query(query, projectID, component)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query dependencies: %w", err)
 	}
@@ -77,19 +118,137 @@ func GetDependencies(projectID string) ([]*Dependency, error) {
 	var dependencies []*Dependency
 	for rows.Next() {
 		dep := &Dependency{}
-		err := rows.Scan(
-			&dep.ID,
-			&dep.ProjectID,
-			&dep.Name,
-			&dep.Version,
-			&dep.DepType,
-			&dep.CreatedAt,
-		)
-		if err != nil {
+		if err := rows.Scan(dependencyDest(dep)...); err != nil {
 			return nil, fmt.Errorf("failed to scan dependency: %w", err)
 		}
 		dependencies = append(dependencies, dep)
 	}
 
-	return dependencies, nil
+	return dependencies, rows.Err()
+}
+
+// DependencyUsage pairs a dependency with the project that declares it
+type DependencyUsage struct {
+	Project    *Project
+	Dependency *Dependency
+}
+
+// VersionUsage lists the projects using one version of a package. Components[i] is
+// the component of Projects[i] declaring it, empty for the project's primary language.
+type VersionUsage struct {
+	Version    string
+	Projects   []*Project
+	Components []string
+}
+
+// VersionDrift describes a package of one language declared with different versions
+// across projects
+type VersionDrift struct {
+	Name     string
+	Language string
+	Versions []*VersionUsage
+}
+
+// effectiveVersion returns the SQL expression for the resolved version, falling back
+// to the declared one, optionally qualified with a table alias
+func effectiveVersion(alias string) string {
+	if alias != "" {
+		alias += "."
+	}
+	return "COALESCE(NULLIF(" + alias + "resolved_version, ''), " + alias + "version)"
+}
+
+// FindDependents retrieves every project that depends on a package (case-insensitive)
+func FindDependents(name string) ([]*DependencyUsage, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	query := `
+		SELECT ` + qualifyColumns("d", dependencyColumns) + `, ` + qualifyColumns("p", projectColumns) + `
+		FROM dependencies d
+		JOIN projects p ON p.id = d.project_id
+		WHERE LOWER(d.name) = LOWER(?)
+		ORDER BY d.indirect, p.name, d.component, d.version
+	`
+
+	rows, err := DB.Query(query, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependents: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var usages []*DependencyUsage
+	for rows.Next() {
+		dep := &Dependency{}
+		project := &Project{}
+		if err := rows.Scan(append(dependencyDest(dep), projectDest(project)...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan dependent: %w", err)
+		}
+		usages = append(usages, &DependencyUsage{Project: project, Dependency: dep})
+	}
+
+	return usages, rows.Err()
+}
+
+// GetVersionDrift retrieves direct dependencies that resolve to more than one version
+// across tracked projects, grouped by version. Packages are compared within a language,
+// taken from the component for component dependencies, so same-named packages of
+// different ecosystems don't drift. The lockfile version is used when known, otherwise
+// the declared range.
+func GetVersionDrift() ([]*VersionDrift, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	usages := `
+		SELECT d.project_id, d.component, LOWER(d.name) AS name,
+			CASE WHEN d.component <> '' THEN COALESCE(c.language, p.language) ELSE p.language END AS language,
+			` + effectiveVersion("d") + ` AS v
+		FROM dependencies d
+		JOIN projects p ON p.id = d.project_id
+		LEFT JOIN project_components c ON c.project_id = d.project_id AND c.name = d.component
+		WHERE d.indirect = 0
+	`
+	query := `
+		WITH usages AS (` + usages + `)
+		SELECT DISTINCT u.name, u.language, u.v, u.component, ` + qualifyColumns("p", projectColumns) + `
+		FROM usages u
+		JOIN projects p ON p.id = u.project_id
+		WHERE (u.name, u.language) IN (
+			SELECT name, language FROM usages
+			GROUP BY name, language
+			HAVING COUNT(DISTINCT v) > 1
+		)
+		ORDER BY u.name, u.language, u.v, p.name, u.component
+	`
+
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query version drift: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var drifts []*VersionDrift
+	for rows.Next() {
+		var name, language, version, component string
+		project := &Project{}
+		if err := rows.Scan(append([]any{&name, &language, &version, &component}, projectDest(project)...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan version drift: %w", err)
+		}
+
+		if len(drifts) == 0 || drifts[len(drifts)-1].Name != name || drifts[len(drifts)-1].Language != language {
+			drifts = append(drifts, &VersionDrift{Name: name, Language: language})
+		}
+		drift := drifts[len(drifts)-1]
+
+		if len(drift.Versions) == 0 || drift.Versions[len(drift.Versions)-1].Version != version {
+			drift.Versions = append(drift.Versions, &VersionUsage{Version: version})
+		}
+		usage := drift.Versions[len(drift.Versions)-1]
+		usage.Projects = append(usage.Projects, project)
+		usage.Components = append(usage.Components, component)
+	}
+
+	return drifts, rows.Err()
 }
@@ -199,3 +199,220 @@ func TestDependenciesDBNotConnected(t *testing.T) {
 		t.Errorf("Expected 'database not connected' error, got: %v", err)
 	}
 }
+
+func TestFindDependents(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("WHO001", "api", "/tmp/who-api", "javascript", "pnpm")
+	_, _ = CreateProject("WHO002", "web", "/tmp/who-web", "javascript", "npm")
+	_, _ = CreateProject("WHO003", "cli", "/tmp/who-cli", "go", "go")
+
+	_ = SyncDependencies("WHO001", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "^4.17.21", DepType: "prod"},
+	})
+	_ = SyncDependencies("WHO002", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "^4.17.15", DepType: "dev"},
+		"react":  {Name: "react", Version: "^18.0.0", DepType: "prod"},
+	})
+
+	usages, err := FindDependents("Lodash")
+	if err != nil {
+		t.Fatalf("Failed to find dependents: %v", err)
+	}
+
+	if len(usages) != 2 {
+		t.Fatalf("Expected 2 dependents, got %d", len(usages))
+	}
+	if usages[0].Project.Name != "api" || usages[0].Dependency.Version != "^4.17.21" {
+		t.Errorf("Unexpected first dependent: %s %s", usages[0].Project.Name, usages[0].Dependency.Version)
+	}
+	if usages[1].Project.Name != "web" || usages[1].Dependency.DepType != "dev" {
+		t.Errorf("Unexpected second dependent: %s %s", usages[1].Project.Name, usages[1].Dependency.DepType)
+	}
+
+	usages, err = FindDependents("left-pad")
+	if err != nil {
+		t.Fatalf("Failed to find dependents: %v", err)
+	}
+	if len(usages) != 0 {
+		t.Errorf("Expected no dependents for unused package, got %d", len(usages))
+	}
+}
+
+func TestGetVersionDrift(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("DRIFT001", "a", "/tmp/drift-a", "javascript", "pnpm")
+	_, _ = CreateProject("DRIFT002", "b", "/tmp/drift-b", "javascript", "pnpm")
+	_, _ = CreateProject("DRIFT003", "c", "/tmp/drift-c", "javascript", "pnpm")
+
+	_ = SyncDependencies("DRIFT001", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "4.17.21", DepType: "prod"},
+		"react":  {Name: "react", Version: "18.2.0", DepType: "prod"},
+	})
+	_ = SyncDependencies("DRIFT002", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "4.17.15", DepType: "prod"},
+		"react":  {Name: "react", Version: "18.2.0", DepType: "prod"},
+	})
+	_ = SyncDependencies("DRIFT003", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "4.17.21", DepType: "prod"},
+	})
+
+	drifts, err := GetVersionDrift()
+	if err != nil {
+		t.Fatalf("Failed to get version drift: %v", err)
+	}
+
+	if len(drifts) != 1 {
+		t.Fatalf("Expected 1 drifting package, got %d", len(drifts))
+	}
+	if drifts[0].Name != "lodash" {
+		t.Errorf("Expected drifting package 'lodash', got '%s'", drifts[0].Name)
+	}
+	if len(drifts[0].Versions) != 2 {
+		t.Fatalf("Expected 2 versions of lodash, got %d", len(drifts[0].Versions))
+	}
+	if drifts[0].Versions[1].Version != "4.17.21" || len(drifts[0].Versions[1].Projects) != 2 {
+		t.Errorf("Expected 4.17.21 to be used by 2 projects, got %s with %d",
+			drifts[0].Versions[1].Version, len(drifts[0].Versions[1].Projects))
+	}
+}
+
+func TestGetVersionDriftByLanguage(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("DRIFT010", "api", "/tmp/drift-api", "go", "go")
+	_, _ = CreateProject("DRIFT011", "site", "/tmp/drift-site", "javascript", "npm")
+	_ = SyncComponents("DRIFT010", []*Component{
+		{Name: "web", Path: "web", Language: "javascript", PackageManager: "npm"},
+		{Name: "admin", Path: "admin", Language: "javascript", PackageManager: "npm"},
+	})
+
+	// The Go module yaml is a different package from the npm package yaml
+	_ = SyncDependencies("DRIFT010", map[string]*Dependency{
+		"yaml": {Name: "yaml", Version: "v3.0.1", DepType: "prod"},
+	})
+	_ = SyncComponentDependencies("DRIFT010", "web", map[string]*Dependency{
+		"yaml": {Name: "yaml", Version: "2.4.0", DepType: "prod"},
+	})
+	_ = SyncComponentDependencies("DRIFT010", "admin", map[string]*Dependency{
+		"yaml": {Name: "yaml", Version: "2.4.0", DepType: "prod"},
+		"Yaml": {Name: "Yaml", Version: "2.4.0", DepType: "prod"},
+	})
+	_ = SyncDependencies("DRIFT011", map[string]*Dependency{
+		"yaml": {Name: "yaml", Version: "2.3.0", DepType: "prod"},
+	})
+
+	drifts, err := GetVersionDrift()
+	if err != nil {
+		t.Fatalf("Failed to get version drift: %v", err)
+	}
+
+	if len(drifts) != 1 || drifts[0].Language != "javascript" {
+		t.Fatalf("Expected only the javascript yaml to drift, got %d packages", len(drifts))
+	}
+	versions := drifts[0].Versions
+	if len(versions) != 2 || versions[0].Version != "2.3.0" || versions[1].Version != "2.4.0" {
+		t.Fatalf("Expected versions 2.3.0 and 2.4.0, got %d versions", len(versions))
+	}
+
+	// Each component is listed once, even with the package declared twice
+	shared := versions[1]
+	if len(shared.Projects) != 2 || shared.Components[0] != "admin" || shared.Components[1] != "web" {
+		t.Errorf("Expected 2.4.0 to be used by api/admin and api/web, got %v", shared.Components)
+	}
+}
+
+func TestSyncDependenciesResolved(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("RES001", "web", "/tmp/res-web", "javascript", "npm")
+
+	err := SyncDependencies("RES001", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "^4.17.0", Resolved: "4.17.21", DepType: "prod"},
+		"ms":     {Name: "ms", Resolved: "2.1.2", DepType: "prod", Indirect: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to sync dependencies: %v", err)
+	}
+
+	deps, err := GetDependencies("RES001")
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 dependencies, got %d", len(deps))
+	}
+
+	// Direct dependencies are listed before indirect ones
+	if deps[0].Name != "lodash" || deps[0].Resolved != "4.17.21" || deps[0].Indirect {
+		t.Errorf("Unexpected direct dependency: %+v", deps[0])
+	}
+	if deps[1].Name != "ms" || !deps[1].Indirect || deps[1].Version != "" {
+		t.Errorf("Unexpected indirect dependency: %+v", deps[1])
+	}
+	if deps[0].EffectiveVersion() != "4.17.21" {
+		t.Errorf("Expected effective version 4.17.21, got %s", deps[0].EffectiveVersion())
+	}
+}
+
+func TestGetVersionDriftUsesResolved(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("RES010", "a", "/tmp/res-a", "javascript", "npm")
+	_, _ = CreateProject("RES011", "b", "/tmp/res-b", "javascript", "npm")
+
+	// Same resolved version behind different ranges is not drift;
+	// different versions of an indirect dependency are ignored
+	_ = SyncDependencies("RES010", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "^4.17.0", Resolved: "4.17.21", DepType: "prod"},
+		"react":  {Name: "react", Version: "^18.0.0", Resolved: "18.2.0", DepType: "prod"},
+		"ms":     {Name: "ms", Resolved: "2.1.2", DepType: "prod", Indirect: true},
+	})
+	_ = SyncDependencies("RES011", map[string]*Dependency{
+		"lodash": {Name: "lodash", Version: "^4.0.0", Resolved: "4.17.21", DepType: "prod"},
+		"react":  {Name: "react", Version: "^18.0.0", Resolved: "18.3.1", DepType: "prod"},
+		"ms":     {Name: "ms", Resolved: "2.1.3", DepType: "prod", Indirect: true},
+	})
+
+	drifts, err := GetVersionDrift()
+	if err != nil {
+		t.Fatalf("Failed to get version drift: %v", err)
+	}
+
+	if len(drifts) != 1 || drifts[0].Name != "react" {
+		t.Fatalf("Expected only react to drift, got %d packages", len(drifts))
+	}
+	if drifts[0].Versions[0].Version != "18.2.0" || drifts[0].Versions[1].Version != "18.3.1" {
+		t.Errorf("Expected resolved versions 18.2.0 and 18.3.1, got %s and %s",
+			drifts[0].Versions[0].Version, drifts[0].Versions[1].Version)
+	}
+}
+
+func TestSyncDependenciesGroups(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("GRP001", "api", "/tmp/grp-api", "python", "poetry")
+
+	err := SyncDependencies("GRP001", map[string]*Dependency{
+		"fastapi": {Name: "fastapi", Version: "^0.110", DepType: "prod", Group: "main"},
+		"sphinx":  {Name: "sphinx", Version: "^7.2", DepType: "dev", Group: "docs"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to sync dependencies: %v", err)
+	}
+
+	deps, err := GetDependencies("GRP001")
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 dependencies, got %d", len(deps))
+	}
+	if deps[0].Name != "sphinx" || deps[0].Group != "docs" {
+		t.Errorf("Unexpected dev dependency: %+v", deps[0])
+	}
+	if deps[1].Name != "fastapi" || deps[1].Group != "main" {
+		t.Errorf("Unexpected prod dependency: %+v", deps[1])
+	}
+}
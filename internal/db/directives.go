@@ -0,0 +1,117 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// Directive kinds recorded from go.mod
+const (
+	DirectiveReplace   = "replace"
+	DirectiveExclude   = "exclude"
+	DirectiveRetract   = "retract"
+	DirectiveToolchain = "toolchain"
+	DirectiveTool      = "tool"
+)
+
+// Directive kinds recorded from package.json
+const (
+	DirectiveEngine         = "engine"
+	DirectivePackageManager = "packageManager"
+)
+
+// Directive is a manifest directive that is not a requirement, such as a go.mod
+// replace line or a package.json engine constraint
+type Directive struct {
+	ID            int
+	ProjectID     string
+	Component     string // Component of a polyglot project; empty for the project's primary language
+	Kind          string
+	Path          string // Module, package or engine name; empty for toolchain and retract
+	Version       string // Version the directive applies to (a range "[v1, v2]" for retractions)
+	Target        string // Replacement module or local directory
+	TargetVersion string // Replacement version, empty for local directories
+	Note          string // Retraction rationale
+	CreatedAt     time.Time
+}
+
+// IsLocalReplace reports whether a replace directive points at a local directory
+func (d *Directive) IsLocalReplace() bool {
+	return d.Kind == DirectiveReplace && d.TargetVersion == ""
+}
+
+// SyncDirectives replaces the module directives of a project's primary language with a new set
+func SyncDirectives(projectID string, directives []*Directive) error {
+	return SyncComponentDirectives(projectID, "", directives)
+}
+
+// SyncComponentDirectives replaces the module directives of one component of a project
+func SyncComponentDirectives(projectID, component string, directives []*Directive) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM module_directives WHERE project_id = ? AND component = ?", projectID, component); err != nil {
+		return fmt.Errorf("failed to delete existing directives: %w", err)
+	}
+
+	query := `
+		INSERT INTO module_directives (project_id, component, kind, path, version, target, target_version, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	for _, d := range directives {
+		_, err := tx.Exec(query, projectID, component, d.Kind, d.Path, d.Version, d.Target, d.TargetVersion, d.Note, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to insert directive: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetDirectives retrieves the module directives of a project's primary language in declaration order
+func GetDirectives(projectID string) ([]*Directive, error) {
+	return GetComponentDirectives(projectID, "")
+}
+
+// GetComponentDirectives retrieves the module directives of one component of a project
+func GetComponentDirectives(projectID, component string) ([]*Directive, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	query := `
+		SELECT id, project_id, component, kind, path, version, target, target_version, note, created_at
+		FROM module_directives
+		WHERE project_id = ? AND component = ?
+		ORDER BY id
+	`
+
+	rows, err := DB.Query(query, projectID, component)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query directives: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var directives []*Directive
+	for rows.Next() {
+		d := &Directive{}
+		if err := rows.Scan(&d.ID, &d.ProjectID, &d.Component, &d.Kind, &d.Path, &d.Version, &d.Target, &d.TargetVersion, &d.Note, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan directive: %w", err)
+		}
+		directives = append(directives, d)
+	}
+
+	return directives, rows.Err()
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestSyncDirectives(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("DIR001", "svc", "/tmp/dir-svc", "go", "go")
+
+	err := SyncDirectives("DIR001", []*Directive{
+		{Kind: DirectiveReplace, Path: "example.com/shared", Target: "../shared"},
+		{Kind: DirectiveToolchain, Version: "go1.24.2"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to sync directives: %v", err)
+	}
+
+	directives, err := GetDirectives("DIR001")
+	if err != nil {
+		t.Fatalf("Failed to get directives: %v", err)
+	}
+	if len(directives) != 2 {
+		t.Fatalf("Expected 2 directives, got %d", len(directives))
+	}
+	if directives[0].Kind != DirectiveReplace || directives[0].Target != "../shared" || !directives[0].IsLocalReplace() {
+		t.Errorf("Unexpected replace directive: %+v", directives[0])
+	}
+	if directives[1].Kind != DirectiveToolchain || directives[1].Version != "go1.24.2" {
+		t.Errorf("Unexpected toolchain directive: %+v", directives[1])
+	}
+
+	// Syncing again replaces the previous set
+	if err := SyncDirectives("DIR001", nil); err != nil {
+		t.Fatalf("Failed to clear directives: %v", err)
+	}
+	directives, _ = GetDirectives("DIR001")
+	if len(directives) != 0 {
+		t.Errorf("Expected directives to be cleared, got %d", len(directives))
+	}
+}
+
+func TestDirectivesDBNotConnected(t *testing.T) {
+	DB = nil
+
+	if err := SyncDirectives("X", nil); err == nil || err.Error() != "database not connected" {
+		t.Errorf("Expected 'database not connected' error, got: %v", err)
+	}
+	if _, err := GetDirectives("X"); err == nil || err.Error() != "database not connected" {
+		t.Errorf("Expected 'database not connected' error, got: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"fmt"
+)
+
+// projectTables lists the tables whose rows belong to a project, with the column referencing it
+var projectTables = []struct {
+	Table  string
+	Column string
+}{
+	{"projects", "parent_id"},
+	{"dependencies", "project_id"},
+	{"module_directives", "project_id"},
+	{"project_components", "project_id"},
+	{"project_tags", "project_id"},
+}
+
+// IntegrityCheck runs SQLite's integrity check and returns the problems it reports,
+// or nil when the database is healthy
+func IntegrityCheck() ([]string, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	rows, err := DB.Query("PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, fmt.Errorf("failed to read integrity check: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	return problems, rows.Err()
+}
+
+// OrphanCounts returns, per table, how many rows reference a project that no longer
+// exists. Such rows are left behind when a database was written without foreign keys.
+func OrphanCounts() (map[string]int, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	counts := make(map[string]int)
+	for _, t := range projectTables {
+		var count int
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s IS NOT NULL AND %s NOT IN (SELECT id FROM projects)`, t.Table, t.Column, t.Column)
+		if err := DB.QueryRow(query).Scan(&count); err != nil {
+			return nil, fmt.Errorf("failed to count orphaned %s: %w", t.Table, err)
+		}
+		if count > 0 {
+			counts[t.Table] = count
+		}
+	}
+	return counts, nil
+}
+
+// DeleteOrphans removes every row that references a project that no longer exists
+// and returns how many were removed
+func DeleteOrphans() (int64, error) {
+	if DB == nil {
+		return 0, fmt.Errorf("database not connected")
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var removed int64
+	for _, t := range projectTables {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE %s IS NOT NULL AND %s NOT IN (SELECT id FROM projects)`, t.Table, t.Column, t.Column)
+		result, err := tx.Exec(query)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete orphaned %s: %w", t.Table, err)
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		removed += count
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return removed, nil
+}
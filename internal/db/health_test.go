@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIntegrityCheck(t *testing.T) {
+	setupTestDB(t)
+
+	problems, err := IntegrityCheck()
+	if err != nil {
+		t.Fatalf("Failed to run integrity check: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("Expected a healthy database, got %v", problems)
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("ORP001", "kept", "/tmp/orp-kept", "go", "go")
+	_, _ = CreateProject("ORP002", "gone", "/tmp/orp-gone", "javascript", "npm")
+	_ = SyncDependencies("ORP001", map[string]*Dependency{
+		"yaml": {Name: "yaml", Version: "v3.0.1", DepType: "prod"},
+	})
+	_ = SyncDependencies("ORP002", map[string]*Dependency{
+		"react": {Name: "react", Version: "^18.2.0", DepType: "prod"},
+		"vite":  {Name: "vite", Version: "^5.0.0", DepType: "dev"},
+	})
+	_ = AddTags("ORP002", "web")
+
+	counts, err := OrphanCounts()
+	if err != nil {
+		t.Fatalf("Failed to count orphans: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("Expected no orphans, got %v", counts)
+	}
+
+	// Deleting without foreign keys leaves the project's rows behind
+	conn, err := DB.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get connection: %v", err)
+	}
+	_, _ = conn.ExecContext(context.Background(), "PRAGMA foreign_keys = OFF")
+	if _, err := conn.ExecContext(context.Background(), "DELETE FROM projects WHERE id = 'ORP002'"); err != nil {
+		t.Fatalf("Failed to delete project: %v", err)
+	}
+	_, _ = conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON")
+	_ = conn.Close()
+
+	counts, _ = OrphanCounts()
+	if counts["dependencies"] != 2 || counts["project_tags"] != 1 || len(counts) != 2 {
+		t.Errorf("Expected 2 orphaned dependencies and 1 tag, got %v", counts)
+	}
+
+	removed, err := DeleteOrphans()
+	if err != nil {
+		t.Fatalf("Failed to delete orphans: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("Expected 3 rows removed, got %d", removed)
+	}
+
+	counts, _ = OrphanCounts()
+	if len(counts) != 0 {
+		t.Errorf("Expected no orphans after cleanup, got %v", counts)
+	}
+	if deps, _ := GetDependencies("ORP001"); len(deps) != 1 {
+		t.Errorf("Expected the kept project's dependencies to remain, got %+v", deps)
+	}
+}
@@ -0,0 +1,231 @@
+package db
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// Migration is a single numbered schema change
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// MigrationStatus reports whether a migration has been applied to the database
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// loadMigrations reads the embedded migration files, named NNNN_description.sql,
+// and returns them sorted by version
+func loadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(entry.Name(), ".sql")
+		prefix, name, found := strings.Cut(base, "_")
+		if !found {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		if other, exists := seen[version]; exists {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, other, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		data, err := migrationFS.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    name,
+			SQL:     string(data),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// ensureVersionTable creates the schema_version bookkeeping table
+func ensureVersionTable() error {
+	_, err := DB.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+	return nil
+}
+
+// appliedMigrations returns the applied migration versions and when they ran
+func appliedMigrations() (map[int]time.Time, error) {
+	if err := ensureVersionTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := DB.Query(`SELECT version, applied_at FROM schema_version`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema version: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema version: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// SchemaVersion returns the highest applied migration version (0 for a fresh database)
+func SchemaVersion() (int, error) {
+	if DB == nil {
+		return 0, fmt.Errorf("database not connected")
+	}
+
+	applied, err := appliedMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// MigrationStatuses lists every known migration along with its applied state
+func MigrationStatuses() ([]MigrationStatus, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		appliedAt, ok := applied[m.Version]
+		statuses[i] = MigrationStatus{
+			Migration: m,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		}
+	}
+
+	return statuses, nil
+}
+
+// PendingMigrations returns the migrations that have not been applied yet
+func PendingMigrations() ([]Migration, error) {
+	statuses, err := MigrationStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// RunMigrations applies all pending migrations in version order.
+// Each migration runs in its own transaction together with its schema_version row,
+// so a failing migration leaves the database at the previous version.
+func RunMigrations() error {
+	if DB == nil {
+		return fmt.Errorf("database connection not established")
+	}
+
+	pending, err := PendingMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		if err := applyMigration(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration inside a transaction
+func applyMigration(m Migration) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(m.SQL); err != nil {
+		return fmt.Errorf("migration %04d_%s failed: %w", m.Version, m.Name, err)
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.Version, m.Name, time.Now(),
+	); err != nil {
+		return fmt.Errorf("failed to record migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+-- Create project tags table
+CREATE TABLE IF NOT EXISTS project_tags (
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_tags_tag ON project_tags(tag);
@@ -0,0 +1,4 @@
+-- Record the version resolved by the lockfile next to the declared range,
+-- and flag transitive dependencies that are not declared in the manifest
+ALTER TABLE dependencies ADD COLUMN resolved_version TEXT NOT NULL DEFAULT '';
+ALTER TABLE dependencies ADD COLUMN indirect BOOLEAN NOT NULL DEFAULT 0;
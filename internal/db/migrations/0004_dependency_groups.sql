@@ -0,0 +1,3 @@
+-- Record the manifest group a dependency belongs to (e.g. "main", "docs", "build"),
+-- which is finer-grained than prod/dev
+ALTER TABLE dependencies ADD COLUMN dep_group TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,15 @@
+-- Module directives other than requirements (Go replace, exclude, retract,
+-- toolchain and tool), one row per directive
+CREATE TABLE IF NOT EXISTS module_directives (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    path TEXT NOT NULL DEFAULT '',
+    version TEXT NOT NULL DEFAULT '',
+    target TEXT NOT NULL DEFAULT '',
+    target_version TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_module_directives_project ON module_directives(project_id);
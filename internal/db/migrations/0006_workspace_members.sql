@@ -0,0 +1,5 @@
+-- Link workspace members (npm/pnpm workspaces, Cargo members, go.work modules,
+-- uv members) to the monorepo project that contains them
+ALTER TABLE projects ADD COLUMN parent_id TEXT REFERENCES projects(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_projects_parent_id ON projects(parent_id);
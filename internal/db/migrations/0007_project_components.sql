@@ -0,0 +1,42 @@
+-- Additional language/package-manager roots of polyglot projects, such as a
+-- JavaScript frontend in the web/ folder of a Go backend
+CREATE TABLE IF NOT EXISTS project_components (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    path TEXT NOT NULL DEFAULT '.',
+    language TEXT NOT NULL,
+    package_manager TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(project_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_components_project ON project_components(project_id);
+
+-- Dependencies and directives record the component they belong to; the empty
+-- component is the project's primary language. The dependencies table is rebuilt
+-- so the same package name can appear once per component.
+CREATE TABLE dependencies_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    component TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    version TEXT NOT NULL,
+    resolved_version TEXT NOT NULL DEFAULT '',
+    dep_type TEXT NOT NULL CHECK (dep_type IN ('prod', 'dev')),
+    dep_group TEXT NOT NULL DEFAULT '',
+    indirect BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(project_id, component, name)
+);
+
+INSERT INTO dependencies_new (id, project_id, name, version, resolved_version, dep_type, dep_group, indirect, created_at)
+SELECT id, project_id, name, version, resolved_version, dep_type, dep_group, indirect, created_at
+FROM dependencies;
+
+DROP TABLE dependencies;
+ALTER TABLE dependencies_new RENAME TO dependencies;
+
+CREATE INDEX IF NOT EXISTS idx_dependencies_project_id ON dependencies(project_id);
+
+ALTER TABLE module_directives ADD COLUMN component TEXT NOT NULL DEFAULT '';
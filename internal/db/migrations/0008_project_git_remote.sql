@@ -0,0 +1,3 @@
+-- Remember the origin remote of each project so a project whose folder was
+-- moved outside pkt can be found again by its remote
+ALTER TABLE projects ADD COLUMN git_remote TEXT NOT NULL DEFAULT '';
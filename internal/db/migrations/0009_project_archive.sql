@@ -0,0 +1,3 @@
+-- Archived projects are compressed into a tarball and their folder removed; they
+-- stay tracked (with their dependencies) until they are unarchived
+ALTER TABLE projects ADD COLUMN archive_path TEXT NOT NULL DEFAULT '';
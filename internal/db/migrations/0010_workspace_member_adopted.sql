@@ -0,0 +1,3 @@
+-- Remember which workspace members were tracked on their own before their workspace
+-- root adopted them, so they are detached rather than untracked when they leave it
+ALTER TABLE projects ADD COLUMN adopted INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestLoadMigrationsOrdered(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatal("Expected at least one migration")
+	}
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("Expected migration %d to have version %d, got %d", i, i+1, m.Version)
+		}
+		if m.Name == "" {
+			t.Errorf("Migration %d has an empty name", m.Version)
+		}
+	}
+}
+
+func TestRunMigrationsRecordsVersion(t *testing.T) {
+	setupTestDB(t)
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	version, err := SchemaVersion()
+	if err != nil {
+		t.Fatalf("Failed to get schema version: %v", err)
+	}
+	if version != migrations[len(migrations)-1].Version {
+		t.Errorf("Expected schema version %d, got %d", migrations[len(migrations)-1].Version, version)
+	}
+
+	pending, err := PendingMigrations()
+	if err != nil {
+		t.Fatalf("Failed to get pending migrations: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending migrations, got %d", len(pending))
+	}
+}
+
+func TestRunMigrationsIdempotent(t *testing.T) {
+	setupTestDB(t)
+
+	if err := RunMigrations(); err != nil {
+		t.Fatalf("Second migration run failed: %v", err)
+	}
+
+	statuses, err := MigrationStatuses()
+	if err != nil {
+		t.Fatalf("Failed to get migration statuses: %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied {
+			t.Errorf("Expected migration %04d_%s to be applied", s.Version, s.Name)
+		}
+	}
+}
+
+func TestRunMigrationsLegacyDatabase(t *testing.T) {
+	setupTestDB(t)
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	// Simulate a database created before schema_version existed: only the
+	// original schema, without any later migration
+	rows, err := DB.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		t.Fatalf("Failed to list tables: %v", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("Failed to scan table name: %v", err)
+		}
+		tables = append(tables, name)
+	}
+	_ = rows.Close()
+
+	for _, table := range tables {
+		if _, err := DB.Exec("DROP TABLE " + table); err != nil {
+			t.Fatalf("Failed to drop %s: %v", table, err)
+		}
+	}
+	if _, err := DB.Exec(migrations[0].SQL); err != nil {
+		t.Fatalf("Failed to create legacy schema: %v", err)
+	}
+	if _, err := DB.Exec(
+		`INSERT INTO projects (id, name, path, language, package_manager) VALUES (?, ?, ?, ?, ?)`,
+		"LEGACY001", "legacy", "/tmp/legacy", "go", "go",
+	); err != nil {
+		t.Fatalf("Failed to insert legacy project: %v", err)
+	}
+
+	hasData, err := hasUserTables()
+	if err != nil {
+		t.Fatalf("Failed to inspect tables: %v", err)
+	}
+	if !hasData {
+		t.Error("Expected legacy database to report existing tables")
+	}
+
+	if err := RunMigrations(); err != nil {
+		t.Fatalf("Failed to migrate legacy database: %v", err)
+	}
+
+	project, err := GetProjectByID("LEGACY001")
+	if err != nil {
+		t.Fatalf("Expected legacy project to survive migration: %v", err)
+	}
+	if project.Name != "legacy" {
+		t.Errorf("Expected Name 'legacy', got '%s'", project.Name)
+	}
+}
+
+func TestApplyMigrationRollsBackOnError(t *testing.T) {
+	setupTestDB(t)
+
+	bad := Migration{
+		Version: 9999,
+		Name:    "broken",
+		SQL:     "CREATE TABLE half_done (id INTEGER); INSERT INTO no_such_table VALUES (1);",
+	}
+
+	if err := applyMigration(bad); err == nil {
+		t.Fatal("Expected error from broken migration, got nil")
+	}
+
+	var count int
+	if err := DB.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'half_done'").Scan(&count); err != nil {
+		t.Fatalf("Failed to query sqlite_master: %v", err)
+	}
+	if count != 0 {
+		t.Error("Expected partially applied migration to be rolled back")
+	}
+
+	if err := DB.QueryRow("SELECT COUNT(*) FROM schema_version WHERE version = 9999").Scan(&count); err != nil {
+		t.Fatalf("Failed to query schema_version: %v", err)
+	}
+	if count != 0 {
+		t.Error("Expected failed migration not to be recorded")
+	}
+}
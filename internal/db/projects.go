@@ -3,7 +3,10 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Project represents a tracked project
@@ -13,9 +16,119 @@ type Project struct {
 	Path           string
 	Language       string
 	PackageManager string
+	ParentID       string // Workspace root this project is a member of, empty for top-level projects
+	GitRemote      string // URL of the origin remote when the project was last checked, if any
+	ArchivePath    string // Tarball holding the project while it is archived, empty otherwise
+	Adopted        bool   // Member that was tracked on its own before its workspace root adopted it
 	CreatedAt      time.Time
 }
 
+// IsMember reports whether the project is a member of a workspace
+func (p *Project) IsMember() bool {
+	return p.ParentID != ""
+}
+
+// IsArchived reports whether the project's folder was replaced by an archive
+func (p *Project) IsArchived() bool {
+	return p.ArchivePath != ""
+}
+
+// ProjectFilter narrows a project listing; zero-value fields match everything
+type ProjectFilter struct {
+	Language        string
+	Tag             string
+	ExcludeMembers  bool // Only list top-level projects, not workspace members
+	IncludeArchived bool // Also list archived projects, whose folder does not exist
+}
+
+// projectColumns is the column list shared by every project query
+const projectColumns = `id, name, path, language, package_manager, parent_id, git_remote, archive_path, adopted, created_at`
+
+// qualifyColumns prefixes each column in a comma-separated list with a table alias for joins
+func qualifyColumns(alias, columns string) string {
+	cols := strings.Split(columns, ", ")
+	for i, col := range cols {
+		cols[i] = alias + "." + col
+	}
+	return strings.Join(cols, ", ")
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// projectDest returns scan destinations matching projectColumns
+func projectDest(project *Project) []any {
+	return []any{
+		&project.ID,
+		&project.Name,
+		&project.Path,
+		&project.Language,
+		&project.PackageManager,
+		nullableString{&project.ParentID},
+		&project.GitRemote,
+		&project.ArchivePath,
+		&project.Adopted,
+		&project.CreatedAt,
+	}
+}
+
+// nullableString scans a nullable TEXT column into a string, mapping NULL to ""
+type nullableString struct {
+	dest *string
+}
+
+// Scan implements sql.Scanner
+func (n nullableString) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*n.dest = ""
+	case string:
+		*n.dest = v
+	case []byte:
+		*n.dest = string(v)
+	default:
+		return fmt.Errorf("unexpected value %v for a text column", value)
+	}
+	return nil
+}
+
+// nullIfEmpty maps "" to NULL for nullable columns
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// scanProject reads a single project row selected with projectColumns
+func scanProject(row rowScanner) (*Project, error) {
+	project := &Project{}
+	if err := row.Scan(projectDest(project)...); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// scanProjects reads all project rows selected with projectColumns
+func scanProjects(rows *sql.Rows) ([]*Project, error) {
+	var projects []*Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read projects: %w", err)
+	}
+
+	return projects, nil
+}
+
 // CreateProject inserts a new project into the database
 func CreateProject(id, name, path, language, pm string) (*Project, error) {
 	if DB == nil {
@@ -50,18 +163,9 @@ func GetProjectByID(id string) (*Project, error) {
 		return nil, fmt.Errorf("database not connected")
 	}
 
-	project := &Project{}
-	query := `SELECT id, name, path, language, package_manager, created_at FROM projects WHERE id = ?`
-
-	err := DB.QueryRow(query, id).Scan(
-		&project.ID,
-		&project.Name,
-		&project.Path,
-		&project.Language,
-		&project.PackageManager,
-		&project.CreatedAt,
-	)
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = ?`
 
+	project, err := scanProject(DB.QueryRow(query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("project not found")
 	}
@@ -78,18 +182,9 @@ func GetProjectByPath(path string) (*Project, error) {
 		return nil, fmt.Errorf("database not connected")
 	}
 
-	project := &Project{}
-	query := `SELECT id, name, path, language, package_manager, created_at FROM projects WHERE path = ?`
-
-	err := DB.QueryRow(query, path).Scan(
-		&project.ID,
-		&project.Name,
-		&project.Path,
-		&project.Language,
-		&project.PackageManager,
-		&project.CreatedAt,
-	)
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE path = ?`
 
+	project, err := scanProject(DB.QueryRow(query, path))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("project not found")
 	}
@@ -106,7 +201,7 @@ func GetProjectsByName(name string) ([]*Project, error) {
 		return nil, fmt.Errorf("database not connected")
 	}
 
-	query := `SELECT id, name, path, language, package_manager, created_at FROM projects WHERE name = ? ORDER BY created_at DESC`
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE name = ? ORDER BY created_at DESC`
 
 	rows, err := DB.Query(query, name)
 	if err != nil {
@@ -114,24 +209,7 @@ func GetProjectsByName(name string) ([]*Project, error) {
 	}
 	defer func() { _ = rows.Close() }()
 
-	var projects []*Project
-	for rows.Next() {
-		project := &Project{}
-		err := rows.Scan(
-			&project.ID,
-			&project.Name,
-			&project.Path,
-			&project.Language,
-			&project.PackageManager,
-			&project.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan project: %w", err)
-		}
-		projects = append(projects, project)
-	}
-
-	return projects, nil
+	return scanProjects(rows)
 }
 
 // GetProjectsByLanguage retrieves all projects with a given language
@@ -140,7 +218,7 @@ func GetProjectsByLanguage(language string) ([]*Project, error) {
 		return nil, fmt.Errorf("database not connected")
 	}
 
-	query := `SELECT id, name, path, language, package_manager, created_at FROM projects WHERE language = ? ORDER BY created_at DESC`
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE language = ? ORDER BY created_at DESC`
 
 	rows, err := DB.Query(query, language)
 	if err != nil {
@@ -148,24 +226,7 @@ func GetProjectsByLanguage(language string) ([]*Project, error) {
 	}
 	defer func() { _ = rows.Close() }()
 
-	var projects []*Project
-	for rows.Next() {
-		project := &Project{}
-		err := rows.Scan(
-			&project.ID,
-			&project.Name,
-			&project.Path,
-			&project.Language,
-			&project.PackageManager,
-			&project.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan project: %w", err)
-		}
-		projects = append(projects, project)
-	}
-
-	return projects, nil
+	return scanProjects(rows)
 }
 
 // ListAllProjects retrieves all projects from the database
@@ -174,7 +235,7 @@ func ListAllProjects() ([]*Project, error) {
 		return nil, fmt.Errorf("database not connected")
 	}
 
-	query := `SELECT id, name, path, language, package_manager, created_at FROM projects ORDER BY created_at DESC`
+	query := `SELECT ` + projectColumns + ` FROM projects ORDER BY created_at DESC`
 
 	rows, err := DB.Query(query)
 	if err != nil {
@@ -182,24 +243,41 @@ func ListAllProjects() ([]*Project, error) {
 	}
 	defer func() { _ = rows.Close() }()
 
-	var projects []*Project
-	for rows.Next() {
-		project := &Project{}
-		err := rows.Scan(
-			&project.ID,
-			&project.Name,
-			&project.Path,
-			&project.Language,
-			&project.PackageManager,
-			&project.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan project: %w", err)
-		}
-		projects = append(projects, project)
+	return scanProjects(rows)
+}
+
+// ListProjects retrieves all projects matching the filter
+func ListProjects(filter ProjectFilter) ([]*Project, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
 	}
 
-	return projects, nil
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE 1 = 1`
+	var args []any
+
+	if filter.Language != "" {
+		query += ` AND language = ?`
+		args = append(args, filter.Language)
+	}
+	if filter.Tag != "" {
+		query += ` AND id IN (SELECT project_id FROM project_tags WHERE tag = ?)`
+		args = append(args, filter.Tag)
+	}
+	if filter.ExcludeMembers {
+		query += ` AND parent_id IS NULL`
+	}
+	if !filter.IncludeArchived {
+		query += ` AND archive_path = ''`
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanProjects(rows)
 }
 
 // DeleteProject removes a project from the database
@@ -250,6 +328,65 @@ func UpdateProjectPM(id, pm string) error {
 	return nil
 }
 
+// SetProjectParent links a project to the workspace root it belongs to; an empty
+// parentID makes it a top-level project again
+func SetProjectParent(id, parentID string) error {
+	return setProjectParent(id, parentID, false)
+}
+
+// AdoptProject links a project that was tracked on its own to the workspace root it
+// turned out to belong to
+func AdoptProject(id, parentID string) error {
+	if parentID == "" {
+		return fmt.Errorf("a project can only be adopted by a workspace root")
+	}
+	return setProjectParent(id, parentID, true)
+}
+
+// setProjectParent updates the workspace root and adopted flag of a project
+func setProjectParent(id, parentID string, adopted bool) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+	if id == parentID {
+		return fmt.Errorf("a project cannot be its own workspace member")
+	}
+
+	query := `UPDATE projects SET parent_id = ?, adopted = ? WHERE id = ?`
+	result, err := DB.Exec(query, nullIfEmpty(parentID), adopted, id)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
+
+// GetWorkspaceMembers retrieves the members of a workspace root, ordered by path
+func GetWorkspaceMembers(parentID string) ([]*Project, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE parent_id = ? ORDER BY path`
+
+	rows, err := DB.Query(query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanProjects(rows)
+}
+
 // RenameProject updates the name of a project
 func RenameProject(id, newName string) error {
 	if DB == nil {
@@ -297,3 +434,116 @@ func RenameProjectWithPath(id, newName, newPath string) error {
 
 	return nil
 }
+
+// UpdateProjectPath points a project at a new directory. Workspace members inside the
+// old directory are moved along with it.
+func UpdateProjectPath(id, newPath string) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	project, err := GetProjectByID(id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`UPDATE projects SET path = ? WHERE id = ?`, newPath, id); err != nil {
+		return fmt.Errorf("failed to update project path: %w", err)
+	}
+
+	// Members keep their path relative to the workspace root (substr counts characters)
+	oldPrefix := project.Path + string(filepath.Separator)
+	prefixLen := utf8.RuneCountInString(oldPrefix)
+	_, err = tx.Exec(`
+		UPDATE projects SET path = ? || substr(path, ?)
+		WHERE parent_id = ? AND substr(path, 1, ?) = ?
+	`, newPath+string(filepath.Separator), prefixLen+1, id, prefixLen, oldPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to update workspace member paths: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProjectLanguage changes the language and package manager of a project
+func UpdateProjectLanguage(id, language, pm string) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	query := `UPDATE projects SET language = ?, package_manager = ? WHERE id = ?`
+	result, err := DB.Exec(query, language, pm, id)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
+
+// SetProjectRemote records the origin remote URL of a project
+func SetProjectRemote(id, remote string) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	query := `UPDATE projects SET git_remote = ? WHERE id = ?`
+	result, err := DB.Exec(query, remote, id)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
+
+// SetProjectArchive records the archive holding a project and its workspace members;
+// an empty path marks them as unarchived
+func SetProjectArchive(id, archivePath string) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	query := `UPDATE projects SET archive_path = ? WHERE id = ? OR parent_id = ?`
+	result, err := DB.Exec(query, archivePath, id, id)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
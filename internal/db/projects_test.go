@@ -398,3 +398,202 @@ func TestRenameProjectNotFound(t *testing.T) {
 		t.Error("Expected error when renaming non-existent project, got nil")
 	}
 }
+
+func TestWorkspaceMembers(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("MONO001", "mono", "/tmp/mono", "javascript", "pnpm")
+	_, _ = CreateProject("MONO002", "web", "/tmp/mono/apps/web", "javascript", "pnpm")
+	_, _ = CreateProject("MONO003", "api", "/tmp/mono/apps/api", "go", "go")
+	_, _ = CreateProject("SOLO001", "solo", "/tmp/solo", "go", "go")
+
+	for _, id := range []string{"MONO002", "MONO003"} {
+		if err := SetProjectParent(id, "MONO001"); err != nil {
+			t.Fatalf("Failed to set parent of %s: %v", id, err)
+		}
+	}
+
+	members, err := GetWorkspaceMembers("MONO001")
+	if err != nil {
+		t.Fatalf("Failed to get workspace members: %v", err)
+	}
+	if len(members) != 2 || members[0].Name != "api" || members[1].Name != "web" {
+		t.Fatalf("Expected members api and web ordered by path, got %+v", members)
+	}
+	if !members[0].IsMember() || members[0].ParentID != "MONO001" {
+		t.Errorf("Expected member to link to its root, got %+v", members[0])
+	}
+
+	root, _ := GetProjectByID("MONO001")
+	if root.IsMember() {
+		t.Error("Expected the workspace root to be a top-level project")
+	}
+
+	projects, err := ListProjects(ProjectFilter{ExcludeMembers: true})
+	if err != nil {
+		t.Fatalf("Failed to list projects: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Errorf("Expected 2 top-level projects, got %d", len(projects))
+	}
+
+	// Unlinking makes a member top-level again
+	if err := SetProjectParent("MONO003", ""); err != nil {
+		t.Fatalf("Failed to unlink member: %v", err)
+	}
+	members, _ = GetWorkspaceMembers("MONO001")
+	if len(members) != 1 {
+		t.Errorf("Expected 1 member after unlinking, got %d", len(members))
+	}
+
+	// Deleting the root removes its members
+	if err := DeleteProject("MONO001"); err != nil {
+		t.Fatalf("Failed to delete root: %v", err)
+	}
+	if _, err := GetProjectByID("MONO002"); err == nil {
+		t.Error("Expected members to be deleted with their root")
+	}
+	if _, err := GetProjectByID("MONO003"); err != nil {
+		t.Errorf("Expected unlinked project to survive: %v", err)
+	}
+}
+
+func TestAdoptProject(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("ADO001", "mono", "/tmp/mono", "javascript", "pnpm")
+	_, _ = CreateProject("ADO002", "web", "/tmp/mono/apps/web", "javascript", "pnpm")
+
+	if err := AdoptProject("ADO002", "ADO001"); err != nil {
+		t.Fatalf("Failed to adopt project: %v", err)
+	}
+	member, _ := GetProjectByID("ADO002")
+	if member.ParentID != "ADO001" || !member.Adopted {
+		t.Errorf("Expected an adopted member of ADO001, got %+v", member)
+	}
+
+	// Detaching clears the flag
+	if err := SetProjectParent("ADO002", ""); err != nil {
+		t.Fatalf("Failed to detach project: %v", err)
+	}
+	member, _ = GetProjectByID("ADO002")
+	if member.IsMember() || member.Adopted {
+		t.Errorf("Expected a top-level project, got %+v", member)
+	}
+
+	if err := AdoptProject("ADO002", ""); err == nil {
+		t.Error("Expected an error when adopting without a workspace root")
+	}
+}
+
+func TestSetProjectParentInvalid(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("SELF001", "self", "/tmp/self", "go", "go")
+
+	if err := SetProjectParent("SELF001", "SELF001"); err == nil {
+		t.Error("Expected error when a project is its own parent")
+	}
+	if err := SetProjectParent("SELF001", "MISSING"); err == nil {
+		t.Error("Expected error for an unknown parent")
+	}
+	if err := SetProjectParent("MISSING", ""); err == nil {
+		t.Error("Expected error for an unknown project")
+	}
+}
+
+func TestUpdateProjectPath(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("MOVE001", "mono", "/tmp/old/mono", "javascript", "pnpm")
+	_, _ = CreateProject("MOVE002", "ui", "/tmp/old/mono/packages/ui", "javascript", "pnpm")
+	_, _ = CreateProject("MOVE003", "mono-2", "/tmp/old/mono-2", "javascript", "pnpm")
+	_ = SetProjectParent("MOVE002", "MOVE001")
+
+	if err := UpdateProjectPath("MOVE001", "/tmp/new/mono"); err != nil {
+		t.Fatalf("Failed to update path: %v", err)
+	}
+
+	root, _ := GetProjectByID("MOVE001")
+	if root.Path != "/tmp/new/mono" {
+		t.Errorf("Expected root path /tmp/new/mono, got %s", root.Path)
+	}
+	member, _ := GetProjectByID("MOVE002")
+	if member.Path != "/tmp/new/mono/packages/ui" {
+		t.Errorf("Expected member to move with its root, got %s", member.Path)
+	}
+	sibling, _ := GetProjectByID("MOVE003")
+	if sibling.Path != "/tmp/old/mono-2" {
+		t.Errorf("Expected unrelated project to keep its path, got %s", sibling.Path)
+	}
+
+	if err := UpdateProjectPath("MISSING", "/tmp/x"); err == nil {
+		t.Error("Expected error for an unknown project")
+	}
+}
+
+func TestUpdateProjectLanguageAndRemote(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("LANG001", "app", "/tmp/lang-app", "javascript", "npm")
+
+	if err := UpdateProjectLanguage("LANG001", "go", "go"); err != nil {
+		t.Fatalf("Failed to update language: %v", err)
+	}
+	if err := SetProjectRemote("LANG001", "git@github.com:acme/app.git"); err != nil {
+		t.Fatalf("Failed to set remote: %v", err)
+	}
+
+	project, _ := GetProjectByID("LANG001")
+	if project.Language != "go" || project.PackageManager != "go" {
+		t.Errorf("Expected go/go, got %s/%s", project.Language, project.PackageManager)
+	}
+	if project.GitRemote != "git@github.com:acme/app.git" {
+		t.Errorf("Expected remote to be recorded, got %q", project.GitRemote)
+	}
+
+	if err := UpdateProjectLanguage("MISSING", "go", "go"); err == nil {
+		t.Error("Expected error for an unknown project")
+	}
+	if err := SetProjectRemote("MISSING", ""); err == nil {
+		t.Error("Expected error for an unknown project")
+	}
+}
+
+func TestSetProjectArchive(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("ARC001", "mono", "/tmp/arc-mono", "javascript", "pnpm")
+	_, _ = CreateProject("ARC002", "ui", "/tmp/arc-mono/packages/ui", "javascript", "pnpm")
+	_, _ = CreateProject("ARC003", "api", "/tmp/arc-api", "go", "go")
+	_ = SetProjectParent("ARC002", "ARC001")
+
+	// Archiving a workspace root archives its members too
+	if err := SetProjectArchive("ARC001", "/tmp/archive/mono.tar.gz"); err != nil {
+		t.Fatalf("Failed to archive project: %v", err)
+	}
+	member, _ := GetProjectByID("ARC002")
+	if !member.IsArchived() || member.ArchivePath != "/tmp/archive/mono.tar.gz" {
+		t.Errorf("Expected member to be archived with its root, got %q", member.ArchivePath)
+	}
+
+	projects, _ := ListProjects(ProjectFilter{})
+	if len(projects) != 1 || projects[0].ID != "ARC003" {
+		t.Errorf("Expected archived projects to be hidden, got %d projects", len(projects))
+	}
+	projects, _ = ListProjects(ProjectFilter{IncludeArchived: true})
+	if len(projects) != 3 {
+		t.Errorf("Expected 3 projects including archived ones, got %d", len(projects))
+	}
+
+	if err := SetProjectArchive("ARC001", ""); err != nil {
+		t.Fatalf("Failed to unarchive project: %v", err)
+	}
+	if projects, _ := ListProjects(ProjectFilter{}); len(projects) != 3 {
+		t.Errorf("Expected 3 projects after unarchiving, got %d", len(projects))
+	}
+
+	if err := SetProjectArchive("MISSING", ""); err == nil {
+		t.Error("Expected error for an unknown project")
+	}
+}
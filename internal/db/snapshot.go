@@ -0,0 +1,158 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ProjectSnapshot is everything the database records about a project, so the project
+// can be restored after it was deleted
+type ProjectSnapshot struct {
+	Project      *Project           `json:"project"`
+	Tags         []string           `json:"tags,omitempty"`
+	Components   []*Component       `json:"components,omitempty"`
+	Dependencies []*Dependency      `json:"dependencies,omitempty"` // Of every component
+	Directives   []*Directive       `json:"directives,omitempty"`   // Of every component
+	Members      []*ProjectSnapshot `json:"members,omitempty"`      // Workspace members, deleted along with the project
+}
+
+// SnapshotProject captures a project with its tags, components, dependencies,
+// directives and workspace members
+func SnapshotProject(id string) (*ProjectSnapshot, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	project, err := GetProjectByID(id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &ProjectSnapshot{Project: project}
+
+	if snapshot.Tags, err = GetProjectTags(id); err != nil {
+		return nil, err
+	}
+	if snapshot.Components, err = GetComponents(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := DB.Query(`SELECT `+dependencyColumns+` FROM dependencies WHERE project_id = ? ORDER BY component, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		dep := &Dependency{}
+		if err := rows.Scan(dependencyDest(dep)...); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		snapshot.Dependencies = append(snapshot.Dependencies, dep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dependencies: %w", err)
+	}
+
+	components := map[string]bool{"": true}
+	for _, c := range snapshot.Components {
+		components[c.Name] = true
+	}
+	for component := range components {
+		directives, err := GetComponentDirectives(id, component)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Directives = append(snapshot.Directives, directives...)
+	}
+
+	members, err := GetWorkspaceMembers(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		memberSnapshot, err := SnapshotProject(member.ID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Members = append(snapshot.Members, memberSnapshot)
+	}
+
+	return snapshot, nil
+}
+
+// RestoreSnapshot inserts a snapshotted project and everything recorded with it,
+// keeping its ID and creation time. Nothing is inserted when any row fails.
+func RestoreSnapshot(snapshot *ProjectSnapshot) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := restoreSnapshot(tx, snapshot); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// restoreSnapshot inserts a snapshot and its members inside a transaction
+func restoreSnapshot(tx *sql.Tx, snapshot *ProjectSnapshot) error {
+	p := snapshot.Project
+	_, err := tx.Exec(`
+		INSERT INTO projects (id, name, path, language, package_manager, parent_id, git_remote, archive_path, adopted, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, p.ID, p.Name, p.Path, p.Language, p.PackageManager, nullIfEmpty(p.ParentID), p.GitRemote, p.ArchivePath, p.Adopted, p.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to restore project %s: %w", p.Name, err)
+	}
+
+	for _, tag := range snapshot.Tags {
+		if _, err := tx.Exec(`INSERT INTO project_tags (project_id, tag) VALUES (?, ?)`, p.ID, tag); err != nil {
+			return fmt.Errorf("failed to restore tag: %w", err)
+		}
+	}
+
+	for _, c := range snapshot.Components {
+		_, err := tx.Exec(`
+			INSERT INTO project_components (project_id, name, path, language, package_manager, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, p.ID, c.Name, c.Path, c.Language, c.PackageManager, c.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore component: %w", err)
+		}
+	}
+
+	for _, dep := range snapshot.Dependencies {
+		_, err := tx.Exec(`
+			INSERT INTO dependencies (project_id, component, name, version, resolved_version, dep_type, dep_group, indirect, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, p.ID, dep.Component, dep.Name, dep.Version, dep.Resolved, dep.DepType, dep.Group, dep.Indirect, dep.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore dependency: %w", err)
+		}
+	}
+
+	for _, d := range snapshot.Directives {
+		_, err := tx.Exec(`
+			INSERT INTO module_directives (project_id, component, kind, path, version, target, target_version, note, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, p.ID, d.Component, d.Kind, d.Path, d.Version, d.Target, d.TargetVersion, d.Note, d.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore directive: %w", err)
+		}
+	}
+
+	for _, member := range snapshot.Members {
+		if err := restoreSnapshot(tx, member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("SNP001", "mono", "/tmp/snp-mono", "javascript", "pnpm")
+	_, _ = CreateProject("SNP002", "ui", "/tmp/snp-mono/packages/ui", "javascript", "pnpm")
+	_ = SetProjectParent("SNP002", "SNP001")
+	_ = SetProjectRemote("SNP001", "git@github.com:acme/mono.git")
+	_ = AddTags("SNP001", "client-acme")
+	_ = SyncComponents("SNP001", []*Component{{Name: "api", Path: "api", Language: "go", PackageManager: "go"}})
+	_ = SyncDependencies("SNP001", map[string]*Dependency{
+		"react": {Name: "react", Version: "^18.2.0", Resolved: "18.2.0", DepType: "prod"},
+	})
+	_ = SyncComponentDependencies("SNP001", "api", map[string]*Dependency{
+		"yaml": {Name: "gopkg.in/yaml.v3", Version: "v3.0.1", DepType: "prod"},
+	})
+	_ = SyncComponentDirectives("SNP001", "api", []*Directive{{Kind: DirectiveToolchain, Version: "go1.22.4"}})
+	_ = SyncDependencies("SNP002", map[string]*Dependency{
+		"clsx": {Name: "clsx", Version: "^2.0.0", DepType: "prod"},
+	})
+
+	snapshot, err := SnapshotProject("SNP001")
+	if err != nil {
+		t.Fatalf("Failed to snapshot project: %v", err)
+	}
+	if len(snapshot.Dependencies) != 2 || len(snapshot.Directives) != 1 || len(snapshot.Members) != 1 {
+		t.Fatalf("Expected 2 dependencies, 1 directive and 1 member, got %+v", snapshot)
+	}
+
+	// Snapshots are stored as JSON in the trash
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("Failed to encode snapshot: %v", err)
+	}
+	var stored ProjectSnapshot
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Failed to decode snapshot: %v", err)
+	}
+
+	if err := DeleteProject("SNP001"); err != nil {
+		t.Fatalf("Failed to delete project: %v", err)
+	}
+	if _, err := GetProjectByID("SNP002"); err == nil {
+		t.Fatal("Expected the workspace member to be deleted with its root")
+	}
+
+	if err := RestoreSnapshot(&stored); err != nil {
+		t.Fatalf("Failed to restore snapshot: %v", err)
+	}
+
+	project, err := GetProjectByID("SNP001")
+	if err != nil {
+		t.Fatalf("Failed to get restored project: %v", err)
+	}
+	if project.GitRemote != "git@github.com:acme/mono.git" || !project.CreatedAt.Equal(snapshot.Project.CreatedAt) {
+		t.Errorf("Expected the remote and creation time to be restored, got %+v", project)
+	}
+	if tags, _ := GetProjectTags("SNP001"); len(tags) != 1 || tags[0] != "client-acme" {
+		t.Errorf("Expected tag client-acme, got %v", tags)
+	}
+	if deps, _ := GetComponentDependencies("SNP001", "api"); len(deps) != 1 || deps[0].Name != "gopkg.in/yaml.v3" {
+		t.Errorf("Expected the component dependency to be restored, got %+v", deps)
+	}
+	if deps, _ := GetDependencies("SNP001"); len(deps) != 1 || deps[0].Resolved != "18.2.0" {
+		t.Errorf("Expected the resolved version to be restored, got %+v", deps)
+	}
+	if directives, _ := GetComponentDirectives("SNP001", "api"); len(directives) != 1 {
+		t.Errorf("Expected the directive to be restored, got %+v", directives)
+	}
+	members, _ := GetWorkspaceMembers("SNP001")
+	if len(members) != 1 || members[0].ID != "SNP002" {
+		t.Fatalf("Expected member SNP002, got %+v", members)
+	}
+	if deps, _ := GetDependencies("SNP002"); len(deps) != 1 {
+		t.Errorf("Expected the member's dependency to be restored, got %+v", deps)
+	}
+
+	// Restoring twice fails without a partial insert
+	if err := RestoreSnapshot(&stored); err == nil {
+		t.Error("Expected restoring an existing project to fail")
+	}
+}
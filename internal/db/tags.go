@@ -0,0 +1,144 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TagCount is a tag together with the number of projects carrying it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// NormalizeTag lowercases and trims a tag, rejecting empty or whitespace-containing values
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("tag cannot be empty")
+	}
+	if strings.ContainsAny(tag, " \t\n,") {
+		return "", fmt.Errorf("invalid tag %q: tags cannot contain spaces or commas", tag)
+	}
+	return tag, nil
+}
+
+// AddTags attaches tags to a project, ignoring tags it already has
+func AddTags(projectID string, tags ...string) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+
+	if _, err := GetProjectByID(projectID); err != nil {
+		return err
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `INSERT OR IGNORE INTO project_tags (project_id, tag, created_at) VALUES (?, ?, ?)`
+	for _, tag := range tags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(query, projectID, normalized, time.Now()); err != nil {
+			return fmt.Errorf("failed to add tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveTags detaches tags from a project and returns how many were removed
+func RemoveTags(projectID string, tags ...string) (int, error) {
+	if DB == nil {
+		return 0, fmt.Errorf("database not connected")
+	}
+
+	removed := 0
+	for _, tag := range tags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			return removed, err
+		}
+
+		result, err := DB.Exec(`DELETE FROM project_tags WHERE project_id = ? AND tag = ?`, projectID, normalized)
+		if err != nil {
+			return removed, fmt.Errorf("failed to remove tag: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return removed, fmt.Errorf("failed to check rows affected: %w", err)
+		}
+		removed += int(rowsAffected)
+	}
+
+	return removed, nil
+}
+
+// GetProjectTags retrieves the tags of a project in alphabetical order
+func GetProjectTags(projectID string) ([]string, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	rows, err := DB.Query(`SELECT tag FROM project_tags WHERE project_id = ? ORDER BY tag`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// ListTags retrieves every tag in use with its project count
+func ListTags() ([]TagCount, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	rows, err := DB.Query(`SELECT tag, COUNT(*) FROM project_tags GROUP BY tag ORDER BY tag`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tags []TagCount
+	for rows.Next() {
+		var tc TagCount
+		if err := rows.Scan(&tc.Tag, &tc.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tc)
+	}
+
+	return tags, rows.Err()
+}
+
+// GetProjectsByTag retrieves all projects carrying a tag
+func GetProjectsByTag(tag string) ([]*Project, error) {
+	normalized, err := NormalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	return ListProjects(ProjectFilter{Tag: normalized})
+}
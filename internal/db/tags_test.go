@@ -0,0 +1,153 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{"infra", "infra", false},
+		{"  Client-ACME ", "client-acme", false},
+		{"", "", true},
+		{"two words", "", true},
+		{"a,b", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeTag(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("NormalizeTag(%q) expected error, got %q", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NormalizeTag(%q) unexpected error: %v", tt.input, err)
+		}
+		if got != tt.expected {
+			t.Errorf("NormalizeTag(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestAddAndGetTags(t *testing.T) {
+	setupTestDB(t)
+
+	_, err := CreateProject("TAG001", "tagged", "/tmp/tagged", "go", "go")
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	if err := AddTags("TAG001", "infra", "Client-Acme", "infra"); err != nil {
+		t.Fatalf("Failed to add tags: %v", err)
+	}
+
+	tags, err := GetProjectTags("TAG001")
+	if err != nil {
+		t.Fatalf("Failed to get tags: %v", err)
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("Expected 2 tags, got %d: %v", len(tags), tags)
+	}
+	if tags[0] != "client-acme" || tags[1] != "infra" {
+		t.Errorf("Expected [client-acme infra], got %v", tags)
+	}
+}
+
+func TestAddTagsProjectNotFound(t *testing.T) {
+	setupTestDB(t)
+
+	if err := AddTags("MISSING", "infra"); err == nil {
+		t.Error("Expected error when tagging non-existent project, got nil")
+	}
+}
+
+func TestRemoveTags(t *testing.T) {
+	setupTestDB(t)
+
+	_, err := CreateProject("TAG002", "tagged", "/tmp/tagged2", "go", "go")
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := AddTags("TAG002", "infra", "oss"); err != nil {
+		t.Fatalf("Failed to add tags: %v", err)
+	}
+
+	removed, err := RemoveTags("TAG002", "infra", "unknown")
+	if err != nil {
+		t.Fatalf("Failed to remove tags: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 tag removed, got %d", removed)
+	}
+
+	tags, err := GetProjectTags("TAG002")
+	if err != nil {
+		t.Fatalf("Failed to get tags: %v", err)
+	}
+	if len(tags) != 1 || tags[0] != "oss" {
+		t.Errorf("Expected [oss], got %v", tags)
+	}
+}
+
+func TestListTagsAndFilter(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("TAG010", "api", "/tmp/api", "go", "go")
+	_, _ = CreateProject("TAG011", "web", "/tmp/web", "javascript", "pnpm")
+	_, _ = CreateProject("TAG012", "ml", "/tmp/ml", "python", "uv")
+
+	_ = AddTags("TAG010", "client-acme", "infra")
+	_ = AddTags("TAG011", "client-acme")
+
+	counts, err := ListTags()
+	if err != nil {
+		t.Fatalf("Failed to list tags: %v", err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("Expected 2 tags, got %d", len(counts))
+	}
+	if counts[0].Tag != "client-acme" || counts[0].Count != 2 {
+		t.Errorf("Expected client-acme with 2 projects, got %s with %d", counts[0].Tag, counts[0].Count)
+	}
+
+	projects, err := GetProjectsByTag("client-acme")
+	if err != nil {
+		t.Fatalf("Failed to get projects by tag: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Errorf("Expected 2 projects tagged client-acme, got %d", len(projects))
+	}
+
+	projects, err = ListProjects(ProjectFilter{Language: "go", Tag: "client-acme"})
+	if err != nil {
+		t.Fatalf("Failed to filter projects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].ID != "TAG010" {
+		t.Errorf("Expected only TAG010 for go + client-acme, got %d projects", len(projects))
+	}
+}
+
+func TestTagsCascadeDelete(t *testing.T) {
+	setupTestDB(t)
+
+	_, _ = CreateProject("TAG020", "doomed", "/tmp/doomed", "go", "go")
+	_ = AddTags("TAG020", "infra")
+
+	if err := DeleteProject("TAG020"); err != nil {
+		t.Fatalf("Failed to delete project: %v", err)
+	}
+
+	counts, err := ListTags()
+	if err != nil {
+		t.Fatalf("Failed to list tags: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("Expected tags to be removed with project, got %v", counts)
+	}
+}
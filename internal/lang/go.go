@@ -43,3 +43,8 @@ func (g *Go) DetectPackageManager(dir string) string {
 	// Go only has one package manager
 	return "go"
 }
+
+func (g *Go) CleanDirs() []string {
+	// Go keeps its build and module caches outside the project, see 'pkt clean --global'
+	return nil
+}
@@ -55,3 +55,7 @@ func (j *JavaScript) DetectPackageManager(dir string) string {
 
 	return j.DefaultPackageManager()
 }
+
+func (j *JavaScript) CleanDirs() []string {
+	return []string{"node_modules", "dist", "build", ".next", ".nuxt", ".svelte-kit", ".turbo", ".parcel-cache"}
+}
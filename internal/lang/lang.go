@@ -27,6 +27,10 @@ type Language interface {
 
 	// DetectPackageManager detects which package manager is being used in a project
 	DetectPackageManager(dir string) string
+
+	// CleanDirs returns the cache and build folders 'pkt clean' prunes by default,
+	// relative to the project directory; glob patterns are allowed
+	CleanDirs() []string
 }
 
 // Manifest represents a project manifest file (package.json, pyproject.toml, etc.)
@@ -119,3 +123,15 @@ func Detect(dir string) (Language, error) {
 	}
 	return nil, fmt.Errorf("could not detect project language in %s", dir)
 }
+
+// DetectAll returns every language with a manifest in a directory, in the same
+// order of specificity as Detect
+func DetectAll(dir string) []Language {
+	var langs []Language
+	for _, name := range []string{"rust", "go", "python", "javascript"} {
+		if lang := registry[name]; lang.DetectProject(dir) {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
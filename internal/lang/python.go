@@ -81,3 +81,7 @@ func (p *Python) DetectPackageManager(dir string) string {
 
 	return p.DefaultPackageManager()
 }
+
+func (p *Python) CleanDirs() []string {
+	return []string{"venv", ".venv", "__pycache__", ".pytest_cache", ".mypy_cache", ".ruff_cache", ".tox"}
+}
@@ -43,3 +43,7 @@ func (r *Rust) DetectPackageManager(dir string) string {
 	// Rust only has cargo as its package manager
 	return "cargo"
 }
+
+func (r *Rust) CleanDirs() []string {
+	return []string{"target"}
+}
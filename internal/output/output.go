@@ -0,0 +1,287 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is how listing commands print their results, selected with --output
+type Format string
+
+const (
+	FormatTable Format = "table" // Aligned columns for humans (default)
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatCSV   Format = "csv"
+)
+
+// Formats lists every supported format
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML, FormatCSV}
+
+// current is the format selected for this run
+var current = FormatTable
+
+// stdoutIsTerminal is checked once, colour depends on it
+var stdoutIsTerminal = term.IsTerminal(int(os.Stdout.Fd()))
+
+// ParseFormat validates a format name; an empty name selects the table format
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return FormatTable, nil
+	}
+	for _, format := range Formats {
+		if strings.EqualFold(name, string(format)) {
+			return format, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return "", fmt.Errorf("unknown output format: %s (available: %s)", name, strings.Join(names, ", "))
+}
+
+// SetFormat selects the output format for this run
+func SetFormat(name string) error {
+	format, err := ParseFormat(name)
+	if err != nil {
+		return err
+	}
+	current = format
+	return nil
+}
+
+// Current returns the selected output format
+func Current() Format {
+	return current
+}
+
+// Structured reports whether a machine-readable format was selected. Commands then
+// print only the encoded data, without progress messages or hints.
+func Structured() bool {
+	return current != FormatTable
+}
+
+// ColorEnabled reports whether ANSI colours may be used: only for the table format on
+// a terminal, and never when NO_COLOR is set or TERM is dumb
+func ColorEnabled() bool {
+	return colorEnabled(current, stdoutIsTerminal, os.Getenv("NO_COLOR") != "", os.Getenv("TERM") == "dumb")
+}
+
+func colorEnabled(format Format, terminal, noColor, dumb bool) bool {
+	return format == FormatTable && terminal && !noColor && !dumb
+}
+
+// Color wraps s in an ANSI SGR code such as "1" (bold) or "32" (green) when colour is enabled
+func Color(code, s string) string {
+	if !ColorEnabled() {
+		return s
+	}
+	return "\033[" + code + "m" + s + "\033[0m"
+}
+
+// Warnf prints a warning. With structured output it goes to stderr so stdout stays parseable.
+func Warnf(format string, a ...any) {
+	out := io.Writer(os.Stdout)
+	if Structured() {
+		out = os.Stderr
+	}
+	_, _ = fmt.Fprintf(out, "⚠️  Warning: "+format+"\n", a...)
+}
+
+// Table is a list of rows under column headers, printed as aligned text
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// NewTable creates a table with the given column headers
+func NewTable(headers ...string) *Table {
+	return &Table{Headers: headers}
+}
+
+// Add appends a row
+func (t *Table) Add(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Write prints the table with a dashed line under the headers
+func (t *Table) Write(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	dashes := make([]string, len(t.Headers))
+	for i, header := range t.Headers {
+		dashes[i] = strings.Repeat("-", len(header))
+	}
+	_, _ = fmt.Fprintln(w, strings.Join(t.Headers, "\t"))
+	_, _ = fmt.Fprintln(w, strings.Join(dashes, "\t"))
+	for _, row := range t.Rows {
+		_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// Print writes records, a slice of flat structs, to stdout in the selected structured format
+func Print(records any) error {
+	return Encode(os.Stdout, current, records, records)
+}
+
+// PrintDocument writes doc to stdout as JSON or YAML; CSV, which needs flat rows, writes rows instead
+func PrintDocument(doc, rows any) error {
+	return Encode(os.Stdout, current, doc, rows)
+}
+
+// Encode writes doc as JSON or YAML, or rows (a slice of flat structs) as CSV. Nil
+// slices are written as empty lists so the schema stays stable.
+func Encode(w io.Writer, format Format, doc, rows any) error {
+	doc = emptyIfNil(doc)
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		return encoder.Close()
+	case FormatCSV:
+		return writeCSV(w, rows)
+	default:
+		return fmt.Errorf("format %s cannot encode data", format)
+	}
+}
+
+// emptyIfNil replaces a nil slice with an empty one of the same type
+func emptyIfNil(v any) any {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		return reflect.MakeSlice(value.Type(), 0, 0).Interface()
+	}
+	return v
+}
+
+// csvColumn is a struct field written as a CSV column
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// writeCSV writes a slice of structs as CSV, one column per field named after its json
+// tag. Embedded structs are flattened and list fields are joined with ";".
+func writeCSV(w io.Writer, rows any) error {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("CSV output needs a list, got %T", rows)
+	}
+	elem := value.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("CSV output needs a list of records, got %T", rows)
+	}
+
+	columns := csvColumns(elem, nil)
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		row := reflect.Indirect(value.Index(i))
+		record := make([]string, len(columns))
+		for j, column := range columns {
+			record[j] = csvCell(row.FieldByIndex(column.index))
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvColumns returns the exported fields of a struct type, flattening embedded structs
+func csvColumns(t reflect.Type, parent []int) []csvColumn {
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && name == "" {
+			columns = append(columns, csvColumns(field.Type, index)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, csvColumn{name: name, index: index})
+	}
+	return columns
+}
+
+// csvCell formats one field value
+func csvCell(v reflect.Value) string {
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return csvCell(v.Elem())
+	case reflect.Slice, reflect.Array:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = csvCell(v.Index(i))
+		}
+		return strings.Join(items, ";")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
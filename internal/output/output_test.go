@@ -0,0 +1,134 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type testBase struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type testRecord struct {
+	testBase `yaml:",inline"`
+	Size     int64    `json:"size_bytes" yaml:"size_bytes"`
+	Tags     []string `json:"tags" yaml:"tags"`
+	Member   bool     `json:"member" yaml:"member"`
+	Parent   *string  `json:"parent,omitempty" yaml:"parent,omitempty"`
+	internal string
+	Skipped  string `json:"-" yaml:"-"`
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    Format
+		expectError bool
+	}{
+		{"", FormatTable, false},
+		{"table", FormatTable, false},
+		{"JSON", FormatJSON, false},
+		{"yaml", FormatYAML, false},
+		{"csv", FormatCSV, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("ParseFormat(%q) expected error, got %q", tt.input, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", tt.input, got, err, tt.expected)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	parent := "root"
+	records := []testRecord{
+		{testBase: testBase{Name: "web"}, Size: 2048, Tags: []string{"infra", "client-acme"}, Parent: &parent, internal: "x", Skipped: "y"},
+		{testBase: testBase{Name: "api, v2"}},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, FormatCSV, records, records); err != nil {
+		t.Fatalf("Failed to encode CSV: %v", err)
+	}
+	want := "name,size_bytes,tags,member,parent\n" +
+		"web,2048,infra;client-acme,false,root\n" +
+		"\"api, v2\",0,,false,\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := Encode(&buf, FormatJSON, records[:1], nil); err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"name": "web"`) || !strings.Contains(buf.String(), `"size_bytes": 2048`) {
+		t.Errorf("Unexpected JSON:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := Encode(&buf, FormatYAML, records[:1], nil); err != nil {
+		t.Fatalf("Failed to encode YAML: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "- name: web\n  size_bytes: 2048\n") {
+		t.Errorf("Unexpected YAML:\n%s", buf.String())
+	}
+
+	// Empty results keep a stable shape
+	var none []testRecord
+	buf.Reset()
+	_ = Encode(&buf, FormatJSON, none, none)
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected an empty JSON list, got %q", buf.String())
+	}
+	buf.Reset()
+	_ = Encode(&buf, FormatCSV, none, none)
+	if buf.String() != "name,size_bytes,tags,member,parent\n" {
+		t.Errorf("Expected only the CSV header, got %q", buf.String())
+	}
+
+	if err := Encode(&buf, FormatCSV, map[string]int{}, map[string]int{}); err == nil {
+		t.Error("Expected error for CSV output of a non-list")
+	}
+}
+
+func TestTableWrite(t *testing.T) {
+	table := NewTable("NAME", "PACKAGE MANAGER")
+	table.Add("web", "pnpm")
+	table.Add("api-server", "go")
+
+	var buf bytes.Buffer
+	if err := table.Write(&buf); err != nil {
+		t.Fatalf("Failed to write table: %v", err)
+	}
+	want := "NAME         PACKAGE MANAGER\n" +
+		"----         ---------------\n" +
+		"web          pnpm\n" +
+		"api-server   go\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected table:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	if !colorEnabled(FormatTable, true, false, false) {
+		t.Error("Expected colour on a terminal")
+	}
+	if colorEnabled(FormatTable, false, false, false) {
+		t.Error("Expected no colour when stdout is not a terminal")
+	}
+	if colorEnabled(FormatTable, true, true, false) || colorEnabled(FormatTable, true, false, true) {
+		t.Error("Expected NO_COLOR and TERM=dumb to disable colour")
+	}
+	if colorEnabled(FormatJSON, true, false, false) {
+		t.Error("Expected no colour for structured output")
+	}
+}
@@ -1,6 +1,9 @@
 package pm
 
-import "os/exec"
+import (
+	"fmt"
+	"os/exec"
+)
 
 // Bun implements PackageManager for bun
 type Bun struct{}
@@ -47,6 +50,18 @@ func (b *Bun) Update(workDir string, packages []string) error {
 	return runCommand("bun", args, workDir)
 }
 
+func (b *Bun) Outdated(workDir string) ([]OutdatedDep, error) {
+	// bun outdated has no machine-readable output, npm reads the same node_modules
+	if _, err := exec.LookPath("npm"); err != nil {
+		return nil, fmt.Errorf("checking bun projects for outdated packages needs npm, which is not installed")
+	}
+	output, err := runOutputCommand("npm", []string{"outdated", "--json"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parseNpmOutdated(output)
+}
+
 func (b *Bun) IsAvailable() bool {
 	_, err := exec.LookPath("bun")
 	return err == nil
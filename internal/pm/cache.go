@@ -0,0 +1,82 @@
+package pm
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Cache is a package or build cache shared by every project on the machine
+type Cache struct {
+	Name  string // e.g. "go build cache"
+	Path  string
+	Clean string // Command that empties it safely
+}
+
+// cacheSources lists the shared caches of each tool and how to find their location
+var cacheSources = []struct {
+	Name  string
+	Tool  string
+	Path  func() (string, error)
+	Clean string
+}{
+	{"go build cache", "go", toolOutput("go", "env", "GOCACHE"), "go clean -cache"},
+	{"go module cache", "go", toolOutput("go", "env", "GOMODCACHE"), "go clean -modcache"},
+	{"npm cache", "npm", toolOutput("npm", "config", "get", "cache"), "npm cache clean --force"},
+	{"pnpm store", "pnpm", toolOutput("pnpm", "store", "path"), "pnpm store prune"},
+	{"bun cache", "bun", toolOutput("bun", "pm", "cache"), "bun pm cache rm"},
+	{"pip cache", "pip", toolOutput("pip", "cache", "dir"), "pip cache purge"},
+	{"uv cache", "uv", toolOutput("uv", "cache", "dir"), "uv cache clean"},
+	{"poetry cache", "poetry", toolOutput("poetry", "config", "cache-dir"), "poetry cache clear --all PyPI"},
+	{"cargo registry", "cargo", cargoRegistry, "cargo cache --autoclean (from cargo-cache)"},
+}
+
+// GlobalCaches returns the shared caches of the installed tools that exist on disk
+func GlobalCaches() []Cache {
+	var caches []Cache
+	for _, source := range cacheSources {
+		if !CheckAvailability(source.Tool) && !(source.Tool == "pip" && CheckAvailability("pip3")) {
+			continue
+		}
+		path, err := source.Path()
+		if err != nil || path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			continue
+		}
+		caches = append(caches, Cache{Name: source.Name, Path: path, Clean: source.Clean})
+	}
+	return caches
+}
+
+// toolOutput returns a function that runs a tool and returns its trimmed output,
+// falling back from pip to pip3
+func toolOutput(tool string, args ...string) func() (string, error) {
+	return func() (string, error) {
+		binary := tool
+		if tool == "pip" && !CheckAvailability("pip") {
+			binary = "pip3"
+		}
+		output, err := exec.Command(binary, args...).Output()
+		if err != nil {
+			return "", err
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+		return line, nil
+	}
+}
+
+// cargoRegistry returns the registry folder of CARGO_HOME, ~/.cargo by default
+func cargoRegistry() (string, error) {
+	cargoHome := os.Getenv("CARGO_HOME")
+	if cargoHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cargoHome = filepath.Join(home, ".cargo")
+	}
+	return filepath.Join(cargoHome, "registry"), nil
+}
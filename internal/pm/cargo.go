@@ -1,6 +1,9 @@
 package pm
 
-import "os/exec"
+import (
+	"fmt"
+	"os/exec"
+)
 
 // Cargo implements PackageManager for Rust's Cargo
 type Cargo struct{}
@@ -65,6 +68,25 @@ func (c *Cargo) Update(workDir string, packages []string) error {
 	return runCommand("cargo", args, workDir)
 }
 
+func (c *Cargo) Outdated(workDir string) ([]OutdatedDep, error) {
+	// Without cargo-outdated, fall back to the semver-compatible updates cargo itself knows about
+	if _, err := exec.LookPath("cargo-outdated"); err != nil {
+		cmd := exec.Command("cargo", "update", "--dry-run")
+		cmd.Dir = workDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("command failed: %w\nOutput: %s", err, string(output))
+		}
+		return parseCargoUpdateDryRun(output), nil
+	}
+
+	output, err := runOutputCommand("cargo", []string{"outdated", "-R", "--format", "json"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parseCargoOutdated(output)
+}
+
 func (c *Cargo) IsAvailable() bool {
 	_, err := exec.LookPath("cargo")
 	return err == nil
@@ -73,6 +73,14 @@ func (g *GoMod) Update(workDir string, packages []string) error {
 	return nil
 }
 
+func (g *GoMod) Outdated(workDir string) ([]OutdatedDep, error) {
+	output, err := runOutputCommand("go", []string{"list", "-u", "-m", "-json", "all"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parseGoOutdated(output)
+}
+
 func (g *GoMod) IsAvailable() bool {
 	_, err := exec.LookPath("go")
 	return err == nil
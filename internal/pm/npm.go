@@ -50,6 +50,14 @@ func (n *NPM) Update(workDir string, packages []string) error {
 	return runCommand("npm", args, workDir)
 }
 
+func (n *NPM) Outdated(workDir string) ([]OutdatedDep, error) {
+	output, err := runOutputCommand("npm", []string{"outdated", "--json"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parseNpmOutdated(output)
+}
+
 func (n *NPM) IsAvailable() bool {
 	_, err := exec.LookPath("npm")
 	return err == nil
@@ -0,0 +1,312 @@
+package pm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UpdateType classifies an available update by the version component that changes
+type UpdateType string
+
+const (
+	UpdateMajor   UpdateType = "major"
+	UpdateMinor   UpdateType = "minor"
+	UpdatePatch   UpdateType = "patch"
+	UpdateUnknown UpdateType = "unknown"
+)
+
+// Severity ranks update types, higher is more severe
+func (u UpdateType) Severity() int {
+	switch u {
+	case UpdateMajor:
+		return 3
+	case UpdateMinor:
+		return 2
+	case UpdatePatch:
+		return 1
+	default:
+		return 0
+	}
+}
+
+var versionNumbers = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// parseVersionParts extracts major, minor and patch numbers from a version such as "v1.2.3" or "^4.17"
+func parseVersionParts(version string) ([3]int, bool) {
+	var parts [3]int
+
+	version = strings.TrimLeft(strings.TrimSpace(version), "^~=<>! ")
+	matches := versionNumbers.FindStringSubmatch(version)
+	if matches == nil {
+		return parts, false
+	}
+
+	for i := 0; i < 3; i++ {
+		if matches[i+1] != "" {
+			parts[i], _ = strconv.Atoi(matches[i+1])
+		}
+	}
+	return parts, true
+}
+
+// ClassifyUpdate reports whether moving from current to latest is a major, minor or patch update
+func ClassifyUpdate(current, latest string) UpdateType {
+	from, okFrom := parseVersionParts(current)
+	to, okTo := parseVersionParts(latest)
+	if !okFrom || !okTo {
+		return UpdateUnknown
+	}
+
+	switch {
+	case from[0] != to[0]:
+		return UpdateMajor
+	case from[1] != to[1]:
+		return UpdateMinor
+	default:
+		return UpdatePatch
+	}
+}
+
+// SortOutdated orders outdated dependencies by severity (major first), then by name
+func SortOutdated(deps []OutdatedDep) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		si, sj := deps[i].UpdateType.Severity(), deps[j].UpdateType.Severity()
+		if si != sj {
+			return si > sj
+		}
+		return deps[i].Name < deps[j].Name
+	})
+}
+
+// newOutdatedDep builds an OutdatedDep and classifies its update
+func newOutdatedDep(name, current, wanted, latest, depType string) OutdatedDep {
+	return OutdatedDep{
+		Name:       name,
+		Current:    current,
+		Wanted:     wanted,
+		Latest:     latest,
+		DepType:    depType,
+		UpdateType: ClassifyUpdate(current, latest),
+	}
+}
+
+// runOutputCommand runs a command and returns its stdout.
+// Outdated checks such as npm outdated exit non-zero when updates exist,
+// so a failing command is only an error when it produced no output.
+func runOutputCommand(name string, args []string, workDir string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = workDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil && len(bytes.TrimSpace(output)) == 0 {
+		return nil, fmt.Errorf("command failed: %w\nOutput: %s", err, stderr.String())
+	}
+	return output, nil
+}
+
+// jsDepType maps a package.json section name to prod or dev
+func jsDepType(section string) string {
+	if section == "devDependencies" {
+		return "dev"
+	}
+	return "prod"
+}
+
+// parseNpmOutdated parses `npm outdated --json`
+func parseNpmOutdated(data []byte) ([]OutdatedDep, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	var entries map[string]struct {
+		Current string `json:"current"`
+		Wanted  string `json:"wanted"`
+		Latest  string `json:"latest"`
+		Type    string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse npm outdated output: %w", err)
+	}
+
+	deps := make([]OutdatedDep, 0, len(entries))
+	for name, e := range entries {
+		deps = append(deps, newOutdatedDep(name, e.Current, e.Wanted, e.Latest, jsDepType(e.Type)))
+	}
+	return deps, nil
+}
+
+// parsePnpmOutdated parses `pnpm outdated --format json`
+func parsePnpmOutdated(data []byte) ([]OutdatedDep, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	var entries map[string]struct {
+		Current        string `json:"current"`
+		Wanted         string `json:"wanted"`
+		Latest         string `json:"latest"`
+		DependencyType string `json:"dependencyType"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse pnpm outdated output: %w", err)
+	}
+
+	deps := make([]OutdatedDep, 0, len(entries))
+	for name, e := range entries {
+		deps = append(deps, newOutdatedDep(name, e.Current, e.Wanted, e.Latest, jsDepType(e.DependencyType)))
+	}
+	return deps, nil
+}
+
+// parsePipOutdated parses `pip list --outdated --format=json` (also emitted by `uv pip list`)
+func parsePipOutdated(data []byte) ([]OutdatedDep, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	var entries []struct {
+		Name          string `json:"name"`
+		Version       string `json:"version"`
+		LatestVersion string `json:"latest_version"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse pip outdated output: %w", err)
+	}
+
+	deps := make([]OutdatedDep, 0, len(entries))
+	for _, e := range entries {
+		deps = append(deps, newOutdatedDep(e.Name, e.Version, "", e.LatestVersion, "prod"))
+	}
+	return deps, nil
+}
+
+// parsePoetryOutdated parses the columns of `poetry show --outdated`:
+// name, current, latest, description. Packages that are not installed are prefixed with "(!)".
+func parsePoetryOutdated(data []byte) ([]OutdatedDep, error) {
+	var deps []OutdatedDep
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var fields []string
+		for _, field := range strings.Fields(scanner.Text()) {
+			if field != "(!)" {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) < 3 {
+			continue
+		}
+		if _, ok := parseVersionParts(fields[1]); !ok {
+			continue
+		}
+		deps = append(deps, newOutdatedDep(fields[0], fields[1], "", fields[2], "prod"))
+	}
+
+	return deps, scanner.Err()
+}
+
+// parseGoOutdated parses the JSON stream of `go list -u -m -json all`
+func parseGoOutdated(data []byte) ([]OutdatedDep, error) {
+	var deps []OutdatedDep
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var module struct {
+			Path    string `json:"Path"`
+			Version string `json:"Version"`
+			Main    bool   `json:"Main"`
+			Update  *struct {
+				Version string `json:"Version"`
+			} `json:"Update"`
+		}
+		if err := decoder.Decode(&module); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse go list output: %w", err)
+		}
+
+		if module.Main || module.Update == nil {
+			continue
+		}
+		deps = append(deps, newOutdatedDep(module.Path, module.Version, "", module.Update.Version, "prod"))
+	}
+
+	return deps, nil
+}
+
+// parseCargoOutdated parses `cargo outdated --format json`
+func parseCargoOutdated(data []byte) ([]OutdatedDep, error) {
+	var report struct {
+		Dependencies []struct {
+			Name    string `json:"name"`
+			Project string `json:"project"`
+			Compat  string `json:"compat"`
+			Latest  string `json:"latest"`
+			Kind    string `json:"kind"`
+		} `json:"dependencies"`
+	}
+
+	// cargo outdated prints one JSON document per workspace member
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var deps []OutdatedDep
+	for {
+		if err := decoder.Decode(&report); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse cargo outdated output: %w", err)
+		}
+
+		for _, d := range report.Dependencies {
+			latest := d.Latest
+			if _, ok := parseVersionParts(latest); !ok {
+				latest = d.Compat
+			}
+			if _, ok := parseVersionParts(latest); !ok || latest == d.Project {
+				continue
+			}
+
+			wanted := d.Compat
+			if _, ok := parseVersionParts(wanted); !ok {
+				wanted = ""
+			}
+
+			depType := "prod"
+			if d.Kind == "Development" {
+				depType = "dev"
+			}
+			deps = append(deps, newOutdatedDep(d.Name, d.Project, wanted, latest, depType))
+		}
+		report.Dependencies = nil
+	}
+
+	return deps, nil
+}
+
+// cargoUpdateLine matches "Updating serde v1.0.100 -> v1.0.197" with an optional "(latest: v2.0.0)" note
+var cargoUpdateLine = regexp.MustCompile(`Updating\s+(\S+)\s+v(\S+)\s+->\s+v(\S+)(?:\s+\((?:latest|available):\s+v(\S+)\))?`)
+
+// parseCargoUpdateDryRun parses `cargo update --dry-run`, used when cargo-outdated is not installed.
+// It only reports semver-compatible updates unless cargo notes a newer incompatible release.
+func parseCargoUpdateDryRun(data []byte) []OutdatedDep {
+	var deps []OutdatedDep
+	for _, m := range cargoUpdateLine.FindAllStringSubmatch(string(data), -1) {
+		latest := m[3]
+		if m[4] != "" {
+			latest = m[4]
+		}
+		deps = append(deps, newOutdatedDep(m[1], m[2], m[3], latest, "prod"))
+	}
+	return deps
+}
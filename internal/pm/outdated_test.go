@@ -0,0 +1,230 @@
+package pm
+
+import (
+	"testing"
+)
+
+// findOutdated returns the entry for a package, failing the test if it is missing
+func findOutdated(t *testing.T, deps []OutdatedDep, name string) OutdatedDep {
+	t.Helper()
+	for _, dep := range deps {
+		if dep.Name == name {
+			return dep
+		}
+	}
+	t.Fatalf("Expected outdated entry for %q", name)
+	return OutdatedDep{}
+}
+
+func TestClassifyUpdate(t *testing.T) {
+	tests := []struct {
+		current  string
+		latest   string
+		expected UpdateType
+	}{
+		{"4.17.15", "4.17.21", UpdatePatch},
+		{"1.2.0", "1.3.0", UpdateMinor},
+		{"1.9.9", "2.0.0", UpdateMajor},
+		{"v0.3.1", "v0.4.0", UpdateMinor},
+		{"^18.2.0", "19.0.0", UpdateMajor},
+		{"2.28", "2.31.0", UpdateMinor},
+		{"v1.0.0", "v1.0.1-0.20240101000000-abcdef123456", UpdatePatch},
+		{"", "1.0.0", UpdateUnknown},
+		{"git+https://x", "1.0.0", UpdateUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyUpdate(tt.current, tt.latest); got != tt.expected {
+			t.Errorf("ClassifyUpdate(%q, %q) = %s, expected %s", tt.current, tt.latest, got, tt.expected)
+		}
+	}
+}
+
+func TestSortOutdated(t *testing.T) {
+	deps := []OutdatedDep{
+		{Name: "b", UpdateType: UpdatePatch},
+		{Name: "c", UpdateType: UpdateMajor},
+		{Name: "a", UpdateType: UpdatePatch},
+		{Name: "d", UpdateType: UpdateUnknown},
+		{Name: "e", UpdateType: UpdateMinor},
+	}
+
+	SortOutdated(deps)
+
+	expected := []string{"c", "e", "a", "b", "d"}
+	for i, name := range expected {
+		if deps[i].Name != name {
+			t.Errorf("Position %d: expected %s, got %s", i, name, deps[i].Name)
+		}
+	}
+}
+
+func TestParseNpmOutdated(t *testing.T) {
+	output := `{
+		"lodash": {"current": "4.17.15", "wanted": "4.17.21", "latest": "4.17.21", "type": "dependencies"},
+		"typescript": {"current": "4.9.5", "wanted": "4.9.5", "latest": "5.4.5", "type": "devDependencies"}
+	}`
+
+	deps, err := parseNpmOutdated([]byte(output))
+	if err != nil {
+		t.Fatalf("Failed to parse npm output: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(deps))
+	}
+
+	lodash := findOutdated(t, deps, "lodash")
+	if lodash.Current != "4.17.15" || lodash.Wanted != "4.17.21" || lodash.DepType != "prod" || lodash.UpdateType != UpdatePatch {
+		t.Errorf("Unexpected lodash entry: %+v", lodash)
+	}
+
+	ts := findOutdated(t, deps, "typescript")
+	if ts.DepType != "dev" || ts.UpdateType != UpdateMajor {
+		t.Errorf("Unexpected typescript entry: %+v", ts)
+	}
+
+	deps, err = parseNpmOutdated([]byte(""))
+	if err != nil || len(deps) != 0 {
+		t.Errorf("Expected empty output to mean nothing outdated, got %v, %v", deps, err)
+	}
+}
+
+func TestParsePnpmOutdated(t *testing.T) {
+	output := `{
+		"react": {"current": "18.2.0", "latest": "19.0.0", "wanted": "18.3.1", "isDeprecated": false, "dependencyType": "dependencies"},
+		"vitest": {"current": "1.5.0", "latest": "1.6.0", "wanted": "1.6.0", "isDeprecated": false, "dependencyType": "devDependencies"}
+	}`
+
+	deps, err := parsePnpmOutdated([]byte(output))
+	if err != nil {
+		t.Fatalf("Failed to parse pnpm output: %v", err)
+	}
+
+	react := findOutdated(t, deps, "react")
+	if react.Wanted != "18.3.1" || react.UpdateType != UpdateMajor || react.DepType != "prod" {
+		t.Errorf("Unexpected react entry: %+v", react)
+	}
+	vitest := findOutdated(t, deps, "vitest")
+	if vitest.DepType != "dev" || vitest.UpdateType != UpdateMinor {
+		t.Errorf("Unexpected vitest entry: %+v", vitest)
+	}
+}
+
+func TestParsePipOutdated(t *testing.T) {
+	output := `[{"name": "requests", "version": "2.28.0", "latest_version": "2.31.0", "latest_filetype": "wheel"}]`
+
+	deps, err := parsePipOutdated([]byte(output))
+	if err != nil {
+		t.Fatalf("Failed to parse pip output: %v", err)
+	}
+	if len(deps) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(deps))
+	}
+	if deps[0].Name != "requests" || deps[0].Latest != "2.31.0" || deps[0].UpdateType != UpdateMinor {
+		t.Errorf("Unexpected entry: %+v", deps[0])
+	}
+
+	if _, err := parsePipOutdated([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid pip output, got nil")
+	}
+}
+
+func TestParsePoetryOutdated(t *testing.T) {
+	output := `requests     2.28.0 2.31.0 Python HTTP for Humans.
+(!) flask    2.3.3  3.0.3  A simple framework for building complex web applications.
+Warning: some unrelated message
+`
+
+	deps, err := parsePoetryOutdated([]byte(output))
+	if err != nil {
+		t.Fatalf("Failed to parse poetry output: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 entries, got %d: %+v", len(deps), deps)
+	}
+
+	flask := findOutdated(t, deps, "flask")
+	if flask.Current != "2.3.3" || flask.Latest != "3.0.3" || flask.UpdateType != UpdateMajor {
+		t.Errorf("Unexpected flask entry: %+v", flask)
+	}
+}
+
+func TestParseGoOutdated(t *testing.T) {
+	output := `{
+	"Path": "example.com/app",
+	"Main": true
+}
+{
+	"Path": "github.com/spf13/cobra",
+	"Version": "v1.7.0",
+	"Update": {
+		"Path": "github.com/spf13/cobra",
+		"Version": "v1.8.0"
+	}
+}
+{
+	"Path": "github.com/spf13/pflag",
+	"Version": "v1.0.5",
+	"Indirect": true
+}
+`
+
+	deps, err := parseGoOutdated([]byte(output))
+	if err != nil {
+		t.Fatalf("Failed to parse go output: %v", err)
+	}
+	if len(deps) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(deps))
+	}
+	if deps[0].Name != "github.com/spf13/cobra" || deps[0].Latest != "v1.8.0" || deps[0].UpdateType != UpdateMinor {
+		t.Errorf("Unexpected entry: %+v", deps[0])
+	}
+}
+
+func TestParseCargoOutdated(t *testing.T) {
+	output := `{"crate_name":"app","dependencies":[` +
+		`{"name":"serde","project":"1.0.100","compat":"1.0.197","latest":"1.0.197","kind":"Normal","platform":null},` +
+		`{"name":"rand","project":"0.7.3","compat":"---","latest":"0.8.5","kind":"Normal","platform":null},` +
+		`{"name":"insta","project":"1.30.0","compat":"1.39.0","latest":"Removed","kind":"Development","platform":null},` +
+		`{"name":"log","project":"0.4.21","compat":"---","latest":"---","kind":"Normal","platform":null}]}`
+
+	deps, err := parseCargoOutdated([]byte(output))
+	if err != nil {
+		t.Fatalf("Failed to parse cargo output: %v", err)
+	}
+	if len(deps) != 3 {
+		t.Fatalf("Expected 3 entries, got %d: %+v", len(deps), deps)
+	}
+
+	rand := findOutdated(t, deps, "rand")
+	if rand.Wanted != "" || rand.UpdateType != UpdateMinor {
+		t.Errorf("Unexpected rand entry: %+v", rand)
+	}
+	insta := findOutdated(t, deps, "insta")
+	if insta.Latest != "1.39.0" || insta.DepType != "dev" {
+		t.Errorf("Unexpected insta entry: %+v", insta)
+	}
+}
+
+func TestParseCargoUpdateDryRun(t *testing.T) {
+	output := `    Updating crates.io index
+     Locking 2 packages to latest compatible versions
+    Updating serde v1.0.100 -> v1.0.197
+    Updating rand v0.8.4 -> v0.8.5 (latest: v0.9.0)
+warning: not updating lockfile due to dry run
+`
+
+	deps := parseCargoUpdateDryRun([]byte(output))
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(deps))
+	}
+
+	serde := findOutdated(t, deps, "serde")
+	if serde.Current != "1.0.100" || serde.Latest != "1.0.197" || serde.UpdateType != UpdatePatch {
+		t.Errorf("Unexpected serde entry: %+v", serde)
+	}
+	rand := findOutdated(t, deps, "rand")
+	if rand.Wanted != "0.8.5" || rand.Latest != "0.9.0" || rand.UpdateType != UpdateMinor {
+		t.Errorf("Unexpected rand entry: %+v", rand)
+	}
+}
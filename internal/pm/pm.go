@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
 )
 
 // PackageManager defines the interface for package manager operations
@@ -32,16 +35,21 @@ type PackageManager interface {
 	// Update updates packages (empty slice = update all)
 	Update(workDir string, packages []string) error
 
+	// Outdated lists dependencies that have a newer version available
+	Outdated(workDir string) ([]OutdatedDep, error)
+
 	// IsAvailable checks if this package manager is installed
 	IsAvailable() bool
 }
 
 // OutdatedDep represents an outdated dependency
 type OutdatedDep struct {
-	Name    string
-	Current string
-	Latest  string
-	DepType string
+	Name       string     `json:"name" yaml:"name"`
+	Current    string     `json:"current" yaml:"current"`
+	Wanted     string     `json:"wanted" yaml:"wanted"` // Newest version allowed by the declared range, when known
+	Latest     string     `json:"latest" yaml:"latest"`
+	DepType    string     `json:"type" yaml:"type"`
+	UpdateType UpdateType `json:"update" yaml:"update"`
 }
 
 // Registry holds all available package managers by language
@@ -116,6 +124,59 @@ func ListAvailable() []string {
 	return available
 }
 
+// Registered returns every package manager in the registry, sorted by language and name
+func Registered() []PackageManager {
+	var all []PackageManager
+	for _, langPMs := range registry {
+		for _, pm := range langPMs {
+			all = append(all, pm)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Language() != all[j].Language() {
+			return all[i].Language() < all[j].Language()
+		}
+		return all[i].Name() < all[j].Name()
+	})
+	return all
+}
+
+// versionArgs holds the arguments that print a package manager's version, when not --version
+var versionArgs = map[string][]string{
+	"go": {"version"},
+}
+
+// versionPattern matches the first version number in a tool's output
+var versionPattern = regexp.MustCompile(`\d+\.\d+(?:\.\d+)?`)
+
+// Version returns the version reported by an installed package manager
+func Version(name string) (string, error) {
+	binary := name
+	if name == "pip" && !CheckAvailability("pip") {
+		binary = "pip3"
+	}
+	args, ok := versionArgs[name]
+	if !ok {
+		args = []string{"--version"}
+	}
+
+	output, err := exec.Command(binary, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s version: %w", name, err)
+	}
+	return parseVersion(string(output))
+}
+
+// parseVersion extracts the version number from the first line of a --version output
+func parseVersion(output string) (string, error) {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	version := versionPattern.FindString(line)
+	if version == "" {
+		return "", fmt.Errorf("unrecognized version output: %s", line)
+	}
+	return version, nil
+}
+
 // CheckAvailability checks if a package manager is installed
 func CheckAvailability(name string) bool {
 	_, err := exec.LookPath(name)
@@ -381,3 +381,42 @@ func TestAddWithFlags(t *testing.T) {
 		t.Fatalf("npm add with dev flag failed: %v", err)
 	}
 }
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"10.2.4\n", "10.2.4"},
+		{"go version go1.22.3 linux/amd64\n", "1.22.3"},
+		{"pip 24.0 from /usr/lib/python3/dist-packages/pip (python 3.12)\n", "24.0"},
+		{"Poetry (version 1.8.3)\n", "1.8.3"},
+		{"cargo 1.80.0 (376290515 2024-07-16)\n", "1.80.0"},
+		{"uv 0.4.18\nextra line 9.9.9\n", "0.4.18"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseVersion(tt.output)
+		if err != nil {
+			t.Errorf("parseVersion(%q) unexpected error: %v", tt.output, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("parseVersion(%q) = %q, want %q", tt.output, got, tt.expected)
+		}
+	}
+
+	if _, err := parseVersion("command not found"); err == nil {
+		t.Error("Expected error for output without a version")
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	all := Registered()
+	if len(all) != 8 {
+		t.Fatalf("Expected 8 registered package managers, got %d", len(all))
+	}
+	if all[0].Language() != "go" || all[len(all)-1].Language() != "rust" {
+		t.Errorf("Expected package managers sorted by language, got %s first and %s last", all[0].Name(), all[len(all)-1].Name())
+	}
+}
@@ -47,6 +47,14 @@ func (p *PNPM) Update(workDir string, packages []string) error {
 	return runCommand("pnpm", args, workDir)
 }
 
+func (p *PNPM) Outdated(workDir string) ([]OutdatedDep, error) {
+	output, err := runOutputCommand("pnpm", []string{"outdated", "--format", "json"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parsePnpmOutdated(output)
+}
+
 func (p *PNPM) IsAvailable() bool {
 	_, err := exec.LookPath("pnpm")
 	return err == nil
@@ -58,6 +58,14 @@ func (u *UV) Update(workDir string, packages []string) error {
 	return runCommand("uv", args, workDir)
 }
 
+func (u *UV) Outdated(workDir string) ([]OutdatedDep, error) {
+	output, err := runOutputCommand("uv", []string{"pip", "list", "--outdated", "--format", "json"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parsePipOutdated(output)
+}
+
 func (u *UV) IsAvailable() bool {
 	_, err := exec.LookPath("uv")
 	return err == nil
@@ -319,6 +327,21 @@ func (p *Pip) Update(workDir string, packages []string) error {
 	return runCommand(pip, args, workDir)
 }
 
+func (p *Pip) Outdated(workDir string) ([]OutdatedDep, error) {
+	// Only the project venv holds the project's packages; the global pip would
+	// report whatever is installed system-wide
+	pip := p.venvPip(workDir)
+	if _, err := os.Stat(pip); err != nil {
+		return nil, fmt.Errorf("no venv/ in %s, install the dependencies first", workDir)
+	}
+
+	output, err := runOutputCommand(pip, []string{"list", "--outdated", "--format=json"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parsePipOutdated(output)
+}
+
 func (p *Pip) IsAvailable() bool {
 	_, err := exec.LookPath("pip")
 	if err != nil {
@@ -378,6 +401,14 @@ func (p *Poetry) Update(workDir string, packages []string) error {
 	return runCommand("poetry", args, workDir)
 }
 
+func (p *Poetry) Outdated(workDir string) ([]OutdatedDep, error) {
+	output, err := runOutputCommand("poetry", []string{"show", "--outdated", "--no-ansi"}, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return parsePoetryOutdated(output)
+}
+
 func (p *Poetry) IsAvailable() bool {
 	_, err := exec.LookPath("poetry")
 	return err == nil
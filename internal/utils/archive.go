@@ -0,0 +1,324 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+// Archive formats written by pkt archive
+const (
+	ArchiveZstd = "zst" // .tar.zst, compressed by the zstd command
+	ArchiveGzip = "gz"  // .tar.gz
+)
+
+// DefaultArchiveFormat returns zst when the zstd command is installed, gz otherwise
+func DefaultArchiveFormat() string {
+	if _, err := exec.LookPath("zstd"); err == nil {
+		return ArchiveZstd
+	}
+	return ArchiveGzip
+}
+
+// ArchiveExt returns the file extension of an archive format
+func ArchiveExt(format string) string {
+	return ".tar." + format
+}
+
+// CheckNotArchived fails for an archived project, whose folder does not exist until
+// it is unarchived
+func CheckNotArchived(project *db.Project) error {
+	if project.IsArchived() {
+		return fmt.Errorf("%s is archived in %s; run 'pkt unarchive %s' first", project.Name, ShortPath(project.ArchivePath), project.Name)
+	}
+	return nil
+}
+
+// WriteArchive packs the contents of dir into a tarball at dest, leaving out the
+// excluded directories. The archive only appears at dest once it is complete.
+func WriteArchive(dir, dest, format string, exclude []string) error {
+	skip := make(map[string]bool, len(exclude))
+	for _, path := range exclude {
+		skip[path] = true
+	}
+
+	tmp := dest + ".tmp"
+	if err := writeCompressed(tmp, format, func(w io.Writer) error {
+		return writeTar(dir, w, skip)
+	}); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	return nil
+}
+
+// writeCompressed creates path and passes write a writer compressing into it
+func writeCompressed(path, format string, write func(io.Writer) error) error {
+	switch format {
+	case ArchiveGzip:
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		defer func() { _ = f.Close() }()
+
+		gz := gzip.NewWriter(f)
+		if err := write(gz); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to compress archive: %w", err)
+		}
+		return f.Close()
+
+	case ArchiveZstd:
+		pr, pw := io.Pipe()
+		cmd := exec.Command("zstd", "-q", "-f", "-T0", "-o", path)
+		cmd.Stdin = pr
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to run zstd: %w", err)
+		}
+
+		writeErr := write(pw)
+		_ = pw.CloseWithError(writeErr)
+		waitErr := cmd.Wait()
+		if writeErr != nil {
+			return writeErr
+		}
+		if waitErr != nil {
+			return zstdError(stderr.String(), waitErr)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported archive format: %s (use %s or %s)", format, ArchiveZstd, ArchiveGzip)
+	}
+}
+
+// zstdError describes a failed zstd run by the first line it printed
+func zstdError(stderr string, err error) error {
+	if message, _, _ := strings.Cut(strings.TrimSpace(stderr), "\n"); message != "" {
+		return fmt.Errorf("zstd: %s", message)
+	}
+	return fmt.Errorf("zstd failed: %w", err)
+}
+
+// writeTar writes the files below dir to w, with paths relative to dir
+func writeTar(dir string, w io.Writer, skip map[string]bool) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if d.IsDir() && skip[path] {
+			return filepath.SkipDir
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		// Sockets, pipes and devices are left out
+		if !info.Mode().IsRegular() && !info.IsDir() && info.Mode()&fs.ModeSymlink == 0 {
+			return nil
+		}
+
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", dir, err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", dir, err)
+	}
+	return nil
+}
+
+// ExtractArchive unpacks a tarball written by WriteArchive into dest, which must not
+// exist yet. dest only appears once everything was extracted.
+func ExtractArchive(archive, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", ShortPath(dest))
+	}
+
+	tmp := dest + ".pkt-unarchive"
+	if err := os.RemoveAll(tmp); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", tmp, err)
+	}
+	if err := os.MkdirAll(tmp, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", tmp, err)
+	}
+
+	if err := readCompressed(archive, func(r io.Reader) error {
+		return extractTar(r, tmp)
+	}); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.RemoveAll(tmp)
+		return fmt.Errorf("failed to move extracted files to %s: %w", dest, err)
+	}
+	return nil
+}
+
+// readCompressed opens an archive and passes read a reader decompressing it, picking
+// the format from the file extension
+func readCompressed(path string, read func(io.Reader) error) error {
+	switch {
+	case strings.HasSuffix(path, ArchiveExt(ArchiveGzip)):
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		defer func() { _ = gz.Close() }()
+		return read(gz)
+
+	case strings.HasSuffix(path, ArchiveExt(ArchiveZstd)):
+		cmd := exec.Command("zstd", "-q", "-d", "-c", path)
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return fmt.Errorf("failed to run zstd: %w", err)
+		}
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to run zstd (is it installed?): %w", err)
+		}
+
+		readErr := read(stdout)
+		// Drain the rest so zstd can exit
+		_, _ = io.Copy(io.Discard, stdout)
+		waitErr := cmd.Wait()
+		if readErr != nil {
+			return readErr
+		}
+		if waitErr != nil {
+			return zstdError(stderr.String(), waitErr)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported archive: %s (expected %s or %s)", path, ArchiveExt(ArchiveZstd), ArchiveExt(ArchiveGzip))
+	}
+}
+
+// extractTar writes the entries of a tar stream below dir, refusing paths that
+// would land outside it. Symlinks are created last so no entry is written through one.
+func extractTar(r io.Reader, dir string) error {
+	type symlink struct{ target, path string }
+	var symlinks []symlink
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name := filepath.FromSlash(strings.TrimSuffix(header.Name, "/"))
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("refusing to extract %q outside the project", header.Name)
+		}
+		path := filepath.Join(dir, name)
+		mode := fs.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, mode|0700); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", name, err)
+			}
+		case tar.TypeSymlink:
+			symlinks = append(symlinks, symlink{header.Linkname, path})
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", name, err)
+			}
+			if err := extractFile(tr, path, mode); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", name, err)
+			}
+			_ = os.Chtimes(path, header.ModTime, header.ModTime)
+		}
+	}
+
+	for _, link := range symlinks {
+		if err := os.MkdirAll(filepath.Dir(link.path), 0755); err != nil {
+			return err
+		}
+		if err := os.Symlink(link.target, link.path); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", link.path, err)
+		}
+	}
+	return nil
+}
+
+// extractFile writes the current tar entry to path
+func extractFile(r io.Reader, path string, mode fs.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	formats := []string{ArchiveGzip}
+	if _, err := exec.LookPath("zstd"); err == nil {
+		formats = append(formats, ArchiveZstd)
+	}
+
+	for _, format := range formats {
+		t.Run(format, func(t *testing.T) {
+			root := writeProjectFiles(t, map[string]string{
+				"app/package.json":                `{"name":"app"}`,
+				"app/src/index.js":                "console.log(1)\n",
+				"app/node_modules/react/index.js": "",
+				"app/packages/ui/dist/index.js":   "",
+				"app/packages/ui/src/button.js":   "",
+				"app/.git/HEAD":                   "ref: refs/heads/main\n",
+			})
+			src := filepath.Join(root, "app")
+			if err := os.Symlink("src/index.js", filepath.Join(src, "main.js")); err != nil {
+				t.Fatal(err)
+			}
+
+			archive := filepath.Join(root, "app"+ArchiveExt(format))
+			exclude := []string{filepath.Join(src, "node_modules"), filepath.Join(src, "packages/ui/dist")}
+			if err := WriteArchive(src, archive, format, exclude); err != nil {
+				t.Fatalf("Failed to write archive: %v", err)
+			}
+			if _, err := os.Stat(archive + ".tmp"); !os.IsNotExist(err) {
+				t.Error("Expected the temporary archive to be renamed")
+			}
+
+			dest := filepath.Join(root, "restored")
+			if err := ExtractArchive(archive, dest); err != nil {
+				t.Fatalf("Failed to extract archive: %v", err)
+			}
+
+			for _, name := range []string{"package.json", "src/index.js", "packages/ui/src/button.js", ".git/HEAD"} {
+				if _, err := os.Stat(filepath.Join(dest, name)); err != nil {
+					t.Errorf("Expected %s to be restored: %v", name, err)
+				}
+			}
+			for _, name := range []string{"node_modules", "packages/ui/dist"} {
+				if _, err := os.Stat(filepath.Join(dest, name)); !os.IsNotExist(err) {
+					t.Errorf("Expected %s to be left out", name)
+				}
+			}
+			if target, err := os.Readlink(filepath.Join(dest, "main.js")); err != nil || target != "src/index.js" {
+				t.Errorf("Expected the symlink to be restored, got %q, %v", target, err)
+			}
+
+			if err := ExtractArchive(archive, dest); err == nil {
+				t.Error("Expected extracting over an existing folder to fail")
+			}
+		})
+	}
+}
+
+func TestExtractArchiveRejectsEscapes(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "evil"+ArchiveExt(ArchiveGzip))
+
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	content := []byte("x")
+	_ = tw.WriteHeader(&tar.Header{Name: "../escaped", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	_ = gz.Close()
+	_ = f.Close()
+
+	if err := ExtractArchive(archive, filepath.Join(dir, "out")); err == nil {
+		t.Fatal("Expected an entry outside the project to be refused")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escaped")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be written outside the project")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out")); !os.IsNotExist(err) {
+		t.Error("Expected no partial extraction to be left behind")
+	}
+}
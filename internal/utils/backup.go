@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/genesix/pkt/internal/config"
+	"github.com/genesix/pkt/internal/db"
+)
+
+const (
+	// backupConfigFile and backupDBFile are the names of the config and database
+	// inside a backup
+	backupConfigFile = "config.json"
+	backupDBFile     = "pkt2.db"
+)
+
+// BackupExt is the file extension of backups written by pkt backup
+var BackupExt = ArchiveExt(ArchiveGzip)
+
+// IsBackupFile reports whether path is an existing file pkt restore can read: a
+// backup written by pkt backup or a database backed up before a migration
+func IsBackupFile(path string) bool {
+	if !strings.HasSuffix(path, BackupExt) && !strings.HasSuffix(path, ".db") {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// WriteBackup writes the config and a consistent copy of the database to a tarball
+// at dest. The config is copied as is, so a backup can be taken even when it does
+// not parse.
+func WriteBackup(dest string) error {
+	tmp, err := os.MkdirTemp("", "pkt-backup")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	if err := db.BackupTo(filepath.Join(tmp, backupDBFile)); err != nil {
+		return err
+	}
+
+	configPath, err := config.Path()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(configPath); err == nil {
+		if err := copyFile(configPath, filepath.Join(tmp, backupConfigFile)); err != nil {
+			return fmt.Errorf("failed to back up config: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dest), err)
+	}
+	return WriteArchive(tmp, dest, ArchiveGzip, nil)
+}
+
+// AutoBackup writes a backup to the backup directory before a destructive operation,
+// keeping the newest db.BackupKeep automatic backups. It returns the backup path.
+func AutoBackup(reason string) (string, error) {
+	dir, err := db.BackupDir()
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("auto-%s-%s%s", reason, time.Now().Format("20060102-150405.000"), BackupExt)
+	dest := filepath.Join(dir, name)
+	if err := WriteBackup(dest); err != nil {
+		return "", err
+	}
+
+	// Old backups left behind must not block the operation
+	_ = db.PruneBackups("auto-*"+BackupExt, db.BackupKeep)
+	return dest, nil
+}
+
+// RestoredBackup describes what RestoreBackup put back
+type RestoredBackup struct {
+	SchemaVersion int  // Schema version of the restored database, before migrations
+	Config        bool // False for database-only backups
+}
+
+// RestoreBackup replaces the database, and the config when the backup holds one,
+// with the contents of a backup. Everything is checked before anything is replaced.
+func RestoreBackup(path string) (*RestoredBackup, error) {
+	dbFile, configFile := path, ""
+
+	if strings.HasSuffix(path, BackupExt) {
+		tmp, err := os.MkdirTemp("", "pkt-restore")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+
+		dir := filepath.Join(tmp, "backup")
+		if err := ExtractArchive(path, dir); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		dbFile = filepath.Join(dir, backupDBFile)
+		if _, err := os.Stat(dbFile); err != nil {
+			return nil, fmt.Errorf("%s is not a pkt backup: it has no %s", path, backupDBFile)
+		}
+		if _, err := os.Stat(filepath.Join(dir, backupConfigFile)); err == nil {
+			configFile = filepath.Join(dir, backupConfigFile)
+		}
+	}
+
+	version, err := db.InspectBackup(dbFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg *config.Config
+	if configFile != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config from backup: %w", err)
+		}
+		cfg = &config.Config{}
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("the config in %s is invalid: %w", path, err)
+		}
+	}
+
+	if err := db.RestoreDatabase(dbFile); err != nil {
+		return nil, err
+	}
+	if cfg != nil {
+		if err := config.Save(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return &RestoredBackup{SchemaVersion: version, Config: cfg != nil}, nil
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/genesix/pkt/internal/db"
+)
+
+// cargoDependency is a Cargo.toml dependency, written either as a version string
+// or as a table such as { version = "1", features = [...] } or { workspace = true }
+type cargoDependency struct {
+	Version   string
+	Package   string // Real crate name when the dependency is renamed
+	Workspace bool   // Inherited from [workspace.dependencies]
+}
+
+// UnmarshalTOML implements toml.Unmarshaler
+func (d *cargoDependency) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case string:
+		d.Version = v
+	case map[string]any:
+		d.Version, _ = v["version"].(string)
+		d.Package, _ = v["package"].(string)
+		d.Workspace, _ = v["workspace"].(bool)
+	default:
+		return fmt.Errorf("unexpected dependency value: %v", value)
+	}
+	return nil
+}
+
+// cargoDependencyTables are the dependency sections shared by [package] and [target.*]
+type cargoDependencyTables struct {
+	Dependencies      map[string]cargoDependency `toml:"dependencies"`
+	DevDependencies   map[string]cargoDependency `toml:"dev-dependencies"`
+	BuildDependencies map[string]cargoDependency `toml:"build-dependencies"`
+}
+
+// cargoManifest is the subset of Cargo.toml pkt reads
+type cargoManifest struct {
+	cargoDependencyTables
+	Package struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Target    map[string]cargoDependencyTables `toml:"target"`
+	Workspace *struct {
+		Members      []string                   `toml:"members"`
+		Exclude      []string                   `toml:"exclude"`
+		Dependencies map[string]cargoDependency `toml:"dependencies"`
+	} `toml:"workspace"`
+}
+
+// readCargoManifest decodes a Cargo.toml file
+func readCargoManifest(path string) (*cargoManifest, error) {
+	var manifest cargoManifest
+	if _, err := toml.DecodeFile(path, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return &manifest, nil
+}
+
+// findCargoWorkspace returns the [workspace.dependencies] of the nearest enclosing
+// workspace, starting with the manifest itself
+func findCargoWorkspace(projectPath string, manifest *cargoManifest) map[string]cargoDependency {
+	if manifest.Workspace != nil {
+		return manifest.Workspace.Dependencies
+	}
+
+	dir := filepath.Dir(projectPath)
+	for {
+		parentPath := filepath.Join(dir, "Cargo.toml")
+		if _, err := os.Stat(parentPath); err == nil {
+			parent, err := readCargoManifest(parentPath)
+			if err == nil && parent.Workspace != nil {
+				return parent.Workspace.Dependencies
+			}
+		}
+
+		next := filepath.Dir(dir)
+		if next == dir {
+			return nil
+		}
+		dir = next
+	}
+}
+
+// ParseCargoToml parses Rust Cargo.toml file, including target-specific and build
+// dependencies. Build dependencies are marked with the "build" group. Versions from [workspace.dependencies] are filled in where they are
+// inherited, but are not dependencies of the workspace root by themselves.
+func ParseCargoToml(projectPath string) (map[string]*db.Dependency, error) {
+	cargoPath := filepath.Join(projectPath, "Cargo.toml")
+	deps := make(map[string]*db.Dependency)
+
+	if _, err := os.Stat(cargoPath); err != nil {
+		if os.IsNotExist(err) {
+			return deps, nil
+		}
+		return nil, err
+	}
+
+	manifest, err := readCargoManifest(cargoPath)
+	if err != nil {
+		return nil, err
+	}
+	workspaceDeps := findCargoWorkspace(projectPath, manifest)
+
+	add := func(entries map[string]cargoDependency, depType, group string) {
+		for key, entry := range entries {
+			name := key
+			if entry.Package != "" {
+				name = entry.Package
+			}
+
+			// workspace = true inherits the version declared by the workspace root
+			version := entry.Version
+			if entry.Workspace && version == "" {
+				if inherited, ok := workspaceDeps[key]; ok {
+					version = inherited.Version
+					if inherited.Package != "" {
+						name = inherited.Package
+					}
+				}
+			}
+
+			// A crate used both in production and in tests is a production dependency,
+			// and one used both at runtime and by a build script a runtime one
+			if existing, ok := deps[name]; ok && existing.DepType == "prod" && (existing.Group == "" || group != "") {
+				continue
+			}
+
+			deps[name] = &db.Dependency{
+				Name:    name,
+				Version: version,
+				DepType: depType,
+				Group:   group,
+			}
+		}
+	}
+
+	tables := []cargoDependencyTables{manifest.cargoDependencyTables}
+	for _, target := range manifest.Target {
+		tables = append(tables, target)
+	}
+	for _, table := range tables {
+		add(table.DevDependencies, "dev", "")
+	}
+	for _, table := range tables {
+		add(table.Dependencies, "prod", "")
+	}
+	for _, table := range tables {
+		add(table.BuildDependencies, "prod", "build")
+	}
+
+	return deps, nil
+}
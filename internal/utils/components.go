@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/lang"
+)
+
+// componentSearchDepth is how many directory levels below a project root are searched for components
+const componentSearchDepth = 2
+
+// DiscoverComponents finds the languages of a polyglot project besides its primary one:
+// manifests of other languages in the project root, named after their language, and
+// project roots of another language in sub-directories up to two levels deep, named
+// after their relative path (e.g. "web" or "apps/admin"). Workspace members and nested
+// projects of the primary language are not components.
+func DiscoverComponents(root, primaryLanguage string) ([]*db.Component, error) {
+	members, err := DiscoverWorkspaceMembers(root)
+	if err != nil {
+		return nil, err
+	}
+	isMember := make(map[string]bool)
+	for _, member := range members {
+		isMember[member.Path] = true
+	}
+
+	var components []*db.Component
+	taken := make(map[string]bool)
+	add := func(name, rel string, l lang.Language, dir string) {
+		// A directory named like a root-level language keeps its language as a suffix
+		if taken[name] {
+			name += "-" + l.Name()
+		}
+		taken[name] = true
+		components = append(components, &db.Component{
+			Name:           name,
+			Path:           rel,
+			Language:       l.Name(),
+			PackageManager: l.DetectPackageManager(dir),
+		})
+	}
+
+	for _, l := range lang.DetectAll(root) {
+		if l.Name() != primaryLanguage {
+			add(l.Name(), ".", l, root)
+		}
+	}
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || p == root {
+			return nil
+		}
+		if workspaceSkipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".") || isMember[p] {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		detected, err := lang.Detect(p)
+		if err != nil {
+			if strings.Count(rel, "/")+1 >= componentSearchDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if detected.Name() != primaryLanguage {
+			add(rel, rel, detected, p)
+		}
+
+		// Nested project roots are not searched any further
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// SyncComponents discovers the components of a project and records them in the
+// database, returning them ordered by name. Dependencies are synced separately with
+// SyncComponentDependencies.
+func SyncComponents(project *db.Project) ([]*db.Component, error) {
+	components, err := DiscoverComponents(project.Path, project.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.SyncComponents(project.ID, components); err != nil {
+		return nil, err
+	}
+
+	return db.GetComponents(project.ID)
+}
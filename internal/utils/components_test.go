@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestDiscoverComponents(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary string
+		files   map[string]string
+		want    map[string]string // component name -> "path language/package manager"
+	}{
+		{
+			name:    "go backend with vite frontend",
+			primary: "go",
+			files: map[string]string{
+				"go.mod":                        "module example.com/app\n\ngo 1.22\n",
+				"web/package.json":              `{"name": "web"}`,
+				"web/pnpm-lock.yaml":            "lockfileVersion: '9.0'\n",
+				"web/src/package.json":          `{"name": "nested"}`,
+				"web/node_modules/package.json": `{}`,
+				"tools/gen/go.mod":              "module example.com/app/tools\n",
+				".cache/package.json":           `{}`,
+			},
+			want: map[string]string{
+				"web": "web javascript/pnpm",
+			},
+		},
+		{
+			name:    "second language in the root and a nested service",
+			primary: "javascript",
+			files: map[string]string{
+				"package.json":              `{"name": "site"}`,
+				"pyproject.toml":            "[project]\nname = \"site\"\n",
+				"services/api/Cargo.toml":   "[package]\nname = \"api\"\n",
+				"services/README.md":        "services",
+				"deep/a/b/requirements.txt": "requests\n",
+				"python/requirements.txt":   "flask\n",
+			},
+			want: map[string]string{
+				"python":        ". python/uv",
+				"python-python": "python python/pip",
+				"services/api":  "services/api rust/cargo",
+			},
+		},
+		{
+			name:    "workspace members are not components",
+			primary: "javascript",
+			files: map[string]string{
+				"package.json":             `{"name": "root", "workspaces": ["packages/*"]}`,
+				"packages/ui/package.json": `{"name": "ui"}`,
+				"packages/cli/Cargo.toml":  "[package]\nname = \"cli\"\n",
+			},
+			want: map[string]string{
+				"packages/cli": "packages/cli rust/cargo",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeProjectFiles(t, tt.files)
+
+			components, err := DiscoverComponents(root, tt.primary)
+			if err != nil {
+				t.Fatalf("Failed to discover components: %v", err)
+			}
+
+			got := make(map[string]string)
+			for _, c := range components {
+				got[c.Name] = c.Path + " " + c.Language + "/" + c.PackageManager
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Expected components %v, got %v", tt.want, got)
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("Expected component %s to be %q, got %q", name, want, got[name])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/genesix/pkt/internal/lang"
+)
+
+// DiscoveredProject is a project root found while scanning a directory tree
+type DiscoveredProject struct {
+	Name           string
+	Path           string
+	Language       string
+	PackageManager string
+}
+
+// DiscoverProjects walks a directory tree up to maxDepth levels below root (0 for no
+// limit) and returns every directory detected as a project by lang.Detect, sorted by
+// path. Dependency and build folders (node_modules, target, venv, ...), hidden folders
+// and unreadable folders are skipped. A project's own sub-directories are not searched:
+// its workspace members and components are tracked together with it.
+func DiscoverProjects(root string, maxDepth int) ([]DiscoveredProject, error) {
+	root = filepath.Clean(root)
+
+	var projects []DiscoveredProject
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p != root && d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		if p != root {
+			if workspaceSkipDirs[d.Name()] || d.Name() == "__pycache__" || strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+		}
+
+		if detected, err := lang.Detect(p); err == nil {
+			projects = append(projects, DiscoveredProject{
+				Name:           filepath.Base(p),
+				Path:           p,
+				Language:       detected.Name(),
+				PackageManager: detected.DetectPackageManager(p),
+			})
+			return filepath.SkipDir
+		}
+
+		if maxDepth > 0 && p != root {
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			if strings.Count(filepath.ToSlash(rel), "/")+1 >= maxDepth {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverProjects(t *testing.T) {
+	root := writeProjectFiles(t, map[string]string{
+		"api/go.mod":                              "module example.com/api\n",
+		"api/web/package.json":                    `{"name": "api-web"}`,
+		"clients/web/package.json":                `{"name": "web"}`,
+		"clients/web/pnpm-lock.yaml":              "lockfileVersion: '9.0'\n",
+		"clients/web/node_modules/x/package.json": `{}`,
+		"tools/ml/requirements.txt":               "numpy\n",
+		"tools/ml/venv/lib/pyproject.toml":        "",
+		"archive/2019/old/deep/Cargo.toml":        "[package]\nname = \"old\"\n",
+		".hidden/app/package.json":                `{}`,
+		"node_modules/pkg/package.json":           `{}`,
+		"notes/README.md":                         "not a project",
+	})
+
+	projects, err := DiscoverProjects(root, 0)
+	if err != nil {
+		t.Fatalf("Failed to discover projects: %v", err)
+	}
+
+	want := []string{
+		"api go/go",
+		"archive/2019/old/deep rust/cargo",
+		"clients/web javascript/pnpm",
+		"tools/ml python/pip",
+	}
+	if len(projects) != len(want) {
+		t.Fatalf("Expected %d projects, got %+v", len(want), projects)
+	}
+	for i, project := range projects {
+		rel, _ := filepath.Rel(root, project.Path)
+		got := filepath.ToSlash(rel) + " " + project.Language + "/" + project.PackageManager
+		if got != want[i] {
+			t.Errorf("Expected project %d to be %q, got %q", i, want[i], got)
+		}
+	}
+	if projects[2].Name != "web" {
+		t.Errorf("Expected project name from directory, got %q", projects[2].Name)
+	}
+
+	// A depth limit stops the search below that many levels
+	projects, err = DiscoverProjects(root, 2)
+	if err != nil {
+		t.Fatalf("Failed to discover projects: %v", err)
+	}
+	if len(projects) != 3 {
+		t.Errorf("Expected 3 projects within depth 2, got %+v", projects)
+	}
+
+	// The scanned directory itself may be a project
+	projects, _ = DiscoverProjects(filepath.Join(root, "api"), 0)
+	if len(projects) != 1 || projects[0].Path != filepath.Join(root, "api") {
+		t.Errorf("Expected only the root project, got %+v", projects)
+	}
+}
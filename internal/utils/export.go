@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ExportVersion is the format version written by pkt export
+const ExportVersion = 1
+
+// WorkspaceExport is a portable description of the tracked projects, written by
+// pkt export and read by pkt import
+type WorkspaceExport struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Roots      map[string]string `json:"roots"` // Workspace root name to directory, with ~ for the home directory
+	Projects   []ExportedProject `json:"projects"`
+}
+
+// ExportedProject describes a project independently of the machine it lives on
+type ExportedProject struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Language       string   `json:"language"`
+	PackageManager string   `json:"package_manager"`
+	Root           string   `json:"root,omitempty"`   // Workspace root Path is relative to; empty when outside every root
+	Path           string   `json:"path"`             // Relative to Root, or absolute (with ~) outside every root
+	Parent         string   `json:"parent,omitempty"` // ID of the workspace root for workspace members
+	GitRemote      string   `json:"git_remote,omitempty"`
+	Branch         string   `json:"branch,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Archived       bool     `json:"archived,omitempty"`
+}
+
+// RelativeToRoot finds the innermost workspace root containing path and returns its
+// name with the path relative to it. Paths outside every root are returned as is,
+// with an empty root name.
+func RelativeToRoot(path string, roots map[string]string) (string, string) {
+	bestName, bestRel, bestLen := "", path, -1
+	for name, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(root) > bestLen {
+			bestName, bestRel, bestLen = name, filepath.ToSlash(rel), len(root)
+		}
+	}
+	return bestName, bestRel
+}
+
+// ReadExport reads a workspace export written by pkt export
+func ReadExport(path string) (*WorkspaceExport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	export := &WorkspaceExport{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if export.Version == 0 || export.Version > ExportVersion {
+		return nil, fmt.Errorf("%s is not a pkt workspace export this version of pkt can read (version %d)", path, export.Version)
+	}
+
+	for i, p := range export.Projects {
+		if p.Name == "" || p.Path == "" || p.Language == "" {
+			return nil, fmt.Errorf("project %d in %s needs a name, path and language", i+1, path)
+		}
+		if p.Root != "" && !filepath.IsLocal(filepath.FromSlash(p.Path)) {
+			return nil, fmt.Errorf("project %s: path %q must stay inside workspace root %s", p.Name, p.Path, p.Root)
+		}
+	}
+	return export, nil
+}
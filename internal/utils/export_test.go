@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRelativeToRoot(t *testing.T) {
+	roots := map[string]string{
+		"default": "/home/me/ws",
+		"oss":     "/home/me/ws/oss",
+		"work":    "/srv/work",
+	}
+
+	tests := []struct {
+		path, root, rel string
+	}{
+		{"/home/me/ws/api", "default", "api"},
+		{"/home/me/ws/oss/pkt", "oss", "pkt"}, // The innermost root wins
+		{"/srv/work/client/web", "work", "client/web"},
+		{"/home/me/wsx/app", "", "/home/me/wsx/app"},
+		{"/tmp/app", "", "/tmp/app"},
+	}
+	for _, tt := range tests {
+		root, rel := RelativeToRoot(tt.path, roots)
+		if root != tt.root || rel != tt.rel {
+			t.Errorf("RelativeToRoot(%s) = %s, %s; want %s, %s", tt.path, root, rel, tt.root, tt.rel)
+		}
+	}
+}
+
+func TestReadExport(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	valid := write("valid.json", `{
+  "version": 1,
+  "roots": {"default": "~/ws"},
+  "projects": [
+    {"id": "01A", "name": "api", "language": "go", "package_manager": "go", "root": "default", "path": "api",
+     "git_remote": "git@github.com:acme/api.git", "branch": "main", "tags": ["client-acme"]}
+  ]
+}`)
+	export, err := ReadExport(valid)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	if len(export.Projects) != 1 || export.Projects[0].Branch != "main" || export.Projects[0].Tags[0] != "client-acme" {
+		t.Errorf("Unexpected projects: %+v", export.Projects)
+	}
+
+	invalid := map[string]string{
+		"no-version.json": `{"projects": []}`,
+		"future.json":     `{"version": 99, "projects": []}`,
+		"no-name.json":    `{"version": 1, "projects": [{"language": "go", "path": "api"}]}`,
+		"escape.json":     `{"version": 1, "projects": [{"name": "x", "language": "go", "root": "default", "path": "../../etc"}]}`,
+		"broken.json":     `{"version": 1,`,
+	}
+	for name, content := range invalid {
+		if _, err := ReadExport(write(name, content)); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
@@ -2,9 +2,13 @@ package utils
 
 import (
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // CreateProjectDir creates a project directory and returns its absolute path
@@ -96,6 +100,145 @@ func GetDirSize(path string) (int64, error) {
 	return size, err
 }
 
+// LastModified returns the newest modification time of the files in dir, skipping
+// hidden, dependency and build folders
+func LastModified(dir string) (time.Time, error) {
+	var newest time.Time
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && (workspaceSkipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		return nil
+	})
+	return newest, err
+}
+
+// LastActivity returns when a project was last worked on: the time of its last git
+// commit, or the newest modification time of its files when it has no commit
+func LastActivity(dir string) (time.Time, error) {
+	if IsGitRepo(dir) {
+		if last := GitLastCommit(dir); !last.IsZero() {
+			return last, nil
+		}
+	}
+	return LastModified(dir)
+}
+
+// ParseAge parses an age such as "30d", "2w" or any Go duration like "12h"
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if number, found := strings.CutSuffix(s, suffix); found {
+			n, err := strconv.Atoi(number)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid age %q (use e.g. 30d, 2w or 12h)", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 30d, 2w or 12h)", s)
+	}
+	return age, nil
+}
+
+// MoveDir moves a directory to a new location, copying it when it is on another filesystem
+func MoveDir(src, dst string) error {
+	// Try simple rename first (works if on same filesystem)
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	// If rename fails, do a copy then delete
+	if err := copyDir(src, dst); err != nil {
+		return fmt.Errorf("failed to copy directory: %w", err)
+	}
+
+	if err := os.RemoveAll(src); err != nil {
+		return fmt.Errorf("failed to remove source directory: %w", err)
+	}
+
+	return nil
+}
+
+// copyDir recursively copies a directory
+func copyDir(src, dst string) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+
+		if entry.Type()&os.ModeSymlink != 0 {
+			// Links are recreated as they are, e.g. node_modules/.bin entries
+			target, err := os.Readlink(srcPath)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(target, dstPath); err != nil {
+				return err
+			}
+		} else if entry.IsDir() {
+			if err := copyDir(srcPath, dstPath); err != nil {
+				return err
+			}
+		} else {
+			if err := copyFile(srcPath, dstPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// copyFile copies a single file
+func copyFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = srcFile.Close() }()
+
+	srcInfo, err := srcFile.Stat()
+	if err != nil {
+		return err
+	}
+
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, srcInfo.Mode())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dstFile.Close() }()
+
+	_, err = io.Copy(dstFile, srcFile)
+	return err
+}
+
 // ShortPath replaces the user's home directory with ~
 func ShortPath(path string) string {
 	home, err := os.UserHomeDir()
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		age  string
+		want time.Duration
+	}{
+		{"30d", 30 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"12h", 12 * time.Hour},
+		{"90m", 90 * time.Minute},
+		{" 0d ", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.age)
+		if err != nil {
+			t.Errorf("ParseAge(%q) failed: %v", tt.age, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"", "d", "-3d", "1.5d", "month"} {
+		if _, err := ParseAge(invalid); err == nil {
+			t.Errorf("Expected ParseAge(%q) to fail", invalid)
+		}
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	recent := time.Now().Add(-2 * time.Hour)
+
+	writeFile := func(rel string, modTime time.Time) {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", rel, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set time of %s: %v", rel, err)
+		}
+	}
+	writeFile("main.py", old)
+	writeFile("src/app.py", recent)
+	// Dependency, build and hidden folders do not count as activity
+	writeFile("node_modules/pkg/index.js", time.Now())
+	writeFile(".venv/lib/site.py", time.Now())
+
+	got, err := LastModified(dir)
+	if err != nil {
+		t.Fatalf("LastModified failed: %v", err)
+	}
+	if got.Sub(recent).Abs() > time.Second {
+		t.Errorf("Expected %v, got %v", recent, got)
+	}
+}
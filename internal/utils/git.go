@@ -0,0 +1,258 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IsGitRepo reports whether dir is the root of a git repository
+func IsGitRepo(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// GitRemote returns the URL of the origin remote of the repository in dir, or ""
+// when dir is not a repository or has no origin
+func GitRemote(dir string) string {
+	if !IsGitRepo(dir) {
+		return ""
+	}
+	out, err := exec.Command("git", "-C", dir, "config", "--get", "remote.origin.url").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// NormalizeRemote reduces a git remote URL to host/path so SSH and HTTPS forms of the
+// same repository compare equal, e.g. "git@github.com:acme/app.git" and
+// "https://github.com/acme/app" both become "github.com/acme/app"
+func NormalizeRemote(remote string) string {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return ""
+	}
+
+	if scheme, rest, found := strings.Cut(remote, "://"); found && scheme != "" {
+		remote = rest
+		// Drop credentials such as "user@" or "user:token@"
+		if at := strings.LastIndex(strings.SplitN(remote, "/", 2)[0], "@"); at >= 0 {
+			remote = remote[at+1:]
+		}
+	} else if at := strings.Index(remote, "@"); at >= 0 && strings.Contains(remote[at:], ":") {
+		// scp-like syntax: user@host:path
+		remote = strings.Replace(remote[at+1:], ":", "/", 1)
+	}
+
+	remote = strings.TrimSuffix(strings.TrimSuffix(remote, "/"), ".git")
+	return strings.ToLower(remote)
+}
+
+// GitStatus is the working tree and branch state of a git repository
+type GitStatus struct {
+	Branch       string // Current branch, empty when HEAD is detached
+	Commit       string // HEAD commit, empty before the first commit
+	Detached     bool
+	Upstream     string // Upstream branch, empty when none is set
+	UpstreamGone bool   // The upstream is set but no longer exists
+	Ahead        int    // Commits not pushed to the upstream
+	Behind       int    // Upstream commits not pulled yet
+	Staged       int
+	Unstaged     int
+	Untracked    int
+	Conflicts    int
+	Stashes      int
+	LastCommit   time.Time // Zero before the first commit
+}
+
+// Dirty reports whether the working tree or index has any change
+func (s *GitStatus) Dirty() bool {
+	return s.Staged+s.Unstaged+s.Untracked+s.Conflicts > 0
+}
+
+// HasTrackedChanges reports whether tracked files are modified, staged or conflicted.
+// Untracked files alone don't count: git refuses a pull that would overwrite one.
+func (s *GitStatus) HasTrackedChanges() bool {
+	return s.Staged+s.Unstaged+s.Conflicts > 0
+}
+
+// ReadGitStatus returns the status of the repository in dir
+func ReadGitStatus(dir string) (*GitStatus, error) {
+	out, err := runGit(dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil, err
+	}
+	status := ParseGitStatus(out)
+
+	// Fails harmlessly when there is no stash
+	if out, err := runGit(dir, "rev-list", "--walk-reflogs", "--count", "refs/stash"); err == nil {
+		status.Stashes, _ = strconv.Atoi(out)
+	}
+	status.LastCommit = GitLastCommit(dir)
+
+	return status, nil
+}
+
+// GitLastCommit returns the commit time of HEAD in the repository in dir, or the zero
+// time when there is no commit yet
+func GitLastCommit(dir string) time.Time {
+	out, err := runGit(dir, "log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(out, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// GitFetch fetches the remotes of the repository in dir
+func GitFetch(dir string) error {
+	_, err := runGit(dir, "fetch", "--quiet")
+	return err
+}
+
+// GitMerge merges the upstream of the current branch, which must already be fetched.
+// With ffOnly only a fast-forward is made; otherwise a merge that fails is aborted.
+func GitMerge(dir string, ffOnly bool) error {
+	if ffOnly {
+		_, err := runGit(dir, "merge", "--quiet", "--ff-only", "@{upstream}")
+		return err
+	}
+
+	if _, err := runGit(dir, "merge", "--quiet", "--no-edit", "@{upstream}"); err != nil {
+		_, _ = runGit(dir, "merge", "--abort")
+		return fmt.Errorf("%w (merge aborted)", err)
+	}
+	return nil
+}
+
+// GitHead returns the commit HEAD points to in the repository in dir
+func GitHead(dir string) (string, error) {
+	return runGit(dir, "rev-parse", "HEAD")
+}
+
+// GitBranch returns the branch checked out in the repository in dir, or "" when
+// HEAD is detached or dir is not a repository
+func GitBranch(dir string) string {
+	branch, err := runGit(dir, "symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// GitClone clones a repository into dir, checking out branch when given, without
+// prompting for credentials
+func GitClone(url, dir, branch string) error {
+	args := []string{"clone", "--quiet"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	args = append(args, "--", url, dir)
+
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); message != "" {
+			return fmt.Errorf("git clone: %s", message)
+		}
+		return fmt.Errorf("git clone failed: %w", err)
+	}
+	return nil
+}
+
+// GitChangedFiles returns the paths changed between two commits
+func GitChangedFiles(dir, from, to string) ([]string, error) {
+	out, err := runGit(dir, "diff", "--name-only", from, to)
+	if err != nil || out == "" {
+		return nil, err
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// runGit runs a git command in dir without prompting for credentials and returns its
+// trimmed output; errors carry the first line git printed
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if message, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); message != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ParseGitStatus parses the output of "git status --porcelain=v2 --branch"
+func ParseGitStatus(porcelain string) *GitStatus {
+	status := &GitStatus{}
+	hasAheadBehind := false
+
+	for _, line := range strings.Split(porcelain, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "#":
+			if len(fields) < 3 {
+				continue
+			}
+			switch fields[1] {
+			case "branch.oid":
+				if fields[2] != "(initial)" {
+					status.Commit = fields[2]
+				}
+			case "branch.head":
+				if fields[2] == "(detached)" {
+					status.Detached = true
+				} else {
+					status.Branch = fields[2]
+				}
+			case "branch.upstream":
+				status.Upstream = fields[2]
+			case "branch.ab":
+				hasAheadBehind = true
+				status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "+"))
+				if len(fields) > 3 {
+					status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[3], "-"))
+				}
+			}
+
+		case "1", "2":
+			// The XY field holds the staged and unstaged state, "." meaning unchanged
+			if fields[1][0] != '.' {
+				status.Staged++
+			}
+			if len(fields[1]) > 1 && fields[1][1] != '.' {
+				status.Unstaged++
+			}
+
+		case "u":
+			status.Conflicts++
+
+		case "?":
+			status.Untracked++
+		}
+	}
+
+	// Git only reports ahead/behind counts while the upstream branch exists
+	status.UpstreamGone = status.Upstream != "" && !hasAheadBehind
+	return status
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/genesix/pkt/internal/db"
+	"golang.org/x/mod/modfile"
+)
+
+// readGoMod parses a project's go.mod, returning nil when there is none
+func readGoMod(projectPath string) (*modfile.File, error) {
+	modPath := filepath.Join(projectPath, "go.mod")
+
+	data, err := os.ReadFile(modPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	file, err := modfile.Parse(modPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	return file, nil
+}
+
+// ParseGoMod parses the requirements of a Go go.mod file.
+// Requirements marked "// indirect" are recorded as indirect dependencies.
+func ParseGoMod(projectPath string) (map[string]*db.Dependency, error) {
+	deps := make(map[string]*db.Dependency)
+
+	file, err := readGoMod(projectPath)
+	if err != nil || file == nil {
+		return deps, err
+	}
+
+	for _, req := range file.Require {
+		// go.mod records the selected version, so it is also the resolved one.
+		// Go indirect deps are still production deps.
+		deps[req.Mod.Path] = &db.Dependency{
+			Name:     req.Mod.Path,
+			Version:  req.Mod.Version,
+			Resolved: req.Mod.Version,
+			DepType:  "prod",
+			Indirect: req.Indirect,
+		}
+	}
+
+	return deps, nil
+}
+
+// ParseGoModDirectives returns the replace, exclude, retract, toolchain and tool
+// directives of a Go go.mod file in that order
+func ParseGoModDirectives(projectPath string) ([]*db.Directive, error) {
+	file, err := readGoMod(projectPath)
+	if err != nil || file == nil {
+		return nil, err
+	}
+
+	var directives []*db.Directive
+
+	for _, r := range file.Replace {
+		directives = append(directives, &db.Directive{
+			Kind:          db.DirectiveReplace,
+			Path:          r.Old.Path,
+			Version:       r.Old.Version,
+			Target:        r.New.Path,
+			TargetVersion: r.New.Version,
+		})
+	}
+
+	for _, e := range file.Exclude {
+		directives = append(directives, &db.Directive{
+			Kind:    db.DirectiveExclude,
+			Path:    e.Mod.Path,
+			Version: e.Mod.Version,
+		})
+	}
+
+	for _, r := range file.Retract {
+		version := r.Low
+		if r.Low != r.High {
+			version = fmt.Sprintf("[%s, %s]", r.Low, r.High)
+		}
+		directives = append(directives, &db.Directive{
+			Kind:    db.DirectiveRetract,
+			Version: version,
+			Note:    r.Rationale,
+		})
+	}
+
+	if file.Toolchain != nil {
+		directives = append(directives, &db.Directive{
+			Kind:    db.DirectiveToolchain,
+			Version: file.Toolchain.Name,
+		})
+	}
+
+	for _, t := range file.Tool {
+		module, version := toolModule(file, t.Path)
+		directives = append(directives, &db.Directive{
+			Kind:    db.DirectiveTool,
+			Path:    t.Path,
+			Version: version,
+			Target:  module,
+		})
+	}
+
+	return directives, nil
+}
+
+// toolModule finds the required module providing a tool package: the one with
+// the longest path that prefixes the package path. Tools inside the main
+// module have no version.
+func toolModule(file *modfile.File, pkg string) (string, string) {
+	module, version := "", ""
+	if file.Module != nil && isPathPrefix(file.Module.Mod.Path, pkg) {
+		module = file.Module.Mod.Path
+	}
+
+	for _, req := range file.Require {
+		if isPathPrefix(req.Mod.Path, pkg) && len(req.Mod.Path) > len(module) {
+			module, version = req.Mod.Path, req.Mod.Version
+		}
+	}
+	return module, version
+}
+
+// isPathPrefix reports whether prefix is pkg or one of its parent import paths
+func isPathPrefix(prefix, pkg string) bool {
+	return pkg == prefix || strings.HasPrefix(pkg, prefix+"/")
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+const testGoMod = `module example.com/svc
+
+go 1.24
+
+toolchain go1.24.2
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	example.com/shared v0.0.0
+	golang.org/x/tools v0.30.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+)
+
+replace example.com/shared => ../shared
+
+replace github.com/spf13/pflag v1.0.5 => github.com/fork/pflag v1.0.6
+
+exclude github.com/spf13/cobra v1.7.0
+
+retract (
+	v0.1.0 // Published by mistake
+	[v0.2.0, v0.2.3]
+)
+
+tool (
+	golang.org/x/tools/cmd/stringer
+	example.com/svc/cmd/gen
+)
+`
+
+func TestParseGoMod(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"go.mod": testGoMod})
+
+	deps, err := ParseGoMod(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse go.mod: %v", err)
+	}
+
+	if len(deps) != 4 {
+		t.Fatalf("Expected 4 dependencies, got %d", len(deps))
+	}
+
+	tests := []struct {
+		name     string
+		version  string
+		indirect bool
+	}{
+		{"github.com/spf13/cobra", "v1.8.0", false},
+		{"example.com/shared", "v0.0.0", false},
+		{"golang.org/x/tools", "v0.30.0", true},
+		{"github.com/spf13/pflag", "v1.0.5", true},
+	}
+	for _, tt := range tests {
+		dep := deps[tt.name]
+		if dep == nil {
+			t.Errorf("Expected dependency %q", tt.name)
+			continue
+		}
+		if dep.Version != tt.version || dep.Resolved != tt.version || dep.Indirect != tt.indirect || dep.DepType != "prod" {
+			t.Errorf("Unexpected %s: %+v", tt.name, dep)
+		}
+	}
+}
+
+func TestParseGoModDirectives(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"go.mod": testGoMod})
+
+	directives, err := ParseGoModDirectives(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse go.mod directives: %v", err)
+	}
+
+	expected := []db.Directive{
+		{Kind: db.DirectiveReplace, Path: "example.com/shared", Target: "../shared"},
+		{Kind: db.DirectiveReplace, Path: "github.com/spf13/pflag", Version: "v1.0.5", Target: "github.com/fork/pflag", TargetVersion: "v1.0.6"},
+		{Kind: db.DirectiveExclude, Path: "github.com/spf13/cobra", Version: "v1.7.0"},
+		{Kind: db.DirectiveRetract, Version: "v0.1.0", Note: "Published by mistake"},
+		{Kind: db.DirectiveRetract, Version: "[v0.2.0, v0.2.3]"},
+		{Kind: db.DirectiveToolchain, Version: "go1.24.2"},
+		{Kind: db.DirectiveTool, Path: "golang.org/x/tools/cmd/stringer", Version: "v0.30.0", Target: "golang.org/x/tools"},
+		{Kind: db.DirectiveTool, Path: "example.com/svc/cmd/gen", Target: "example.com/svc"},
+	}
+
+	if len(directives) != len(expected) {
+		t.Fatalf("Expected %d directives, got %d", len(expected), len(directives))
+	}
+	for i, want := range expected {
+		if *directives[i] != want {
+			t.Errorf("Directive %d: expected %+v, got %+v", i, want, *directives[i])
+		}
+	}
+
+	if !directives[0].IsLocalReplace() || directives[1].IsLocalReplace() {
+		t.Error("Expected only the directory replacement to be local")
+	}
+}
+
+func TestParseGoModMissingAndInvalid(t *testing.T) {
+	deps, err := ParseGoMod(t.TempDir())
+	if err != nil || len(deps) != 0 {
+		t.Errorf("Expected no dependencies without go.mod, got %v, %v", deps, err)
+	}
+
+	dir := writeProjectFiles(t, map[string]string{"go.mod": "module example.com/bad\n\nrequire (\n"})
+	if _, err := ParseGoMod(dir); err == nil {
+		t.Error("Expected error for invalid go.mod, got nil")
+	}
+}
@@ -0,0 +1,1116 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/genesix/pkt/internal/db"
+	"gopkg.in/yaml.v3"
+)
+
+// lockedPackage is a single package version pinned by a lockfile
+type lockedPackage struct {
+	Name     string
+	Version  string
+	Dev      bool
+	TopLevel bool // Version the root project resolves when several are locked
+}
+
+// lockImporter identifies the project a lockfile is read for. Lockfiles written at
+// the root of a workspace cover every member, each under its own entry.
+type lockImporter struct {
+	Path string // Project folder relative to the lockfile, with slashes; "." for the lockfile's own folder
+	Name string // Package name from the project's manifest, for lockfiles keyed by name (Cargo.lock)
+}
+
+// errNotImporter is returned by a lockfile parser when the lockfile has no entry for
+// the importer it was read for
+var errNotImporter = errors.New("project not found in lockfile")
+
+// lockfileParser parses one lockfile format into the locked packages of an importer
+type lockfileParser struct {
+	File   string
+	Parse  func(data []byte, importer lockImporter) ([]lockedPackage, error)
+	Shared bool // Written once at a workspace root for all members
+}
+
+// lockfileParsers lists the supported lockfiles per language, in order of preference
+var lockfileParsers = map[string][]lockfileParser{
+	"javascript": {
+		{"pnpm-lock.yaml", parsePnpmLock, true},
+		{"bun.lock", parseBunLock, true},
+		{"package-lock.json", parsePackageLock, true},
+	},
+	"python": {
+		{"uv.lock", parseUvLock, true},
+		{"poetry.lock", parsePoetryLock, false},
+	},
+	"go": {
+		{"go.sum", parseGoSum, false},
+	},
+	"rust": {
+		{"Cargo.lock", parseCargoLock, true},
+	},
+}
+
+// FindLockfile returns the path of the first supported lockfile in a project, or "" if none exists
+func FindLockfile(projectPath, language string) string {
+	for _, parser := range lockfileParsers[language] {
+		path := filepath.Join(projectPath, parser.File)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// ApplyLockfile records the versions resolved by the project's lockfile on deps
+// and adds transitive dependencies flagged as indirect. Projects without a lockfile
+// of their own use the nearest workspace lockfile above them that lists them.
+// Projects without a supported lockfile are left untouched.
+func ApplyLockfile(projectPath, language string, deps map[string]*db.Dependency) error {
+	importer := lockImporter{Path: ".", Name: lockImporterName(projectPath, language)}
+
+	dir := projectPath
+	for {
+		found, err := applyLockfileIn(dir, importer, language, deps)
+		if err != nil || found {
+			return err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(parent, projectPath)
+		if err != nil {
+			return nil
+		}
+		dir, importer.Path = parent, filepath.ToSlash(rel)
+	}
+}
+
+// applyLockfileIn applies the first supported lockfile in dir that covers the
+// importer, and reports whether the search for a lockfile is over
+func applyLockfileIn(dir string, importer lockImporter, language string, deps map[string]*db.Dependency) (bool, error) {
+	for _, parser := range lockfileParsers[language] {
+		if importer.Path != "." && !parser.Shared {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, parser.File))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, fmt.Errorf("failed to read %s: %w", parser.File, err)
+		}
+
+		pkgs, err := parser.Parse(data, importer)
+		if errors.Is(err, errNotImporter) {
+			// A project's own lockfile ends the search even when it has no entry
+			// for it, e.g. the virtual manifest of a Cargo workspace
+			return importer.Path == ".", nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to parse %s: %w", parser.File, err)
+		}
+
+		normalize := strings.TrimSpace
+		if language == "python" {
+			normalize = NormalizePythonName
+		}
+		mergeLockedPackages(deps, pkgs, normalize)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// lockImporterName returns the package name lockfiles keyed by name know a project by
+func lockImporterName(projectPath, language string) string {
+	if language != "rust" {
+		return ""
+	}
+	manifest, err := readCargoManifest(filepath.Join(projectPath, "Cargo.toml"))
+	if err != nil {
+		return ""
+	}
+	return manifest.Package.Name
+}
+
+// lockGraph walks the dependency graph of a workspace lockfile from one importer, so
+// each member only gets the packages it actually depends on
+type lockGraph struct {
+	pkgs    []lockedPackage
+	visited map[string]bool // Node key to whether it was only reached via dev dependencies
+}
+
+// newLockGraph returns an empty walk
+func newLockGraph() *lockGraph {
+	return &lockGraph{visited: make(map[string]bool)}
+}
+
+// enter marks a node reached from the importer and reports whether its own
+// dependencies still need walking. A node first reached via dev dependencies is
+// walked again when a production path leads to it.
+func (g *lockGraph) enter(key string, dev bool) bool {
+	if devOnly, seen := g.visited[key]; seen && (dev || !devOnly) {
+		return false
+	}
+	g.visited[key] = dev
+	return true
+}
+
+// visit enters a locked package and records it
+func (g *lockGraph) visit(key string, pkg lockedPackage) bool {
+	if !g.enter(key, pkg.Dev) {
+		// A direct dependency also reached transitively still resolves at top level
+		if pkg.TopLevel {
+			g.pkgs = append(g.pkgs, pkg)
+		}
+		return false
+	}
+	g.pkgs = append(g.pkgs, pkg)
+	return true
+}
+
+// mergeLockedPackages merges lockfile entries into the manifest dependencies.
+// Declared dependencies get their resolved version; everything else is added as indirect.
+// When a package is locked at several versions, the top-level one wins, otherwise the highest.
+func mergeLockedPackages(deps map[string]*db.Dependency, pkgs []lockedPackage, normalize func(string) string) {
+	byKey := make(map[string]*db.Dependency, len(deps))
+	pinned := make(map[string]bool)
+	for _, dep := range deps {
+		key := normalize(dep.Name)
+		byKey[key] = dep
+		// Manifests that pin exact versions (go.mod) already carry the resolution
+		if dep.Resolved != "" {
+			pinned[key] = true
+		}
+	}
+
+	for _, pkg := range pkgs {
+		// Skip unnamed entries and non-registry sources (links, git, workspace, file)
+		if pkg.Name == "" || pkg.Version == "" || strings.Contains(pkg.Version, ":") {
+			continue
+		}
+
+		key := normalize(pkg.Name)
+		dep, ok := byKey[key]
+		if !ok {
+			dep = &db.Dependency{
+				Name:     pkg.Name,
+				DepType:  "dev",
+				Indirect: true,
+			}
+			deps[pkg.Name] = dep
+			byKey[key] = dep
+		}
+
+		// An indirect package is a production dependency if any locked copy is
+		if dep.Indirect && !pkg.Dev {
+			dep.DepType = "prod"
+		}
+
+		if pinned[key] {
+			continue
+		}
+		if pkg.TopLevel || dep.Resolved == "" || CompareVersions(pkg.Version, dep.Resolved) > 0 {
+			dep.Resolved = pkg.Version
+		}
+		if pkg.TopLevel {
+			pinned[key] = true
+		}
+	}
+}
+
+var pythonNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// NormalizePythonName normalizes a Python distribution name as described in PEP 503
+func NormalizePythonName(name string) string {
+	return pythonNameSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// CompareVersions compares two dotted version strings such as "1.2.10" and "v1.3.0-rc.1".
+// It returns -1, 0 or 1. Pre-release versions sort before the matching release.
+func CompareVersions(a, b string) int {
+	splitVersion := func(v string) (string, string) {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+		if i := strings.Index(v, "+"); i >= 0 {
+			v = v[:i]
+		}
+		release, pre, _ := strings.Cut(v, "-")
+		return release, pre
+	}
+
+	releaseA, preA := splitVersion(a)
+	releaseB, preB := splitVersion(b)
+
+	partsA := strings.Split(releaseA, ".")
+	partsB := strings.Split(releaseB, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		partA, partB := "0", "0"
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+
+		numA, errA := strconv.Atoi(partA)
+		numB, errB := strconv.Atoi(partB)
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case partA != partB:
+			if partA < partB {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	case preA < preB:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// packageLockEntry is a packages entry of package-lock.json v2+, keyed by install path
+type packageLockEntry struct {
+	Version              string            `json:"version"`
+	Dev                  bool              `json:"dev"`
+	Link                 bool              `json:"link"`
+	Workspaces           json.RawMessage   `json:"workspaces"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// parsePackageLock parses npm package-lock.json (lockfile versions 1 to 3). A
+// workspace lockfile is read from the importer's entry and the packages it depends on.
+func parsePackageLock(data []byte, importer lockImporter) ([]lockedPackage, error) {
+	type v1Dependency struct {
+		Version      string                     `json:"version"`
+		Dev          bool                       `json:"dev"`
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+
+	var lock struct {
+		Packages     map[string]packageLockEntry `json:"packages"`
+		Dependencies map[string]json.RawMessage  `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	var pkgs []lockedPackage
+
+	// Lockfile v2+: keys are install paths such as node_modules/a/node_modules/b
+	if len(lock.Packages) > 0 {
+		if workspaces := lock.Packages[""].Workspaces; len(workspaces) > 0 && string(workspaces) != "null" {
+			return walkPackageLock(lock.Packages, importer)
+		}
+		if importer.Path != "." {
+			return nil, errNotImporter
+		}
+
+		for path, entry := range lock.Packages {
+			i := strings.LastIndex(path, "node_modules/")
+			if i < 0 || entry.Link {
+				continue
+			}
+			pkgs = append(pkgs, lockedPackage{
+				Name:     path[i+len("node_modules/"):],
+				Version:  entry.Version,
+				Dev:      entry.Dev,
+				TopLevel: i == 0,
+			})
+		}
+		return pkgs, nil
+	}
+
+	// Lockfile v1 predates workspaces
+	if importer.Path != "." {
+		return nil, errNotImporter
+	}
+
+	// Lockfile v1: nested dependency trees
+	var walk func(entries map[string]json.RawMessage, topLevel bool) error
+	walk = func(entries map[string]json.RawMessage, topLevel bool) error {
+		for name, raw := range entries {
+			var dep v1Dependency
+			if err := json.Unmarshal(raw, &dep); err != nil {
+				return err
+			}
+			pkgs = append(pkgs, lockedPackage{
+				Name:     name,
+				Version:  dep.Version,
+				Dev:      dep.Dev,
+				TopLevel: topLevel,
+			})
+			if err := walk(dep.Dependencies, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(lock.Dependencies, true); err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// walkPackageLock returns the packages one workspace folder of a package-lock.json
+// depends on, resolving each dependency the way Node does: in the node_modules
+// folder of the dependent, then in those of its ancestors
+func walkPackageLock(packages map[string]packageLockEntry, importer lockImporter) ([]lockedPackage, error) {
+	from := importer.Path
+	if from == "." {
+		from = ""
+	}
+	root, ok := packages[from]
+	if !ok {
+		return nil, errNotImporter
+	}
+
+	// parentPath returns the folder Node looks in after path's own node_modules
+	parentPath := func(path string) string {
+		if i := strings.LastIndex(path, "/node_modules/"); i >= 0 {
+			return path[:i]
+		}
+		return ""
+	}
+	resolve := func(from, name string) (string, bool) {
+		for {
+			key := "node_modules/" + name
+			if from != "" {
+				key = from + "/" + key
+			}
+			if _, ok := packages[key]; ok {
+				return key, true
+			}
+			if from == "" {
+				return "", false
+			}
+			from = parentPath(from)
+		}
+	}
+
+	graph := newLockGraph()
+	var walk func(from, name string, dev, topLevel bool)
+	walk = func(from, name string, dev, topLevel bool) {
+		key, ok := resolve(from, name)
+		if !ok {
+			return
+		}
+		entry := packages[key]
+		// Links point to other workspace folders, which are projects of their own
+		if entry.Link {
+			return
+		}
+		pkg := lockedPackage{Name: name, Version: entry.Version, Dev: dev, TopLevel: topLevel}
+		if !graph.visit(key, pkg) {
+			return
+		}
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies, entry.PeerDependencies} {
+			for dep := range deps {
+				walk(key, dep, dev, false)
+			}
+		}
+	}
+
+	for _, deps := range []map[string]string{root.Dependencies, root.OptionalDependencies} {
+		for name := range deps {
+			walk(from, name, false, true)
+		}
+	}
+	for name := range root.DevDependencies {
+		walk(from, name, true, true)
+	}
+	return graph.pkgs, nil
+}
+
+// pnpmVersion decodes an importer entry, which is a plain version in lockfile v5
+// and a {specifier, version} mapping from v6 on
+type pnpmVersion string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (v *pnpmVersion) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*v = pnpmVersion(node.Value)
+		return nil
+	}
+
+	var spec struct {
+		Version string `yaml:"version"`
+	}
+	if err := node.Decode(&spec); err != nil {
+		return err
+	}
+	*v = pnpmVersion(spec.Version)
+	return nil
+}
+
+// pnpmImporter lists the dependencies of one pnpm project
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmVersion `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmVersion `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmVersion `yaml:"optionalDependencies"`
+}
+
+// pnpmPackage is a packages entry (lockfile v5 to v8) or a snapshots entry (v9)
+type pnpmPackage struct {
+	Dev                  bool              `yaml:"dev"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// cleanPnpmVersion strips peer dependency suffixes such as "18.2.0(react@18.2.0)" or "18.2.0_react@18.2.0"
+func cleanPnpmVersion(version string) string {
+	if i := strings.IndexAny(version, "(_"); i >= 0 {
+		version = version[:i]
+	}
+	return version
+}
+
+// parsePnpmPackageKey splits a pnpm packages key into name and version.
+// Keys look like "lodash@4.17.21" (v9), "/lodash@4.17.21" (v6) or "/lodash/4.17.21" (v5).
+func parsePnpmPackageKey(key string, slashSeparated bool) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+
+	if slashSeparated {
+		i := strings.LastIndex(key, "/")
+		if i <= 0 {
+			return "", ""
+		}
+		return key[:i], cleanPnpmVersion(key[i+1:])
+	}
+
+	if i := strings.Index(key, "("); i >= 0 {
+		key = key[:i]
+	}
+	i := strings.LastIndex(key, "@")
+	if i <= 0 {
+		return "", ""
+	}
+	return key[:i], key[i+1:]
+}
+
+// parsePnpmLock parses pnpm-lock.yaml (lockfile versions 5 to 9). A workspace
+// lockfile is read from the importer's entry and the packages it depends on.
+func parsePnpmLock(data []byte, importer lockImporter) ([]lockedPackage, error) {
+	var lock struct {
+		LockfileVersion string                  `yaml:"lockfileVersion"`
+		Importers       map[string]pnpmImporter `yaml:"importers"`
+		pnpmImporter    `yaml:",inline"`
+		Packages        map[string]pnpmPackage `yaml:"packages"`
+		Snapshots       map[string]pnpmPackage `yaml:"snapshots"`
+	}
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	// Lockfile v5 keeps the root importer at the top level
+	root, ok := lock.Importers[importer.Path]
+	if !ok {
+		if importer.Path != "." {
+			return nil, errNotImporter
+		}
+		root = lock.pnpmImporter
+	}
+
+	major, _, _ := strings.Cut(lock.LockfileVersion, ".")
+	version, _ := strconv.Atoi(major)
+	slashSeparated := version > 0 && version < 6
+
+	if len(lock.Importers) > 1 {
+		return walkPnpmLock(root, lock.Packages, lock.Snapshots), nil
+	}
+
+	var pkgs []lockedPackage
+	addImporterDeps := func(entries map[string]pnpmVersion, dev bool) {
+		for name, version := range entries {
+			pkgs = append(pkgs, lockedPackage{
+				Name:     name,
+				Version:  cleanPnpmVersion(string(version)),
+				Dev:      dev,
+				TopLevel: true,
+			})
+		}
+	}
+	addImporterDeps(root.Dependencies, false)
+	addImporterDeps(root.OptionalDependencies, false)
+	addImporterDeps(root.DevDependencies, true)
+
+	for key, entry := range lock.Packages {
+		name, version := parsePnpmPackageKey(key, slashSeparated)
+		pkgs = append(pkgs, lockedPackage{
+			Name:    name,
+			Version: version,
+			Dev:     entry.Dev,
+		})
+	}
+
+	return pkgs, nil
+}
+
+// walkPnpmLock returns the packages one importer of a workspace lockfile depends on
+func walkPnpmLock(importer pnpmImporter, packages, snapshots map[string]pnpmPackage) []lockedPackage {
+	// Entries are keyed by name and version including peers, in the style of the lockfile version
+	lookup := func(name, version string) (string, pnpmPackage, bool) {
+		for _, key := range []string{name + "@" + version, "/" + name + "@" + version, "/" + name + "/" + version} {
+			if entry, ok := snapshots[key]; ok {
+				return key, entry, true
+			}
+			if entry, ok := packages[key]; ok {
+				return key, entry, true
+			}
+		}
+		return "", pnpmPackage{}, false
+	}
+
+	graph := newLockGraph()
+	var walk func(name, version string, dev, topLevel bool)
+	walk = func(name, version string, dev, topLevel bool) {
+		// Workspace links and aliases are not registry packages
+		if strings.Contains(version, ":") {
+			return
+		}
+		key, entry, ok := lookup(name, version)
+		if !ok {
+			key = name + "@" + version
+		}
+		pkg := lockedPackage{Name: name, Version: cleanPnpmVersion(version), Dev: dev, TopLevel: topLevel}
+		if !graph.visit(key, pkg) || !ok {
+			return
+		}
+		for dep, v := range entry.Dependencies {
+			walk(dep, v, dev, false)
+		}
+		for dep, v := range entry.OptionalDependencies {
+			walk(dep, v, dev, false)
+		}
+	}
+
+	for name, version := range importer.Dependencies {
+		walk(name, string(version), false, true)
+	}
+	for name, version := range importer.OptionalDependencies {
+		walk(name, string(version), false, true)
+	}
+	for name, version := range importer.DevDependencies {
+		walk(name, string(version), true, true)
+	}
+	return graph.pkgs
+}
+
+// stripTrailingCommas removes commas directly before a closing bracket,
+// turning the JSONC written by bun into plain JSON
+func stripTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	escaped := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			out = append(out, c)
+			continue
+		}
+
+		if c == '"' {
+			inString = true
+		} else if c == ',' {
+			rest := bytes.TrimLeft(data[i+1:], " \t\r\n")
+			if len(rest) > 0 && (rest[0] == '}' || rest[0] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+
+	return out
+}
+
+// bunDependencies lists the dependencies of a bun.lock workspace or package
+type bunDependencies struct {
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// parseBunLock parses the text bun.lock format (bun 1.2+). A workspace lockfile is
+// read from the importer's entry and the packages it depends on.
+func parseBunLock(data []byte, importer lockImporter) ([]lockedPackage, error) {
+	var lock struct {
+		Workspaces map[string]bunDependencies   `json:"workspaces"`
+		Packages   map[string][]json.RawMessage `json:"packages"`
+	}
+	if err := json.Unmarshal(stripTrailingCommas(data), &lock); err != nil {
+		return nil, err
+	}
+
+	if len(lock.Workspaces) > 1 {
+		return walkBunLock(lock.Workspaces, lock.Packages, importer)
+	}
+	if importer.Path != "." {
+		return nil, errNotImporter
+	}
+
+	var pkgs []lockedPackage
+	for key, entry := range lock.Packages {
+		name, version, err := parseBunIdent(key, entry)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			continue
+		}
+
+		// Nested copies are keyed by their parent path, e.g. "express/debug"
+		pkgs = append(pkgs, lockedPackage{
+			Name:     name,
+			Version:  version,
+			TopLevel: key == name,
+		})
+	}
+
+	return pkgs, nil
+}
+
+// parseBunIdent reads the name and version of a bun.lock package entry, whose first
+// element identifies it as "name@version"
+func parseBunIdent(key string, entry []json.RawMessage) (string, string, error) {
+	if len(entry) == 0 {
+		return "", "", nil
+	}
+	var ident string
+	if err := json.Unmarshal(entry[0], &ident); err != nil {
+		return "", "", fmt.Errorf("invalid entry for %s: %w", key, err)
+	}
+	i := strings.LastIndex(ident, "@")
+	if i <= 0 {
+		return "", "", nil
+	}
+	return ident[:i], ident[i+1:], nil
+}
+
+// walkBunLock returns the packages one workspace of a bun.lock depends on. Packages
+// are keyed by the path of package names leading to them, e.g. "web/react" for a
+// copy only the web workspace uses, and resolve from the innermost path outwards.
+func walkBunLock(workspaces map[string]bunDependencies, packages map[string][]json.RawMessage, importer lockImporter) ([]lockedPackage, error) {
+	path := importer.Path
+	if path == "." {
+		path = ""
+	}
+	root, ok := workspaces[path]
+	if !ok {
+		return nil, errNotImporter
+	}
+
+	resolve := func(parents []string, name string) ([]string, bool) {
+		for i := len(parents); i >= 0; i-- {
+			key := append(append([]string{}, parents[:i]...), name)
+			if _, ok := packages[strings.Join(key, "/")]; ok {
+				return key, true
+			}
+		}
+		return nil, false
+	}
+
+	graph := newLockGraph()
+	var walkErr error
+	var walk func(parents []string, name string, dev, topLevel bool)
+	walk = func(parents []string, name string, dev, topLevel bool) {
+		keyPath, ok := resolve(parents, name)
+		if !ok || walkErr != nil {
+			return
+		}
+		key := strings.Join(keyPath, "/")
+		entry := packages[key]
+		pkgName, version, err := parseBunIdent(key, entry)
+		if err != nil {
+			walkErr = err
+			return
+		}
+		// Other workspaces are projects of their own
+		if pkgName == "" || strings.Contains(version, ":") {
+			return
+		}
+		if !graph.visit(key, lockedPackage{Name: pkgName, Version: version, Dev: dev, TopLevel: topLevel}) {
+			return
+		}
+
+		var info bunDependencies
+		if len(entry) > 2 {
+			_ = json.Unmarshal(entry[2], &info)
+		}
+		for _, deps := range []map[string]string{info.Dependencies, info.OptionalDependencies, info.PeerDependencies} {
+			for dep := range deps {
+				walk(keyPath, dep, dev, false)
+			}
+		}
+	}
+
+	// Copies only one workspace uses are nested under its package name
+	var parents []string
+	if path != "" && root.Name != "" {
+		parents = []string{root.Name}
+	}
+	for _, deps := range []map[string]string{root.Dependencies, root.OptionalDependencies} {
+		for name := range deps {
+			walk(parents, name, false, true)
+		}
+	}
+	for name := range root.DevDependencies {
+		walk(parents, name, true, true)
+	}
+	return graph.pkgs, walkErr
+}
+
+// cargoLockPackage is a [[package]] entry of Cargo.lock
+type cargoLockPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Source       string   `toml:"source"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+// parseCargoLock parses Rust Cargo.lock. A workspace lockfile is read from the
+// importer's crate and the crates it depends on.
+func parseCargoLock(data []byte, importer lockImporter) ([]lockedPackage, error) {
+	var lock struct {
+		Package []cargoLockPackage `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	local := 0
+	for _, pkg := range lock.Package {
+		if pkg.Source == "" {
+			local++
+		}
+	}
+	if local > 1 {
+		return walkCargoLock(lock.Package, importer)
+	}
+	if importer.Path != "." {
+		return nil, errNotImporter
+	}
+
+	var pkgs []lockedPackage
+	for _, pkg := range lock.Package {
+		// Local crates (the project itself, workspace members, path dependencies)
+		// have no source. Their dependency lists name the exact version of a crate
+		// when several versions are locked.
+		if pkg.Source == "" {
+			for _, dep := range pkg.Dependencies {
+				fields := strings.Fields(dep)
+				if len(fields) >= 2 {
+					pkgs = append(pkgs, lockedPackage{
+						Name:     fields[0],
+						Version:  fields[1],
+						TopLevel: true,
+					})
+				}
+			}
+			continue
+		}
+
+		pkgs = append(pkgs, lockedPackage{
+			Name:    pkg.Name,
+			Version: pkg.Version,
+		})
+	}
+
+	return pkgs, nil
+}
+
+// walkCargoLock returns the crates one local crate of a workspace Cargo.lock depends on
+func walkCargoLock(packages []cargoLockPackage, importer lockImporter) ([]lockedPackage, error) {
+	byName := make(map[string][]int)
+	start := -1
+	for i, pkg := range packages {
+		byName[pkg.Name] = append(byName[pkg.Name], i)
+		if pkg.Source == "" && importer.Name != "" && pkg.Name == importer.Name {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, errNotImporter
+	}
+
+	// Dependencies are written as "name", or "name version" when several versions are locked
+	resolve := func(dep string) (int, bool) {
+		fields := strings.Fields(dep)
+		if len(fields) == 0 {
+			return 0, false
+		}
+		for _, i := range byName[fields[0]] {
+			if len(fields) == 1 || packages[i].Version == fields[1] {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	graph := newLockGraph()
+	var walk func(i int, topLevel bool)
+	walk = func(i int, topLevel bool) {
+		pkg := packages[i]
+		// Other local crates are not recorded, but what they use is
+		key := pkg.Name + " " + pkg.Version
+		if pkg.Source == "" {
+			if !graph.enter(key, false) {
+				return
+			}
+		} else if !graph.visit(key, lockedPackage{Name: pkg.Name, Version: pkg.Version, TopLevel: topLevel}) {
+			return
+		}
+		for _, dep := range pkg.Dependencies {
+			if j, ok := resolve(dep); ok {
+				walk(j, false)
+			}
+		}
+	}
+
+	graph.enter(packages[start].Name+" "+packages[start].Version, false)
+	for _, dep := range packages[start].Dependencies {
+		if j, ok := resolve(dep); ok {
+			walk(j, true)
+		}
+	}
+	return graph.pkgs, nil
+}
+
+// parseGoSum parses Go go.sum, keeping the highest version of each module
+func parseGoSum(data []byte, _ lockImporter) ([]lockedPackage, error) {
+	var pkgs []lockedPackage
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+
+		// "/go.mod" entries only record the module graph, not downloaded code
+		if strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+
+		pkgs = append(pkgs, lockedPackage{
+			Name:    fields[0],
+			Version: fields[1],
+		})
+	}
+
+	return pkgs, scanner.Err()
+}
+
+// uvLockDependency is a dependency of a uv.lock package
+type uvLockDependency struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+}
+
+// uvLockPackage is a [[package]] entry of uv.lock
+type uvLockPackage struct {
+	Name                 string                        `toml:"name"`
+	Version              string                        `toml:"version"`
+	Source               map[string]any                `toml:"source"`
+	Dependencies         []uvLockDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvLockDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvLockDependency `toml:"dev-dependencies"`
+}
+
+// localPath returns the folder of the project itself or a workspace member, which
+// uv records as an editable or virtual source, or "" for other packages
+func (p uvLockPackage) localPath() string {
+	for _, kind := range []string{"editable", "virtual"} {
+		if path, ok := p.Source[kind].(string); ok {
+			return path
+		}
+	}
+	return ""
+}
+
+// parseUvLock parses Python uv.lock. A workspace lockfile is read from the
+// importer's package and the packages it depends on.
+func parseUvLock(data []byte, importer lockImporter) ([]lockedPackage, error) {
+	var lock struct {
+		Package []uvLockPackage `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	local := 0
+	for _, pkg := range lock.Package {
+		if pkg.localPath() != "" {
+			local++
+		}
+	}
+	if local > 1 {
+		return walkUvLock(lock.Package, importer)
+	}
+	if importer.Path != "." {
+		return nil, errNotImporter
+	}
+
+	var pkgs []lockedPackage
+	for _, pkg := range lock.Package {
+		// The project itself and workspace members are editable or virtual sources
+		if pkg.localPath() != "" {
+			for _, dep := range pkg.Dependencies {
+				if dep.Version != "" {
+					pkgs = append(pkgs, lockedPackage{
+						Name:     dep.Name,
+						Version:  dep.Version,
+						TopLevel: true,
+					})
+				}
+			}
+			continue
+		}
+
+		pkgs = append(pkgs, lockedPackage{
+			Name:    pkg.Name,
+			Version: pkg.Version,
+		})
+	}
+
+	return pkgs, nil
+}
+
+// walkUvLock returns the packages one member of a uv workspace lockfile depends on
+func walkUvLock(packages []uvLockPackage, importer lockImporter) ([]lockedPackage, error) {
+	byName := make(map[string][]int)
+	start := -1
+	for i, pkg := range packages {
+		name := NormalizePythonName(pkg.Name)
+		byName[name] = append(byName[name], i)
+		if path := pkg.localPath(); path != "" && filepath.ToSlash(filepath.Clean(path)) == importer.Path {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, errNotImporter
+	}
+
+	resolve := func(dep uvLockDependency) (int, bool) {
+		for _, i := range byName[NormalizePythonName(dep.Name)] {
+			if dep.Version == "" || packages[i].Version == dep.Version {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	graph := newLockGraph()
+	var walk func(dep uvLockDependency, dev, topLevel bool)
+	walk = func(dep uvLockDependency, dev, topLevel bool) {
+		i, ok := resolve(dep)
+		if !ok {
+			return
+		}
+		pkg := packages[i]
+		key := pkg.Name + " " + pkg.Version
+		// Other workspace members are not recorded, but what they use is
+		if pkg.localPath() != "" {
+			if !graph.enter(key, dev) {
+				return
+			}
+		} else if !graph.visit(key, lockedPackage{Name: pkg.Name, Version: pkg.Version, Dev: dev, TopLevel: topLevel}) {
+			return
+		}
+
+		next := pkg.Dependencies
+		for _, extra := range dep.Extra {
+			next = append(next, pkg.OptionalDependencies[extra]...)
+		}
+		for _, d := range next {
+			walk(d, dev, false)
+		}
+	}
+
+	member := packages[start]
+	graph.enter(member.Name+" "+member.Version, false)
+	for _, dep := range member.Dependencies {
+		walk(dep, false, true)
+	}
+	for _, deps := range member.OptionalDependencies {
+		for _, dep := range deps {
+			walk(dep, false, true)
+		}
+	}
+	for _, deps := range member.DevDependencies {
+		for _, dep := range deps {
+			walk(dep, true, true)
+		}
+	}
+	return graph.pkgs, nil
+}
+
+// parsePoetryLock parses Python poetry.lock
+func parsePoetryLock(data []byte, _ lockImporter) ([]lockedPackage, error) {
+	var lock struct {
+		Package []struct {
+			Name     string `toml:"name"`
+			Version  string `toml:"version"`
+			Category string `toml:"category"` // Only written by Poetry < 1.5
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	pkgs := make([]lockedPackage, 0, len(lock.Package))
+	for _, pkg := range lock.Package {
+		pkgs = append(pkgs, lockedPackage{
+			Name:    pkg.Name,
+			Version: pkg.Version,
+			Dev:     pkg.Category == "dev",
+		})
+	}
+
+	return pkgs, nil
+}
@@ -0,0 +1,777 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+// writeProjectFiles creates a temporary project directory containing the given files
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// expectDep checks the resolved version and indirect flag of a parsed dependency
+func expectDep(t *testing.T, deps map[string]*db.Dependency, name, resolved string, indirect bool) {
+	t.Helper()
+
+	dep, ok := deps[name]
+	if !ok {
+		t.Errorf("Expected dependency %q", name)
+		return
+	}
+	if dep.Resolved != resolved {
+		t.Errorf("Expected %s resolved to %q, got %q", name, resolved, dep.Resolved)
+	}
+	if dep.Indirect != indirect {
+		t.Errorf("Expected %s indirect=%v, got %v", name, indirect, dep.Indirect)
+	}
+}
+
+const testPackageJSON = `{
+	"name": "web",
+	"dependencies": { "lodash": "^4.17.0", "@scope/ui": "^1.0.0" },
+	"devDependencies": { "typescript": "^5.0.0" }
+}`
+
+func TestParseDependenciesPackageLock(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": testPackageJSON,
+		"package-lock.json": `{
+			"lockfileVersion": 3,
+			"packages": {
+				"": { "name": "web" },
+				"node_modules/lodash": { "version": "4.17.21" },
+				"node_modules/@scope/ui": { "version": "1.2.0" },
+				"node_modules/@scope/ui/node_modules/lodash": { "version": "3.10.1" },
+				"node_modules/typescript": { "version": "5.4.5", "dev": true },
+				"node_modules/debug": { "version": "4.3.4" },
+				"node_modules/ms": { "version": "2.1.2", "dev": true },
+				"packages/local": { "version": "0.0.0" },
+				"node_modules/local": { "resolved": "packages/local", "link": true }
+			}
+		}`,
+	})
+
+	deps, err := ParseDependencies(dir, "javascript")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "lodash", "4.17.21", false)
+	expectDep(t, deps, "@scope/ui", "1.2.0", false)
+	expectDep(t, deps, "typescript", "5.4.5", false)
+	expectDep(t, deps, "debug", "4.3.4", true)
+	expectDep(t, deps, "ms", "2.1.2", true)
+
+	if deps["lodash"].Version != "^4.17.0" {
+		t.Errorf("Expected declared range to be kept, got %q", deps["lodash"].Version)
+	}
+	if deps["ms"].DepType != "dev" || deps["debug"].DepType != "prod" {
+		t.Errorf("Expected ms dev and debug prod, got %s and %s", deps["ms"].DepType, deps["debug"].DepType)
+	}
+	if _, ok := deps["local"]; ok {
+		t.Error("Expected linked workspace packages to be skipped")
+	}
+}
+
+func TestParseDependenciesPackageLockV1(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": testPackageJSON,
+		"package-lock.json": `{
+			"lockfileVersion": 1,
+			"dependencies": {
+				"lodash": { "version": "4.17.21" },
+				"@scope/ui": {
+					"version": "1.2.0",
+					"dependencies": { "lodash": { "version": "3.10.1" } }
+				}
+			}
+		}`,
+	})
+
+	deps, err := ParseDependencies(dir, "javascript")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "lodash", "4.17.21", false)
+	expectDep(t, deps, "@scope/ui", "1.2.0", false)
+	expectDep(t, deps, "typescript", "", false)
+}
+
+func TestParseDependenciesPnpmLock(t *testing.T) {
+	tests := []struct {
+		name string
+		lock string
+	}{
+		{
+			name: "v9",
+			lock: `lockfileVersion: '9.0'
+importers:
+  .:
+    dependencies:
+      lodash:
+        specifier: ^4.17.0
+        version: 4.17.21
+      '@scope/ui':
+        specifier: ^1.0.0
+        version: 1.2.0(react@18.2.0)
+    devDependencies:
+      typescript:
+        specifier: ^5.0.0
+        version: 5.4.5
+packages:
+  lodash@4.17.21:
+    resolution: {integrity: sha512-x}
+  '@scope/ui@1.2.0':
+    resolution: {integrity: sha512-x}
+  react@18.2.0:
+    resolution: {integrity: sha512-x}
+  typescript@5.4.5:
+    resolution: {integrity: sha512-x}
+`,
+		},
+		{
+			name: "v6",
+			lock: `lockfileVersion: '6.0'
+dependencies:
+  lodash:
+    specifier: ^4.17.0
+    version: 4.17.21
+  '@scope/ui':
+    specifier: ^1.0.0
+    version: 1.2.0(react@18.2.0)
+devDependencies:
+  typescript:
+    specifier: ^5.0.0
+    version: 5.4.5
+packages:
+  /lodash@4.17.21:
+    dev: false
+  /@scope/ui@1.2.0(react@18.2.0):
+    dev: false
+  /react@18.2.0:
+    dev: false
+  /typescript@5.4.5:
+    dev: true
+`,
+		},
+		{
+			name: "v5",
+			lock: `lockfileVersion: 5.4
+specifiers:
+  lodash: ^4.17.0
+dependencies:
+  lodash: 4.17.21
+  '@scope/ui': 1.2.0_react@18.2.0
+devDependencies:
+  typescript: 5.4.5
+packages:
+  /lodash/4.17.21:
+    dev: false
+  /@scope/ui/1.2.0_react@18.2.0:
+    dev: false
+  /react/18.2.0:
+    dev: false
+  /typescript/5.4.5:
+    dev: true
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, map[string]string{
+				"package.json":   testPackageJSON,
+				"pnpm-lock.yaml": tt.lock,
+			})
+
+			deps, err := ParseDependencies(dir, "javascript")
+			if err != nil {
+				t.Fatalf("Failed to parse dependencies: %v", err)
+			}
+
+			expectDep(t, deps, "lodash", "4.17.21", false)
+			expectDep(t, deps, "@scope/ui", "1.2.0", false)
+			expectDep(t, deps, "typescript", "5.4.5", false)
+			expectDep(t, deps, "react", "18.2.0", true)
+		})
+	}
+}
+
+func TestParseDependenciesBunLock(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": testPackageJSON,
+		"bun.lock": `{
+  "lockfileVersion": 1,
+  "workspaces": {
+    "": {
+      "name": "web",
+      "dependencies": { "lodash": "^4.17.0", "@scope/ui": "^1.0.0", },
+    },
+  },
+  "packages": {
+    "lodash": ["lodash@4.17.21", "", {}, "sha512-x"],
+    "@scope/ui": ["@scope/ui@1.2.0", "", { "dependencies": { "lodash": "^3" } }, "sha512-x"],
+    "@scope/ui/lodash": ["lodash@3.10.1", "", {}, "sha512-x"],
+    "typescript": ["typescript@5.4.5", "", {}, "sha512-x"],
+    "ms": ["ms@2.1.2", "", {}, "sha512-x,"],
+  },
+}`,
+	})
+
+	deps, err := ParseDependencies(dir, "javascript")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "lodash", "4.17.21", false)
+	expectDep(t, deps, "@scope/ui", "1.2.0", false)
+	expectDep(t, deps, "typescript", "5.4.5", false)
+	expectDep(t, deps, "ms", "2.1.2", true)
+}
+
+func TestParseDependenciesCargoLock(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"Cargo.toml": `[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = "1.0"
+rand = "0.8"
+`,
+		"Cargo.lock": `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "rand 0.8.5",
+ "serde",
+]
+
+[[package]]
+name = "rand"
+version = "0.7.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "serde"
+version = "1.0.197"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "libc"
+version = "0.2.153"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+	})
+
+	deps, err := ParseDependencies(dir, "rust")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "serde", "1.0.197", false)
+	expectDep(t, deps, "rand", "0.8.5", false)
+	expectDep(t, deps, "libc", "0.2.153", true)
+	if _, ok := deps["app"]; ok {
+		t.Error("Expected the root crate to be skipped")
+	}
+}
+
+func TestParseDependenciesGoSum(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"go.mod": `module example.com/app
+
+go 1.22
+
+require (
+	github.com/spf13/cobra v1.8.0
+	github.com/spf13/pflag v1.0.5 // indirect
+)
+`,
+		"go.sum": `github.com/spf13/cobra v1.8.0 h1:x=
+github.com/spf13/cobra v1.8.0/go.mod h1:x=
+github.com/spf13/pflag v1.0.5 h1:x=
+github.com/spf13/pflag v1.0.5/go.mod h1:x=
+github.com/inconshreveable/mousetrap v1.0.0 h1:x=
+github.com/inconshreveable/mousetrap v1.1.0 h1:x=
+github.com/inconshreveable/mousetrap v1.1.0/go.mod h1:x=
+gopkg.in/yaml.v3 v3.0.1/go.mod h1:x=
+`,
+	})
+
+	deps, err := ParseDependencies(dir, "go")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "github.com/spf13/cobra", "v1.8.0", false)
+	expectDep(t, deps, "github.com/spf13/pflag", "v1.0.5", true)
+	expectDep(t, deps, "github.com/inconshreveable/mousetrap", "v1.1.0", true)
+	if _, ok := deps["gopkg.in/yaml.v3"]; ok {
+		t.Error("Expected go.mod-only go.sum entries to be skipped")
+	}
+}
+
+func TestParseDependenciesUvLock(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"pyproject.toml": `[project]
+name = "app"
+version = "0.1.0"
+dependencies = [
+    "Flask>=3.0",
+    "typing_extensions",
+]
+`,
+		"uv.lock": `version = 1
+
+[[package]]
+name = "app"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "flask" },
+    { name = "typing-extensions" },
+]
+
+[[package]]
+name = "flask"
+version = "3.0.3"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "typing-extensions"
+version = "4.11.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "werkzeug"
+version = "3.0.2"
+source = { registry = "https://pypi.org/simple" }
+`,
+	})
+
+	deps, err := ParseDependencies(dir, "python")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "Flask", "3.0.3", false)
+	expectDep(t, deps, "typing_extensions", "4.11.0", false)
+	expectDep(t, deps, "werkzeug", "3.0.2", true)
+	if _, ok := deps["app"]; ok {
+		t.Error("Expected the editable root package to be skipped")
+	}
+}
+
+func TestParseDependenciesPoetryLock(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"requirements.txt": "requests>=2.28\n",
+		"poetry.lock": `[[package]]
+name = "requests"
+version = "2.31.0"
+category = "main"
+
+[[package]]
+name = "urllib3"
+version = "2.2.1"
+category = "main"
+
+[[package]]
+name = "pytest"
+version = "8.1.1"
+category = "dev"
+`,
+	})
+
+	deps, err := ParseDependencies(dir, "python")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "requests", "2.31.0", false)
+	expectDep(t, deps, "urllib3", "2.2.1", true)
+	expectDep(t, deps, "pytest", "8.1.1", true)
+	if deps["pytest"].DepType != "dev" {
+		t.Errorf("Expected pytest to be a dev dependency, got %s", deps["pytest"].DepType)
+	}
+}
+
+func TestParseDependenciesWorkspaceMember(t *testing.T) {
+	type want struct {
+		resolved string
+		indirect bool
+	}
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		member   string
+		want     map[string]want
+		absent   []string
+	}{
+		{
+			name:     "pnpm",
+			language: "javascript",
+			files: map[string]string{
+				"package.json":              `{"name": "mono", "devDependencies": {"typescript": "^5.0.0"}}`,
+				"packages/web/package.json": `{"name": "web", "dependencies": {"react": "^18.0.0", "api": "workspace:*"}}`,
+				"packages/api/package.json": `{"name": "api", "dependencies": {"express": "^4.0.0"}}`,
+				"pnpm-lock.yaml": `lockfileVersion: '9.0'
+importers:
+  .:
+    devDependencies:
+      typescript:
+        specifier: ^5.0.0
+        version: 5.4.5
+  packages/web:
+    dependencies:
+      react:
+        specifier: ^18.0.0
+        version: 18.2.0
+      api:
+        specifier: workspace:*
+        version: link:../api
+  packages/api:
+    dependencies:
+      express:
+        specifier: ^4.0.0
+        version: 4.19.2
+packages:
+  react@18.2.0:
+    resolution: {integrity: sha512-x}
+  loose-envify@1.4.0:
+    resolution: {integrity: sha512-x}
+  express@4.19.2:
+    resolution: {integrity: sha512-x}
+  debug@2.6.9:
+    resolution: {integrity: sha512-x}
+  typescript@5.4.5:
+    resolution: {integrity: sha512-x}
+snapshots:
+  react@18.2.0:
+    dependencies:
+      loose-envify: 1.4.0
+  loose-envify@1.4.0: {}
+  express@4.19.2:
+    dependencies:
+      debug: 2.6.9
+  debug@2.6.9: {}
+  typescript@5.4.5: {}
+`,
+			},
+			member: "packages/web",
+			want:   map[string]want{"react": {"18.2.0", false}, "loose-envify": {"1.4.0", true}},
+			absent: []string{"express", "debug", "typescript"},
+		},
+		{
+			name:     "package-lock",
+			language: "javascript",
+			files: map[string]string{
+				"package.json":              `{"name": "mono", "workspaces": ["packages/*"], "devDependencies": {"typescript": "^5.0.0"}}`,
+				"packages/web/package.json": `{"name": "web", "dependencies": {"react": "^18.0.0", "ms": "^2.1.0"}}`,
+				"packages/api/package.json": `{"name": "api", "dependencies": {"express": "^4.0.0"}}`,
+				"package-lock.json": `{
+					"lockfileVersion": 3,
+					"packages": {
+						"": { "name": "mono", "workspaces": ["packages/*"], "devDependencies": { "typescript": "^5.0.0" } },
+						"packages/web": { "name": "web", "dependencies": { "react": "^18.0.0", "ms": "^2.1.0" } },
+						"packages/api": { "name": "api", "dependencies": { "express": "^4.0.0" } },
+						"node_modules/web": { "resolved": "packages/web", "link": true },
+						"node_modules/api": { "resolved": "packages/api", "link": true },
+						"node_modules/react": { "version": "18.2.0", "dependencies": { "loose-envify": "^1.1.0" } },
+						"node_modules/loose-envify": { "version": "1.4.0" },
+						"node_modules/express": { "version": "4.19.2", "dependencies": { "debug": "2.6.9" } },
+						"node_modules/debug": { "version": "2.6.9", "dependencies": { "ms": "2.0.0" } },
+						"node_modules/ms": { "version": "2.0.0" },
+						"packages/web/node_modules/ms": { "version": "2.1.3" },
+						"node_modules/typescript": { "version": "5.4.5", "dev": true }
+					}
+				}`,
+			},
+			member: "packages/web",
+			want:   map[string]want{"react": {"18.2.0", false}, "loose-envify": {"1.4.0", true}, "ms": {"2.1.3", false}},
+			absent: []string{"express", "debug", "typescript"},
+		},
+		{
+			name:     "bun",
+			language: "javascript",
+			files: map[string]string{
+				"package.json":              `{"name": "mono", "workspaces": ["packages/*"]}`,
+				"packages/web/package.json": `{"name": "web", "dependencies": {"react": "^18.0.0", "ms": "^2.1.0"}}`,
+				"packages/api/package.json": `{"name": "api", "dependencies": {"express": "^4.0.0"}}`,
+				"bun.lock": `{
+  "lockfileVersion": 1,
+  "workspaces": {
+    "": { "name": "mono", "devDependencies": { "typescript": "^5.0.0" }, },
+    "packages/web": { "name": "web", "dependencies": { "react": "^18.0.0", "ms": "^2.1.0" }, },
+    "packages/api": { "name": "api", "dependencies": { "express": "^4.0.0" }, },
+  },
+  "packages": {
+    "web": ["web@workspace:packages/web"],
+    "api": ["api@workspace:packages/api"],
+    "react": ["react@18.2.0", "", { "dependencies": { "loose-envify": "^1.1.0" } }, "sha512-x"],
+    "loose-envify": ["loose-envify@1.4.0", "", {}, "sha512-x"],
+    "express": ["express@4.19.2", "", { "dependencies": { "debug": "2.6.9" } }, "sha512-x"],
+    "debug": ["debug@2.6.9", "", { "dependencies": { "ms": "2.0.0" } }, "sha512-x"],
+    "ms": ["ms@2.0.0", "", {}, "sha512-x"],
+    "web/ms": ["ms@2.1.3", "", {}, "sha512-x"],
+    "typescript": ["typescript@5.4.5", "", {}, "sha512-x"],
+  },
+}`,
+			},
+			member: "packages/web",
+			want:   map[string]want{"react": {"18.2.0", false}, "loose-envify": {"1.4.0", true}, "ms": {"2.1.3", false}},
+			absent: []string{"express", "debug", "typescript"},
+		},
+		{
+			name:     "cargo",
+			language: "rust",
+			files: map[string]string{
+				"Cargo.toml":             "[workspace]\nmembers = [\"crates/*\"]\n",
+				"crates/cli/Cargo.toml":  "[package]\nname = \"cli\"\n\n[dependencies]\nclap = \"4\"\ncore = { path = \"../core\" }\n",
+				"crates/core/Cargo.toml": "[package]\nname = \"core\"\n\n[dependencies]\nserde = \"1\"\n",
+				"crates/srv/Cargo.toml":  "[package]\nname = \"srv\"\n\n[dependencies]\ntokio = \"1\"\n",
+				"Cargo.lock": `version = 3
+
+[[package]]
+name = "cli"
+version = "0.1.0"
+dependencies = ["clap", "core"]
+
+[[package]]
+name = "core"
+version = "0.1.0"
+dependencies = ["serde"]
+
+[[package]]
+name = "srv"
+version = "0.1.0"
+dependencies = ["tokio"]
+
+[[package]]
+name = "clap"
+version = "4.5.4"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+dependencies = ["clap_builder"]
+
+[[package]]
+name = "clap_builder"
+version = "4.5.2"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "serde"
+version = "1.0.197"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "tokio"
+version = "1.37.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+			},
+			member: "crates/cli",
+			want:   map[string]want{"clap": {"4.5.4", false}, "clap_builder": {"4.5.2", true}, "serde": {"1.0.197", true}},
+			absent: []string{"tokio", "cli"},
+		},
+		{
+			name:     "uv",
+			language: "python",
+			files: map[string]string{
+				"pyproject.toml":              "[project]\nname = \"mono\"\n\n[tool.uv.workspace]\nmembers = [\"packages/*\"]\n",
+				"packages/web/pyproject.toml": "[project]\nname = \"web\"\ndependencies = [\"flask>=3.0\"]\n",
+				"packages/api/pyproject.toml": "[project]\nname = \"api\"\ndependencies = [\"requests\"]\n",
+				"uv.lock": `version = 1
+
+[[package]]
+name = "mono"
+version = "0.1.0"
+source = { virtual = "." }
+
+[[package]]
+name = "web"
+version = "0.1.0"
+source = { editable = "packages/web" }
+dependencies = [{ name = "flask" }]
+
+[package.dev-dependencies]
+dev = [{ name = "pytest" }]
+
+[[package]]
+name = "api"
+version = "0.1.0"
+source = { editable = "packages/api" }
+dependencies = [{ name = "requests" }]
+
+[[package]]
+name = "flask"
+version = "3.0.3"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [{ name = "werkzeug" }]
+
+[[package]]
+name = "werkzeug"
+version = "3.0.2"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "pytest"
+version = "8.1.1"
+source = { registry = "https://pypi.org/simple" }
+`,
+			},
+			member: "packages/web",
+			want:   map[string]want{"flask": {"3.0.3", false}, "werkzeug": {"3.0.2", true}, "pytest": {"8.1.1", true}},
+			absent: []string{"requests", "web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, tt.files)
+
+			deps, err := ParseDependencies(filepath.Join(dir, filepath.FromSlash(tt.member)), tt.language)
+			if err != nil {
+				t.Fatalf("Failed to parse dependencies: %v", err)
+			}
+
+			for name, w := range tt.want {
+				expectDep(t, deps, name, w.resolved, w.indirect)
+			}
+			for _, name := range tt.absent {
+				if _, ok := deps[name]; ok {
+					t.Errorf("Expected %s, which the member does not depend on, to be left out", name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDependenciesWorkspaceRoot(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json":              `{"name": "mono", "devDependencies": {"typescript": "^5.0.0"}}`,
+		"packages/web/package.json": `{"name": "web", "dependencies": {"react": "^18.0.0"}}`,
+		"pnpm-lock.yaml": `lockfileVersion: '9.0'
+importers:
+  .:
+    devDependencies:
+      typescript:
+        specifier: ^5.0.0
+        version: 5.4.5
+  packages/web:
+    dependencies:
+      react:
+        specifier: ^18.0.0
+        version: 18.2.0
+packages:
+  react@18.2.0:
+    resolution: {integrity: sha512-x}
+  typescript@5.4.5:
+    resolution: {integrity: sha512-x}
+snapshots:
+  react@18.2.0: {}
+  typescript@5.4.5: {}
+`,
+	})
+
+	deps, err := ParseDependencies(dir, "javascript")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+
+	expectDep(t, deps, "typescript", "5.4.5", false)
+	if _, ok := deps["react"]; ok {
+		t.Error("Expected the workspace root not to take on its members' dependencies")
+	}
+}
+
+func TestParseDependenciesInvalidLockfile(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json":      testPackageJSON,
+		"package-lock.json": `{ not json`,
+	})
+
+	if _, err := ParseDependencies(dir, "javascript"); err == nil {
+		t.Error("Expected error for invalid lockfile, got nil")
+	}
+}
+
+func TestParseDependenciesWithoutLockfile(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": testPackageJSON,
+	})
+
+	deps, err := ParseDependencies(dir, "javascript")
+	if err != nil {
+		t.Fatalf("Failed to parse dependencies: %v", err)
+	}
+	if len(deps) != 3 {
+		t.Errorf("Expected only the 3 declared dependencies, got %d", len(deps))
+	}
+	expectDep(t, deps, "lodash", "", false)
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.10", "1.2.9", 1},
+		{"v1.0.0", "1.0.1", -1},
+		{"2.0", "2.0.0", 0},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+		{"v2.0.0+incompatible", "v1.9.9", 1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.expected {
+			t.Errorf("CompareVersions(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestNormalizePythonName(t *testing.T) {
+	tests := map[string]string{
+		"Flask":              "flask",
+		"typing_extensions":  "typing-extensions",
+		"zope.interface":     "zope-interface",
+		"Some__Weird-._Name": "some-weird-name",
+	}
+
+	for input, expected := range tests {
+		if got := NormalizePythonName(input); got != expected {
+			t.Errorf("NormalizePythonName(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
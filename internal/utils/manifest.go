@@ -1,307 +1,144 @@
 package utils
 
 import (
-	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
 
 	"github.com/genesix/pkt/internal/db"
 )
 
-// ParseRequirementsTxt parses Python requirements.txt
-func ParseRequirementsTxt(projectPath string) (map[string]*db.Dependency, error) {
-	reqPath := filepath.Join(projectPath, "requirements.txt")
-	deps := make(map[string]*db.Dependency)
+// ParsePythonDeps reads pyproject.toml, falling back to requirements.txt when it
+// declares no dependencies, and adds the dev requirements written by pip
+func ParsePythonDeps(projectPath string) (map[string]*db.Dependency, error) {
+	deps := newPythonDeps()
 
-	file, err := os.Open(reqPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return deps, nil
+	// Check for pyproject.toml first
+	pyprojectPath := filepath.Join(projectPath, "pyproject.toml")
+	if _, err := os.Stat(pyprojectPath); err == nil {
+		if err := collectPyproject(pyprojectPath, deps); err != nil {
+			return nil, err
 		}
-		return nil, err
 	}
-	defer func() { _ = file.Close() }()
-
-	// Regex to match package==version or package>=version etc
-	re := regexp.MustCompile(`^([a-zA-Z0-9_-]+)([=<>!~]+)?(.+)?$`)
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip comments and empty lines
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		// Skip options like -e, --index-url, etc
-		if strings.HasPrefix(line, "-") {
-			continue
-		}
-
-		matches := re.FindStringSubmatch(line)
-		if len(matches) >= 2 {
-			name := matches[1]
-			version := ""
-			if len(matches) >= 4 && matches[3] != "" {
-				version = matches[2] + matches[3]
-			}
 
-			deps[name] = &db.Dependency{
-				Name:    name,
-				Version: version,
-				DepType: "prod",
-			}
+	// Fall back to requirements.txt
+	if len(deps.deps) == 0 {
+		if err := collectRequirements(filepath.Join(projectPath, "requirements.txt"), pythonMainGroup, "prod", deps); err != nil {
+			return nil, err
 		}
 	}
 
-	return deps, scanner.Err()
-}
-
-// ParseGoMod parses Go go.mod file
-func ParseGoMod(projectPath string) (map[string]*db.Dependency, error) {
-	modPath := filepath.Join(projectPath, "go.mod")
-	deps := make(map[string]*db.Dependency)
-
-	data, err := os.ReadFile(modPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return deps, nil
-		}
+	// Pip.Add(dev=true) records dev packages in requirements-dev.txt
+	if err := collectRequirements(filepath.Join(projectPath, "requirements-dev.txt"), "dev", "dev", deps); err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(string(data), "\n")
-	inRequire := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Start of require block
-		if trimmed == "require (" {
-			inRequire = true
-			continue
-		}
-
-		// End of require block
-		if inRequire && trimmed == ")" {
-			inRequire = false
-			continue
-		}
-
-		// Single line require: require github.com/foo/bar v1.0.0
-		if strings.HasPrefix(trimmed, "require ") && !strings.Contains(trimmed, "(") {
-			parts := strings.Fields(trimmed)
-			if len(parts) >= 3 {
-				name := parts[1]
-				version := parts[2]
-
-				deps[name] = &db.Dependency{
-					Name:    name,
-					Version: version,
-					DepType: "prod",
-				}
-			}
-			continue
-		}
-
-		// Inside require block: github.com/foo/bar v1.0.0 // indirect
-		if inRequire && trimmed != "" && !strings.HasPrefix(trimmed, "//") {
-			parts := strings.Fields(trimmed)
-			if len(parts) >= 2 {
-				name := parts[0]
-				version := parts[1]
+	return deps.deps, nil
+}
 
-				// Go indirect deps are still production deps
-				depType := "prod"
+// manifestFiles are the files besides lockfiles that dependencies, workspace members
+// and components are read from
+var manifestFiles = map[string]bool{
+	"package.json":         true,
+	"pnpm-workspace.yaml":  true,
+	"pyproject.toml":       true,
+	"requirements.txt":     true,
+	"requirements-dev.txt": true,
+	"go.mod":               true,
+	"go.work":              true,
+	"Cargo.toml":           true,
+}
 
-				deps[name] = &db.Dependency{
-					Name:    name,
-					Version: version,
-					DepType: depType,
-				}
+// IsDependencyFile reports whether a changed file, given by path, can change the
+// dependencies recorded for a project: a manifest or a supported lockfile
+func IsDependencyFile(path string) bool {
+	name := filepath.Base(path)
+	if manifestFiles[name] {
+		return true
+	}
+	for _, parsers := range lockfileParsers {
+		for _, parser := range parsers {
+			if parser.File == name {
+				return true
 			}
 		}
 	}
-
-	return deps, nil
+	return false
 }
 
-// ParseCargoToml parses Rust Cargo.toml file
-func ParseCargoToml(projectPath string) (map[string]*db.Dependency, error) {
-	cargoPath := filepath.Join(projectPath, "Cargo.toml")
-	deps := make(map[string]*db.Dependency)
+// ParseDependencies parses dependencies based on language, then records the
+// resolved versions and transitive dependencies from the project's lockfile
+func ParseDependencies(projectPath, language string) (map[string]*db.Dependency, error) {
+	var deps map[string]*db.Dependency
+	var err error
 
-	data, err := os.ReadFile(cargoPath)
+	switch language {
+	case "javascript":
+		deps, err = ParsePackageJSON(projectPath)
+	case "python":
+		deps, err = ParsePythonDeps(projectPath)
+	case "go":
+		deps, err = ParseGoMod(projectPath)
+	case "rust":
+		deps, err = ParseCargoToml(projectPath)
+	default:
+		return make(map[string]*db.Dependency), nil
+	}
 	if err != nil {
-		if os.IsNotExist(err) {
-			return deps, nil
-		}
 		return nil, err
 	}
 
-	lines := strings.Split(string(data), "\n")
-	inDeps := false
-	inDevDeps := false
-
-	// Simple regex for dependency lines like: name = "version" or name = { version = "x" }
-	simpleRe := regexp.MustCompile(`^([a-zA-Z0-9_-]+)\s*=\s*"([^"]+)"`)
-	complexRe := regexp.MustCompile(`^([a-zA-Z0-9_-]+)\s*=\s*\{.*version\s*=\s*"([^"]+)"`)
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Detect section headers
-		if trimmed == "[dependencies]" {
-			inDeps = true
-			inDevDeps = false
-			continue
-		}
-		if trimmed == "[dev-dependencies]" {
-			inDeps = false
-			inDevDeps = true
-			continue
-		}
-		if strings.HasPrefix(trimmed, "[") {
-			inDeps = false
-			inDevDeps = false
-			continue
-		}
-
-		if !inDeps && !inDevDeps {
-			continue
-		}
-
-		depType := "prod"
-		if inDevDeps {
-			depType = "dev"
-		}
-
-		// Try simple format first
-		if matches := simpleRe.FindStringSubmatch(trimmed); len(matches) >= 3 {
-			deps[matches[1]] = &db.Dependency{
-				Name:    matches[1],
-				Version: matches[2],
-				DepType: depType,
-			}
-			continue
-		}
-
-		// Try complex format
-		if matches := complexRe.FindStringSubmatch(trimmed); len(matches) >= 3 {
-			deps[matches[1]] = &db.Dependency{
-				Name:    matches[1],
-				Version: matches[2],
-				DepType: depType,
-			}
-		}
+	if err := ApplyLockfile(projectPath, language, deps); err != nil {
+		return nil, err
 	}
 
 	return deps, nil
 }
 
-// ParsePyproject parses Python pyproject.toml (PEP 621 format)
-func ParsePyproject(projectPath string) (map[string]*db.Dependency, error) {
-	pyprojectPath := filepath.Join(projectPath, "pyproject.toml")
-	deps := make(map[string]*db.Dependency)
-
-	data, err := os.ReadFile(pyprojectPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return deps, nil
-		}
-		return nil, err
+// ParseDirectives returns the manifest directives recorded for a project's language:
+// go.mod replace, exclude, retract, toolchain and tool lines, and package.json
+// engines and packageManager
+func ParseDirectives(projectPath, language string) ([]*db.Directive, error) {
+	switch language {
+	case "go":
+		return ParseGoModDirectives(projectPath)
+	case "javascript":
+		return ParsePackageJSONDirectives(projectPath)
+	default:
+		return nil, nil
 	}
+}
 
-	lines := strings.Split(string(data), "\n")
-	inDeps := false
-	inDevDeps := false
-
-	// Match dependency lines like: "requests>=2.28.0", "flask", etc.
-	depRe := regexp.MustCompile(`^\s*"([a-zA-Z0-9_-]+)([<>=!~\[\]]*[^"]*)"`)
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Detect [project.dependencies]
-		if trimmed == "dependencies = [" {
-			inDeps = true
-			inDevDeps = false
-			continue
-		}
-
-		// Detect dev dependencies (various formats)
-		if strings.Contains(trimmed, "dev") && strings.Contains(trimmed, "= [") {
-			inDeps = false
-			inDevDeps = true
-			continue
-		}
-
-		// End of array
-		if (inDeps || inDevDeps) && trimmed == "]" {
-			inDeps = false
-			inDevDeps = false
-			continue
-		}
-
-		if !inDeps && !inDevDeps {
-			continue
-		}
-
-		depType := "prod"
-		if inDevDeps {
-			depType = "dev"
-		}
-
-		if matches := depRe.FindStringSubmatch(line); len(matches) >= 2 {
-			name := matches[1]
-			version := ""
-			if len(matches) >= 3 {
-				version = matches[2]
-			}
-			deps[name] = &db.Dependency{
-				Name:    name,
-				Version: version,
-				DepType: depType,
-			}
-		}
-	}
+// SyncProjectDependencies parses a project's dependencies and module directives
+// and stores them in the database, returning the number of dependencies synced
+func SyncProjectDependencies(projectID, projectPath, language string) (int, error) {
+	return syncDependencies(projectID, "", projectPath, language)
+}
 
-	return deps, nil
+// SyncComponentDependencies parses the dependencies and module directives of a
+// component of a polyglot project and stores them in the database
+func SyncComponentDependencies(projectID, projectPath string, component *db.Component) (int, error) {
+	return syncDependencies(projectID, component.Name, component.Dir(projectPath), component.Language)
 }
 
-// ParsePythonDeps tries pyproject.toml first, then falls back to requirements.txt
-func ParsePythonDeps(projectPath string) (map[string]*db.Dependency, error) {
-	// Check for pyproject.toml first
-	pyprojectPath := filepath.Join(projectPath, "pyproject.toml")
-	if _, err := os.Stat(pyprojectPath); err == nil {
-		deps, err := ParsePyproject(projectPath)
-		if err != nil {
-			return nil, err
-		}
-		if len(deps) > 0 {
-			return deps, nil
-		}
+// syncDependencies parses the manifests in dir and stores them under a component
+// (empty for the project's primary language)
+func syncDependencies(projectID, component, dir, language string) (int, error) {
+	deps, err := ParseDependencies(dir, language)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse dependencies: %w", err)
+	}
+	directives, err := ParseDirectives(dir, language)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse dependencies: %w", err)
 	}
 
-	// Fall back to requirements.txt
-	return ParseRequirementsTxt(projectPath)
-}
-
-// ParseDependencies parses dependencies based on language
-func ParseDependencies(projectPath, language string) (map[string]*db.Dependency, error) {
-	switch language {
-	case "javascript":
-		return ParsePackageJSON(projectPath)
-	case "python":
-		return ParsePythonDeps(projectPath)
-	case "go":
-		return ParseGoMod(projectPath)
-	case "rust":
-		return ParseCargoToml(projectPath)
-	default:
-		return make(map[string]*db.Dependency), nil
+	if err := db.SyncComponentDependencies(projectID, component, deps); err != nil {
+		return 0, fmt.Errorf("failed to sync dependencies: %w", err)
+	}
+	if err := db.SyncComponentDirectives(projectID, component, directives); err != nil {
+		return 0, fmt.Errorf("failed to sync dependencies: %w", err)
 	}
+
+	return len(deps), nil
 }
@@ -0,0 +1,506 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+// wantDep is an expected parsed dependency
+type wantDep struct {
+	version string
+	depType string
+}
+
+func TestParseCargoToml(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     map[string]wantDep
+		groups   map[string]string // Groups of the dependencies that have one
+	}{
+		{
+			name: "simple and inline tables",
+			manifest: `[package]
+name = "app"
+version = "0.1.0"
+edition = "2021"
+
+[dependencies]
+anyhow = "1.0"
+tokio = { version = "1.37", features = [
+    "macros",
+    "rt-multi-thread",
+] }
+local = { path = "../local" }
+
+[dev-dependencies]
+insta = { version = "1.39", features = ["yaml"] }
+`,
+			want: map[string]wantDep{
+				"anyhow": {"1.0", "prod"},
+				"tokio":  {"1.37", "prod"},
+				"local":  {"", "prod"},
+				"insta":  {"1.39", "dev"},
+			},
+		},
+		{
+			name: "sub-tables, build and target dependencies",
+			manifest: `[package]
+name = "sys"
+version = "0.1.0"
+
+[dependencies.serde]
+version = "1.0.197"
+features = ["derive"]
+
+[build-dependencies]
+cc = "1.0"
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+
+[target.'cfg(windows)'.dev-dependencies]
+winapi = { version = "0.3", features = ["winuser"] }
+
+[target.'cfg(windows)'.build-dependencies]
+embed-resource = "2.4"
+`,
+			want: map[string]wantDep{
+				"serde":          {"1.0.197", "prod"},
+				"cc":             {"1.0", "prod"},
+				"libc":           {"0.2", "prod"},
+				"winapi":         {"0.3", "dev"},
+				"embed-resource": {"2.4", "prod"},
+			},
+			groups: map[string]string{"cc": "build", "embed-resource": "build"},
+		},
+		{
+			name: "runtime wins over build, build over dev",
+			manifest: `[package]
+name = "both"
+version = "0.1.0"
+
+[dependencies]
+serde = "1.0"
+
+[build-dependencies]
+serde = "1.0"
+cc = "1.0"
+
+[dev-dependencies]
+cc = "1.0"
+`,
+			want: map[string]wantDep{
+				"serde": {"1.0", "prod"},
+				"cc":    {"1.0", "prod"},
+			},
+			groups: map[string]string{"cc": "build"},
+		},
+		{
+			name: "renamed crate and prod wins over dev",
+			manifest: `[package]
+name = "renamed"
+version = "0.1.0"
+
+[dependencies]
+rand_core = { package = "rand", version = "0.8" }
+log = "0.4"
+
+[dev-dependencies]
+log = "0.4.21"
+`,
+			want: map[string]wantDep{
+				"rand": {"0.8", "prod"},
+				"log":  {"0.4", "prod"},
+			},
+		},
+		{
+			// Shared versions only count where a member inherits them
+			name: "virtual workspace root",
+			manifest: `[workspace]
+resolver = "2"
+members = ["crates/*"]
+
+[workspace.dependencies]
+serde = { version = "1.0", features = ["derive"] }
+tokio = "1.37"
+`,
+			want: map[string]wantDep{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, map[string]string{"Cargo.toml": tt.manifest})
+
+			deps, err := ParseCargoToml(dir)
+			if err != nil {
+				t.Fatalf("Failed to parse Cargo.toml: %v", err)
+			}
+
+			if len(deps) != len(tt.want) {
+				t.Errorf("Expected %d dependencies, got %d", len(tt.want), len(deps))
+			}
+			for name, want := range tt.want {
+				dep, ok := deps[name]
+				if !ok {
+					t.Errorf("Expected dependency %q", name)
+					continue
+				}
+				if dep.Version != want.version || dep.DepType != want.depType {
+					t.Errorf("%s: expected %q/%s, got %q/%s", name, want.version, want.depType, dep.Version, dep.DepType)
+				}
+				if dep.Group != tt.groups[name] {
+					t.Errorf("%s: expected group %q, got %q", name, tt.groups[name], dep.Group)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCargoTomlWorkspaceInheritance(t *testing.T) {
+	root := writeProjectFiles(t, map[string]string{
+		"Cargo.toml": `[workspace]
+members = ["crates/cli"]
+
+[workspace.dependencies]
+clap = { version = "4.5", features = ["derive"] }
+`,
+	})
+
+	member := filepath.Join(root, "crates", "cli")
+	if err := os.MkdirAll(member, 0755); err != nil {
+		t.Fatalf("Failed to create member dir: %v", err)
+	}
+	err := os.WriteFile(filepath.Join(member, "Cargo.toml"), []byte(`[package]
+name = "cli"
+version.workspace = true
+
+[dependencies]
+clap = { workspace = true }
+anyhow.workspace = true
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write member Cargo.toml: %v", err)
+	}
+
+	deps, err := ParseCargoToml(member)
+	if err != nil {
+		t.Fatalf("Failed to parse member Cargo.toml: %v", err)
+	}
+
+	if deps["clap"] == nil || deps["clap"].Version != "4.5" {
+		t.Errorf("Expected clap to inherit version 4.5, got %+v", deps["clap"])
+	}
+	if deps["anyhow"] == nil || deps["anyhow"].Version != "" {
+		t.Errorf("Expected anyhow without a workspace version, got %+v", deps["anyhow"])
+	}
+}
+
+func TestParseCargoTomlInvalid(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"Cargo.toml": "[dependencies\nserde = "})
+
+	if _, err := ParseCargoToml(dir); err == nil {
+		t.Error("Expected error for invalid Cargo.toml, got nil")
+	}
+}
+
+func TestParsePyproject(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     map[string]wantDep
+	}{
+		{
+			name: "multi-line dependencies",
+			manifest: `[project]
+name = "api"
+version = "0.1.0"
+requires-python = ">=3.10"
+dependencies = [
+    "fastapi>=0.110",
+    "uvicorn[standard] >= 0.29",
+    "tomli>=2.0; python_version < '3.11'",
+    "httpx",
+]
+`,
+			want: map[string]wantDep{
+				"fastapi": {">=0.110", "prod"},
+				"uvicorn": {">=0.29", "prod"},
+				"tomli":   {">=2.0", "prod"},
+				"httpx":   {"", "prod"},
+			},
+		},
+		{
+			name: "single-line arrays and dev sources",
+			manifest: `[project]
+name = "tool"
+dependencies = ["click>=8", "rich"]
+keywords = ["dev", "cli"]
+
+[project.optional-dependencies]
+dev = ["black==24.3.0"]
+
+[dependency-groups]
+dev = ["pytest>=8", { include-group = "lint" }]
+lint = ["ruff"]
+
+[tool.uv]
+dev-dependencies = ["mypy>=1.9", "click"]
+`,
+			want: map[string]wantDep{
+				"click":  {">=8", "prod"},
+				"rich":   {"", "prod"},
+				"black":  {"==24.3.0", "dev"},
+				"pytest": {">=8", "dev"},
+				"ruff":   {"", "dev"},
+				"mypy":   {">=1.9", "dev"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, map[string]string{"pyproject.toml": tt.manifest})
+
+			deps, err := ParsePyproject(dir)
+			if err != nil {
+				t.Fatalf("Failed to parse pyproject.toml: %v", err)
+			}
+
+			if len(deps) != len(tt.want) {
+				t.Errorf("Expected %d dependencies, got %d", len(tt.want), len(deps))
+			}
+			for name, want := range tt.want {
+				dep, ok := deps[name]
+				if !ok {
+					t.Errorf("Expected dependency %q", name)
+					continue
+				}
+				if dep.Version != want.version || dep.DepType != want.depType {
+					t.Errorf("%s: expected %q/%s, got %q/%s", name, want.version, want.depType, dep.Version, dep.DepType)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		spec    string
+		name    string
+		version string
+		ok      bool
+	}{
+		{"requests", "requests", "", true},
+		{"requests>=2.28,<3", "requests", ">=2.28,<3", true},
+		{"Django (>=4.2)", "Django", ">=4.2", true},
+		{"uvicorn[standard]>=0.29", "uvicorn", ">=0.29", true},
+		{`tomli ; python_version < "3.11"`, "tomli", "", true},
+		{"zope.interface~=6.0", "zope.interface", "~=6.0", true},
+		{"", "", "", false},
+		{"--index-url https://example.com", "", "", false},
+		{"pkg @ https://example.com/pkg-1.0-py3-none-any.whl", "pkg", "", true},
+		{"pkg[extra]@ git+https://github.com/org/pkg@v1.0 ; python_version >= '3.9'", "pkg", "", true},
+		{"git+https://github.com/org/repo@v1.0#egg=repo", "", "", false},
+		{"https://example.com/pkg.whl", "", "", false},
+		{"./vendor/pkg", "", "", false},
+		{"vendor/pkg", "", "", false},
+	}
+
+	for _, tt := range tests {
+		name, version, ok := ParseRequirement(tt.spec)
+		if ok != tt.ok || name != tt.name || version != tt.version {
+			t.Errorf("ParseRequirement(%q) = %q, %q, %v; expected %q, %q, %v",
+				tt.spec, name, version, ok, tt.name, tt.version, tt.ok)
+		}
+	}
+}
+
+// expectGroup checks the type and group of a parsed Python dependency
+func expectGroup(t *testing.T, deps map[string]*db.Dependency, name, version, depType, group string) {
+	t.Helper()
+
+	dep, ok := deps[name]
+	if !ok {
+		t.Errorf("Expected dependency %q", name)
+		return
+	}
+	if dep.Version != version || dep.DepType != depType || dep.Group != group {
+		t.Errorf("%s: expected %q/%s/%s, got %q/%s/%s", name, version, depType, group, dep.Version, dep.DepType, dep.Group)
+	}
+}
+
+func TestParsePyprojectGroups(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"pyproject.toml": `[project]
+name = "svc"
+dependencies = ["httpx>=0.27", "tomli>=2; python_version < '3.11'"]
+
+[project.optional-dependencies]
+socks = ["pysocks>=1.7"]
+test = ["pytest-cov"]
+
+[dependency-groups]
+docs = ["mkdocs>=1.5"]
+dev = [{ include-group = "docs" }, "pytest>=8", "httpx"]
+`})
+
+	deps, err := ParsePyproject(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse pyproject.toml: %v", err)
+	}
+
+	if len(deps) != 6 {
+		t.Errorf("Expected 6 dependencies, got %d", len(deps))
+	}
+	expectGroup(t, deps, "httpx", ">=0.27", "prod", "main")
+	expectGroup(t, deps, "tomli", ">=2", "prod", "main")
+	expectGroup(t, deps, "pysocks", ">=1.7", "prod", "socks")
+	expectGroup(t, deps, "pytest-cov", "", "dev", "test")
+	expectGroup(t, deps, "mkdocs", ">=1.5", "dev", "docs")
+	expectGroup(t, deps, "pytest", ">=8", "dev", "dev")
+}
+
+func TestParsePyprojectPoetry(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"pyproject.toml": `[tool.poetry]
+name = "legacy"
+version = "0.1.0"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+requests = "^2.31"
+fastapi = { version = "^0.110", extras = ["all"] }
+numpy = [
+    { version = "<1.25", python = "<3.9" },
+    { version = "^1.26", python = ">=3.9" },
+]
+mylib = { path = "../mylib", develop = true }
+
+[tool.poetry.group.dev.dependencies]
+pytest = "^8.0"
+requests = "^2.32"
+
+[tool.poetry.group.docs.dependencies]
+sphinx = "^7.2"
+
+[tool.poetry.dev-dependencies]
+black = "^24.3"
+`})
+
+	deps, err := ParsePyproject(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse pyproject.toml: %v", err)
+	}
+
+	if _, ok := deps["python"]; ok {
+		t.Error("Expected the python constraint to be skipped")
+	}
+	if len(deps) != 7 {
+		t.Errorf("Expected 7 dependencies, got %d", len(deps))
+	}
+	expectGroup(t, deps, "requests", "^2.31", "prod", "main")
+	expectGroup(t, deps, "fastapi", "^0.110", "prod", "main")
+	expectGroup(t, deps, "numpy", "<1.25", "prod", "main")
+	expectGroup(t, deps, "mylib", "", "prod", "main")
+	expectGroup(t, deps, "pytest", "^8.0", "dev", "dev")
+	expectGroup(t, deps, "sphinx", "^7.2", "dev", "docs")
+	expectGroup(t, deps, "black", "^24.3", "dev", "dev")
+}
+
+func TestParseRequirementsIncludes(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"requirements.txt": `# Runtime
+-r requirements/base.txt
+--constraint constraints.txt
+Flask>=3.0  # web framework
+tomli ; python_version < "3.11"
+-e .
+--index-url https://pypi.org/simple
+requests \
+    [socks]>=2.31
+`,
+		"constraints.txt": "tomli==2.0.1\nunused==1.0\n",
+		"requirements-dev.txt": `-r requirements.txt
+pytest==8.1.1
+flask==3.0.3
+`,
+	})
+	if err := os.MkdirAll(filepath.Join(dir, "requirements"), 0755); err != nil {
+		t.Fatalf("Failed to create requirements dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "requirements", "base.txt"), []byte("click~=8.1\n-r ../requirements.txt\n"), 0644); err != nil {
+		t.Fatalf("Failed to write base.txt: %v", err)
+	}
+
+	deps, err := ParsePythonDeps(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse Python dependencies: %v", err)
+	}
+
+	if len(deps) != 5 {
+		t.Errorf("Expected 5 dependencies, got %d", len(deps))
+	}
+	expectGroup(t, deps, "click", "~=8.1", "prod", "main")
+	expectGroup(t, deps, "Flask", ">=3.0", "prod", "main")
+	expectGroup(t, deps, "tomli", "==2.0.1", "prod", "main")
+	expectGroup(t, deps, "requests", ">=2.31", "prod", "main")
+	expectGroup(t, deps, "pytest", "==8.1.1", "dev", "dev")
+	if _, ok := deps["unused"]; ok {
+		t.Error("Expected constraint-only packages not to be recorded")
+	}
+}
+
+func TestParseRequirementsDirectReferences(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"requirements.txt": `git+https://github.com/org/repo@v1.0#egg=repo
+https://example.com/archive/tool-2.0.tar.gz
+wheelpkg @ https://example.com/wheelpkg-1.0-py3-none-any.whl
+requests>=2.31
+`})
+
+	deps, err := ParseRequirementsTxt(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse requirements: %v", err)
+	}
+
+	if len(deps) != 2 {
+		t.Errorf("Expected 2 dependencies, got %d", len(deps))
+	}
+	expectGroup(t, deps, "wheelpkg", "", "prod", "main")
+	expectGroup(t, deps, "requests", ">=2.31", "prod", "main")
+	for _, name := range []string{"git", "https"} {
+		if _, ok := deps[name]; ok {
+			t.Errorf("Expected no dependency named %q from a bare URL", name)
+		}
+	}
+}
+
+func TestParseRequirementsMissingInclude(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"requirements.txt": "-r missing.txt\n"})
+
+	if _, err := ParseRequirementsTxt(dir); err == nil {
+		t.Error("Expected error for missing included file, got nil")
+	}
+}
+
+func TestIsDependencyFile(t *testing.T) {
+	tests := map[string]bool{
+		"package.json":               true,
+		"apps/web/package.json":      true,
+		"pnpm-lock.yaml":             true,
+		"services/api/go.sum":        true,
+		"Cargo.lock":                 true,
+		"requirements-dev.txt":       true,
+		"README.md":                  false,
+		"src/package.json.bak":       false,
+		"docs/requirements.txt.orig": false,
+	}
+
+	for path, want := range tests {
+		if got := IsDependencyFile(path); got != want {
+			t.Errorf("IsDependencyFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
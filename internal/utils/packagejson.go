@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,54 +13,248 @@ import (
 	"github.com/genesix/pkt/internal/db"
 )
 
-// PackageJSON represents the structure of package.json
+// PackageJSON is a package.json document. The fields pkt understands are
+// decoded into typed fields; the document itself is kept with its original
+// key order and every unknown field, so writing it back is lossless.
 type PackageJSON struct {
-	Name            string            `json:"name"`
-	Version         string            `json:"version"`
-	Dependencies    map[string]string `json:"dependencies,omitempty"`
-	DevDependencies map[string]string `json:"devDependencies,omitempty"`
-	Scripts         map[string]string `json:"scripts,omitempty"`
+	Name                 string
+	Version              string
+	Type                 string
+	PackageManager       string
+	Dependencies         map[string]string
+	DevDependencies      map[string]string
+	PeerDependencies     map[string]string
+	OptionalDependencies map[string]string
+	BundleDependencies   []string
+	Engines              map[string]string
+	Workspaces           []string
+	Scripts              map[string]string
+
+	path        string
+	doc         *jsonObject
+	indent      string
+	trailingEOL bool
 }
 
-// ParsePackageJSON reads and parses package.json from a project path
-func ParsePackageJSON(projectPath string) (map[string]*db.Dependency, error) {
+// packageJSONFields is the typed view of the fields pkt reads
+type packageJSONFields struct {
+	Name                 string             `json:"name"`
+	Version              string             `json:"version"`
+	Type                 string             `json:"type"`
+	PackageManager       string             `json:"packageManager"`
+	Dependencies         map[string]string  `json:"dependencies"`
+	DevDependencies      map[string]string  `json:"devDependencies"`
+	PeerDependencies     map[string]string  `json:"peerDependencies"`
+	OptionalDependencies map[string]string  `json:"optionalDependencies"`
+	BundleDependencies   bundleDependencies `json:"bundleDependencies"`
+	BundledDependencies  bundleDependencies `json:"bundledDependencies"`
+	Engines              engines            `json:"engines"`
+	Workspaces           workspaces         `json:"workspaces"`
+	Scripts              map[string]string  `json:"scripts"`
+}
+
+// bundleDependencies is a list of package names, or true to bundle every dependency
+type bundleDependencies struct {
+	Names []string
+	All   bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *bundleDependencies) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &b.All); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &b.Names)
+}
+
+// engines maps runtimes to version ranges. Very old packages wrote it as an
+// array of strings, which is ignored.
+type engines map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *engines) UnmarshalJSON(data []byte) error {
+	var ranges map[string]string
+	if err := json.Unmarshal(data, &ranges); err == nil {
+		*e = ranges
+	}
+	return nil
+}
+
+// workspaces is a list of globs, written either as an array or as { "packages": [...] }
+type workspaces []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (w *workspaces) UnmarshalJSON(data []byte) error {
+	var globs []string
+	if err := json.Unmarshal(data, &globs); err == nil {
+		*w = globs
+		return nil
+	}
+
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*w = object.Packages
+	return nil
+}
+
+// ReadPackageJSON reads the package.json of a project
+func ReadPackageJSON(projectPath string) (*PackageJSON, error) {
 	pkgJSONPath := filepath.Join(projectPath, "package.json")
 
-	// Read file
 	data, err := os.ReadFile(pkgJSONPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return make(map[string]*db.Dependency), nil
-		}
 		return nil, fmt.Errorf("failed to read package.json: %w", err)
 	}
 
-	// Parse JSON
-	var pkg PackageJSON
-	if err := json.Unmarshal(data, &pkg); err != nil {
+	pkg := &PackageJSON{
+		path:        pkgJSONPath,
+		doc:         &jsonObject{},
+		indent:      detectIndent(data),
+		trailingEOL: bytes.HasSuffix(data, []byte("\n")),
+	}
+	if err := json.Unmarshal(data, pkg.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	var fields packageJSONFields
+	if err := json.Unmarshal(data, &fields); err != nil {
 		return nil, fmt.Errorf("failed to parse package.json: %w", err)
 	}
 
-	// Convert to dependency map
+	pkg.Name = fields.Name
+	pkg.Version = fields.Version
+	pkg.Type = fields.Type
+	pkg.PackageManager = fields.PackageManager
+	pkg.Dependencies = fields.Dependencies
+	pkg.DevDependencies = fields.DevDependencies
+	pkg.PeerDependencies = fields.PeerDependencies
+	pkg.OptionalDependencies = fields.OptionalDependencies
+	pkg.Engines = fields.Engines
+	pkg.Workspaces = fields.Workspaces
+	pkg.Scripts = fields.Scripts
+
+	// npm accepts both spellings; true bundles every production dependency
+	bundled := fields.BundleDependencies
+	if !bundled.All && len(bundled.Names) == 0 {
+		bundled = fields.BundledDependencies
+	}
+	pkg.BundleDependencies = bundled.Names
+	if bundled.All {
+		pkg.BundleDependencies = sortedKeys(pkg.Dependencies)
+	}
+
+	return pkg, nil
+}
+
+// Save writes the document back to package.json, keeping key order, unknown
+// fields and the original indentation
+func (p *PackageJSON) Save() error {
+	compact, err := p.doc.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal package.json: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact, "", p.indent); err != nil {
+		return fmt.Errorf("failed to marshal package.json: %w", err)
+	}
+	if p.trailingEOL {
+		out.WriteByte('\n')
+	}
+
+	if err := os.WriteFile(p.path, out.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write package.json: %w", err)
+	}
+	return nil
+}
+
+// TrackedDependencies converts the dependency sections to tracked dependencies.
+// A package listed in several sections is recorded once: dependencies win over
+// optionalDependencies, which win over peerDependencies and then devDependencies.
+// Peer, optional and bundled dependencies are marked with their group.
+func (p *PackageJSON) TrackedDependencies() map[string]*db.Dependency {
 	deps := make(map[string]*db.Dependency)
 
-	for name, version := range pkg.Dependencies {
-		deps[name] = &db.Dependency{
-			Name:    name,
-			Version: version,
-			DepType: "prod",
+	sections := []struct {
+		deps    map[string]string
+		depType string
+		group   string
+	}{
+		{p.DevDependencies, "dev", ""},
+		{p.PeerDependencies, "prod", "peer"},
+		{p.OptionalDependencies, "prod", "optional"},
+		{p.Dependencies, "prod", ""},
+	}
+	for _, section := range sections {
+		for name, version := range section.deps {
+			deps[name] = &db.Dependency{
+				Name:    name,
+				Version: version,
+				DepType: section.depType,
+				Group:   section.group,
+			}
 		}
 	}
 
-	for name, version := range pkg.DevDependencies {
-		deps[name] = &db.Dependency{
-			Name:    name,
-			Version: version,
-			DepType: "dev",
+	for _, name := range p.BundleDependencies {
+		if dep, ok := deps[name]; ok {
+			dep.Group = "bundled"
+			continue
+		}
+		deps[name] = &db.Dependency{Name: name, DepType: "prod", Group: "bundled"}
+	}
+
+	return deps
+}
+
+// ParsePackageJSON reads and parses package.json from a project path
+func ParsePackageJSON(projectPath string) (map[string]*db.Dependency, error) {
+	pkg, err := ReadPackageJSON(projectPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return make(map[string]*db.Dependency), nil
 		}
+		return nil, err
 	}
 
-	return deps, nil
+	return pkg.TrackedDependencies(), nil
+}
+
+// ParsePackageJSONDirectives returns the engines and packageManager fields of package.json
+func ParsePackageJSONDirectives(projectPath string) ([]*db.Directive, error) {
+	pkg, err := ReadPackageJSON(projectPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var directives []*db.Directive
+	for _, engine := range sortedKeys(pkg.Engines) {
+		directives = append(directives, &db.Directive{
+			Kind:    db.DirectiveEngine,
+			Path:    engine,
+			Version: pkg.Engines[engine],
+		})
+	}
+
+	// Corepack format: "pnpm@9.1.0" or "pnpm@9.1.0+sha512.…"
+	if pkg.PackageManager != "" {
+		name, version, _ := strings.Cut(pkg.PackageManager, "@")
+		version, _, _ = strings.Cut(version, "+")
+		directives = append(directives, &db.Directive{
+			Kind:    db.DirectivePackageManager,
+			Path:    name,
+			Version: version,
+		})
+	}
+
+	return directives, nil
 }
 
 // CreatePackageJSON creates a minimal package.json file
@@ -70,39 +267,28 @@ func CreatePackageJSON(projectPath, projectName string) error {
 	}
 
 	// Create minimal package.json
-	pkg := PackageJSON{
-		Name:    projectName,
-		Version: "1.0.0",
+	pkg := &PackageJSON{
+		path:        pkgJSONPath,
+		doc:         &jsonObject{},
+		indent:      "  ",
+		trailingEOL: true,
 	}
-
-	// Marshal to JSON
-	data, err := json.MarshalIndent(pkg, "", "  ")
-	if err != nil {
+	if err := pkg.doc.Set("name", projectName); err != nil {
 		return fmt.Errorf("failed to marshal package.json: %w", err)
 	}
-
-	// Write to file
-	if err := os.WriteFile(pkgJSONPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write package.json: %w", err)
+	if err := pkg.doc.Set("version", "1.0.0"); err != nil {
+		return fmt.Errorf("failed to marshal package.json: %w", err)
 	}
 
-	return nil
+	return pkg.Save()
 }
 
-// RewriteScripts updates package.json scripts to use the correct package manager
+// RewriteScripts updates package.json scripts to use the correct package manager.
+// Only the script commands change; the rest of the file is written back untouched.
 func RewriteScripts(projectPath, pm string) error {
-	pkgJSONPath := filepath.Join(projectPath, "package.json")
-
-	// Read existing package.json
-	data, err := os.ReadFile(pkgJSONPath)
+	pkg, err := ReadPackageJSON(projectPath)
 	if err != nil {
-		return fmt.Errorf("failed to read package.json: %w", err)
-	}
-
-	// Parse JSON
-	var pkg PackageJSON
-	if err := json.Unmarshal(data, &pkg); err != nil {
-		return fmt.Errorf("failed to parse package.json: %w", err)
+		return err
 	}
 
 	// If no scripts, nothing to rewrite
@@ -110,11 +296,20 @@ func RewriteScripts(projectPath, pm string) error {
 		return nil
 	}
 
+	var scripts jsonObject
+	if _, err := pkg.doc.Get("scripts", &scripts); err != nil {
+		return fmt.Errorf("failed to parse package.json scripts: %w", err)
+	}
+
 	// Map of package managers to replace
 	pms := []string{"npm", "pnpm", "bun", "yarn"}
 
 	// Rewrite scripts
-	for key, script := range pkg.Scripts {
+	for _, key := range scripts.keys {
+		script, ok := pkg.Scripts[key]
+		if !ok {
+			continue
+		}
 		for _, oldPM := range pms {
 			if oldPM != pm {
 				script = strings.ReplaceAll(script, oldPM+" ", pm+" ")
@@ -122,18 +317,127 @@ func RewriteScripts(projectPath, pm string) error {
 			}
 		}
 		pkg.Scripts[key] = script
+		if err := scripts.Set(key, script); err != nil {
+			return fmt.Errorf("failed to marshal package.json: %w", err)
+		}
 	}
 
-	// Marshal back to JSON
-	data, err = json.MarshalIndent(pkg, "", "  ")
-	if err != nil {
+	if err := pkg.doc.Set("scripts", &scripts); err != nil {
 		return fmt.Errorf("failed to marshal package.json: %w", err)
 	}
 
-	// Write back to file
-	if err := os.WriteFile(pkgJSONPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write package.json: %w", err)
+	return pkg.Save()
+}
+
+// detectIndent returns the indentation of the first indented line, defaulting to two spaces
+func detectIndent(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n")[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
 	}
+	return "  "
+}
 
+// jsonObject is a JSON object that remembers its key order and keeps each
+// value as raw JSON, so values pkt does not understand survive a rewrite
+type jsonObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (o *jsonObject) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object")
+	}
+
+	o.keys = nil
+	o.values = make(map[string]json.RawMessage)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		if _, exists := o.values[key]; !exists {
+			o.keys = append(o.keys, key)
+		}
+		o.values[key] = value
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// MarshalJSON implements json.Marshaler
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := marshalNoEscape(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		if err := json.Compact(&buf, o.values[key]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Get decodes the value of key into v, reporting whether the key exists
+func (o *jsonObject) Get(key string, v any) (bool, error) {
+	raw, ok := o.values[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// Set stores a value under key, keeping the key's position or appending it when new
+func (o *jsonObject) Set(key string, v any) error {
+	raw, err := marshalNoEscape(v)
+	if err != nil {
+		return err
+	}
+
+	if o.values == nil {
+		o.values = make(map[string]json.RawMessage)
+	}
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = raw
 	return nil
 }
+
+// marshalNoEscape marshals v without escaping <, > and & like npm does
+func marshalNoEscape(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -3,7 +3,10 @@ package utils
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/genesix/pkt/internal/db"
 )
 
 func TestParsePackageJSON(t *testing.T) {
@@ -252,3 +255,131 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRewriteScriptsLossless(t *testing.T) {
+	original := `{
+	"name": "mono",
+	"private": true,
+	"type": "module",
+	"packageManager": "pnpm@9.1.0",
+	"workspaces": [
+		"packages/*"
+	],
+	"scripts": {
+		"dev": "npm run build && node server.js",
+		"build": "tsc -p . > build.log"
+	},
+	"engines": {
+		"node": ">=18"
+	},
+	"browserslist": [
+		"> 0.5%",
+		"not dead"
+	],
+	"custom": {
+		"nested": {
+			"z": 1,
+			"a": 2.50
+		}
+	}
+}
+`
+	dir := writeProjectFiles(t, map[string]string{"package.json": original})
+
+	if err := RewriteScripts(dir, "pnpm"); err != nil {
+		t.Fatalf("Failed to rewrite scripts: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		t.Fatalf("Failed to read package.json: %v", err)
+	}
+
+	expected := strings.Replace(original, "npm run build", "pnpm run build", 1)
+	if string(content) != expected {
+		t.Errorf("Expected only the script to change, got:\n%s", content)
+	}
+}
+
+func TestReadPackageJSONFields(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"package.json": `{
+  "name": "lib",
+  "type": "module",
+  "packageManager": "pnpm@9.1.0+sha512.abc",
+  "workspaces": {"packages": ["apps/*", "packages/*"]},
+  "engines": {"node": ">=18", "pnpm": ">=9"},
+  "dependencies": {"react-dom": "^18.2.0", "tslib": "^2.6.0"},
+  "devDependencies": {"react": "^18.2.0", "vitest": "^1.6.0"},
+  "peerDependencies": {"react": ">=17"},
+  "optionalDependencies": {"fsevents": "^2.3.3"},
+  "bundledDependencies": ["tslib"]
+}`})
+
+	pkg, err := ReadPackageJSON(dir)
+	if err != nil {
+		t.Fatalf("Failed to read package.json: %v", err)
+	}
+	if pkg.Type != "module" || pkg.PackageManager != "pnpm@9.1.0+sha512.abc" {
+		t.Errorf("Unexpected type/packageManager: %q, %q", pkg.Type, pkg.PackageManager)
+	}
+	if len(pkg.Workspaces) != 2 || pkg.Workspaces[0] != "apps/*" {
+		t.Errorf("Unexpected workspaces: %v", pkg.Workspaces)
+	}
+
+	deps := pkg.TrackedDependencies()
+	tests := []struct {
+		name    string
+		version string
+		depType string
+		group   string
+	}{
+		{"react-dom", "^18.2.0", "prod", ""},
+		{"tslib", "^2.6.0", "prod", "bundled"},
+		{"vitest", "^1.6.0", "dev", ""},
+		{"react", ">=17", "prod", "peer"},
+		{"fsevents", "^2.3.3", "prod", "optional"},
+	}
+	if len(deps) != len(tests) {
+		t.Errorf("Expected %d dependencies, got %d", len(tests), len(deps))
+	}
+	for _, tt := range tests {
+		dep := deps[tt.name]
+		if dep == nil {
+			t.Errorf("Expected dependency %q", tt.name)
+			continue
+		}
+		if dep.Version != tt.version || dep.DepType != tt.depType || dep.Group != tt.group {
+			t.Errorf("%s: expected %q/%s/%q, got %q/%s/%q", tt.name, tt.version, tt.depType, tt.group, dep.Version, dep.DepType, dep.Group)
+		}
+	}
+
+	directives, err := ParsePackageJSONDirectives(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse directives: %v", err)
+	}
+	if len(directives) != 3 {
+		t.Fatalf("Expected 3 directives, got %d", len(directives))
+	}
+	if directives[0].Kind != db.DirectiveEngine || directives[0].Path != "node" || directives[0].Version != ">=18" {
+		t.Errorf("Unexpected engine directive: %+v", directives[0])
+	}
+	if directives[2].Kind != db.DirectivePackageManager || directives[2].Path != "pnpm" || directives[2].Version != "9.1.0" {
+		t.Errorf("Unexpected packageManager directive: %+v", directives[2])
+	}
+}
+
+func TestReadPackageJSONBundleAll(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"package.json": `{
+  "dependencies": {"a": "1.0.0", "b": "2.0.0"},
+  "bundleDependencies": true,
+  "engines": ["node >=0.8"]
+}`})
+
+	deps, err := ParsePackageJSON(dir)
+	if err != nil {
+		t.Fatalf("Failed to parse package.json: %v", err)
+	}
+	if deps["a"].Group != "bundled" || deps["b"].Group != "bundled" {
+		t.Errorf("Expected every dependency to be bundled, got %+v, %+v", deps["a"], deps["b"])
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DefaultWorkers returns the worker count used when none is configured
+func DefaultWorkers() int {
+	return runtime.NumCPU()
+}
+
+// ParallelMap calls fn for every item using at most workers goroutines and
+// returns the results in input order. A worker count <= 0 uses DefaultWorkers.
+func ParallelMap[T, R any](items []T, workers int, fn func(T) R) []R {
+	results := make([]R, len(items))
+	if len(items) == 0 {
+		return results
+	}
+
+	if workers <= 0 {
+		workers = DefaultWorkers()
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fn(items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
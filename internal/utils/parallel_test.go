@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMapPreservesOrder(t *testing.T) {
+	items := []int{5, 1, 4, 2, 3}
+
+	results := ParallelMap(items, 3, func(n int) int {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * 10
+	})
+
+	for i, n := range items {
+		if results[i] != n*10 {
+			t.Errorf("Result %d: expected %d, got %d", i, n*10, results[i])
+		}
+	}
+}
+
+func TestParallelMapBoundsWorkers(t *testing.T) {
+	items := make([]int, 20)
+
+	var running, peak int32
+	ParallelMap(items, 4, func(int) struct{} {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return struct{}{}
+	})
+
+	if peak > 4 {
+		t.Errorf("Expected at most 4 concurrent workers, saw %d", peak)
+	}
+	if peak < 2 {
+		t.Errorf("Expected work to run concurrently, saw peak of %d", peak)
+	}
+}
+
+func TestParallelMapEmpty(t *testing.T) {
+	results := ParallelMap([]string{}, 0, func(s string) string { return s })
+	if len(results) != 0 {
+		t.Errorf("Expected no results, got %d", len(results))
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// NonInteractiveEnv turns prompts off like --yes/--non-interactive when set to a true value
+const NonInteractiveEnv = "PKT_NONINTERACTIVE"
+
+// ErrNonInteractive is returned by prompts that need an answer in non-interactive mode
+var ErrNonInteractive = errors.New("cannot prompt in non-interactive mode")
+
+// nonInteractive is set by the --yes/--non-interactive flag
+var nonInteractive bool
+
+// SetNonInteractive turns prompts off for this run
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// NonInteractive reports whether prompts are off, by flag or by PKT_NONINTERACTIVE.
+// Confirmations are then accepted and every other prompt fails fast.
+func NonInteractive() bool {
+	if nonInteractive {
+		return true
+	}
+	enabled, err := strconv.ParseBool(os.Getenv(NonInteractiveEnv))
+	return err == nil && enabled
+}
+
+// Confirm asks a yes/no question; in non-interactive mode the answer is yes
+func Confirm(message string, defaultValue bool) (bool, error) {
+	if NonInteractive() {
+		return true, nil
+	}
+
+	var confirmed bool
+	prompt := &survey.Confirm{Message: message, Default: defaultValue}
+	if err := survey.AskOne(prompt, &confirmed); err != nil {
+		return false, fmt.Errorf("cancelled: %w", err)
+	}
+	return confirmed, nil
+}
+
+// Select asks to pick one of options; defaultValue may be empty
+func Select(message string, options []string, defaultValue string) (string, error) {
+	if NonInteractive() {
+		return "", fmt.Errorf("%w: %s", ErrNonInteractive, message)
+	}
+
+	var selected string
+	prompt := &survey.Select{Message: message, Options: options, PageSize: 15}
+	if defaultValue != "" {
+		prompt.Default = defaultValue
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return "", fmt.Errorf("cancelled: %w", err)
+	}
+	return selected, nil
+}
+
+// MultiSelect asks to pick any of options and returns the indexes picked; defaults are preselected
+func MultiSelect(message string, options, defaults []string) ([]int, error) {
+	if NonInteractive() {
+		return nil, fmt.Errorf("%w: %s", ErrNonInteractive, message)
+	}
+
+	var picked []int
+	prompt := &survey.MultiSelect{Message: message, Options: options, Default: defaults, PageSize: 15}
+	if err := survey.AskOne(prompt, &picked); err != nil {
+		return nil, fmt.Errorf("cancelled: %w", err)
+	}
+	return picked, nil
+}
+
+// Input asks for a line of text; defaultValue may be empty
+func Input(message, defaultValue string) (string, error) {
+	if NonInteractive() {
+		return "", fmt.Errorf("%w: %s", ErrNonInteractive, message)
+	}
+
+	var answer string
+	prompt := &survey.Input{Message: message, Default: defaultValue}
+	if err := survey.AskOne(prompt, &answer); err != nil {
+		return "", fmt.Errorf("cancelled: %w", err)
+	}
+	return answer, nil
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+func TestNonInteractive(t *testing.T) {
+	t.Cleanup(func() { SetNonInteractive(false) })
+
+	tests := []struct {
+		env      string
+		flag     bool
+		expected bool
+	}{
+		{"", false, false},
+		{"", true, true},
+		{"1", false, true},
+		{"true", false, true},
+		{"false", false, false},
+		{"0", true, true},
+		{"maybe", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Setenv(NonInteractiveEnv, tt.env)
+		SetNonInteractive(tt.flag)
+		if got := NonInteractive(); got != tt.expected {
+			t.Errorf("NonInteractive() with %s=%q and flag %v = %v, want %v", NonInteractiveEnv, tt.env, tt.flag, got, tt.expected)
+		}
+	}
+}
+
+func TestPromptsNonInteractive(t *testing.T) {
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+
+	confirmed, err := Confirm("Delete?", false)
+	if err != nil || !confirmed {
+		t.Errorf("Expected confirmations to be accepted, got %v, %v", confirmed, err)
+	}
+
+	if _, err := Select("Pick one:", []string{"a", "b"}, "a"); !errors.Is(err, ErrNonInteractive) {
+		t.Errorf("Expected Select to fail with ErrNonInteractive, got %v", err)
+	}
+	if _, err := MultiSelect("Pick some:", []string{"a", "b"}, nil); !errors.Is(err, ErrNonInteractive) {
+		t.Errorf("Expected MultiSelect to fail with ErrNonInteractive, got %v", err)
+	}
+	if _, err := Input("Name:", "x"); !errors.Is(err, ErrNonInteractive) {
+		t.Errorf("Expected Input to fail with ErrNonInteractive, got %v", err)
+	}
+}
+
+func TestSelectProjectNonInteractive(t *testing.T) {
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+
+	projects := []*db.Project{
+		{ID: "01HZY0000000000000000000A1", Name: "api", Path: "/work/api"},
+		{ID: "01HZY0000000000000000000B2", Name: "api", Path: "/oss/api"},
+	}
+
+	_, err := selectProject(projects)
+	if err == nil {
+		t.Fatal("Expected an error listing the candidates")
+	}
+	for _, want := range []string{"01HZY0000000000000000000A1", "01HZY0000000000000000000B2", "/oss/api"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %s, got: %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/genesix/pkt/internal/db"
+)
+
+// pythonMainGroup is the group of runtime dependencies
+const pythonMainGroup = "main"
+
+// pythonDevGroups are extra and group names treated as development-only
+var pythonDevGroups = map[string]bool{
+	"dev":         true,
+	"develop":     true,
+	"development": true,
+	"test":        true,
+	"tests":       true,
+	"testing":     true,
+	"lint":        true,
+	"linting":     true,
+	"docs":        true,
+	"doc":         true,
+	"typing":      true,
+	"types":       true,
+	"format":      true,
+	"ci":          true,
+}
+
+// pythonDeps collects Python dependencies from several sources. Packages are
+// matched by normalized name, so a package declared in several places is recorded
+// once: runtime declarations win over development ones, otherwise the first wins.
+type pythonDeps struct {
+	deps  map[string]*db.Dependency
+	index map[string]*db.Dependency
+}
+
+// newPythonDeps creates an empty collector
+func newPythonDeps() *pythonDeps {
+	return &pythonDeps{
+		deps:  make(map[string]*db.Dependency),
+		index: make(map[string]*db.Dependency),
+	}
+}
+
+// add records a dependency in a group
+func (p *pythonDeps) add(name, version, group, depType string) {
+	key := NormalizePythonName(name)
+	if existing, ok := p.index[key]; ok {
+		if existing.DepType == "prod" || depType == "dev" {
+			return
+		}
+		delete(p.deps, existing.Name)
+	}
+
+	dep := &db.Dependency{
+		Name:    name,
+		Version: version,
+		DepType: depType,
+		Group:   group,
+	}
+	p.deps[name] = dep
+	p.index[key] = dep
+}
+
+// addRequirement records a PEP 508 requirement string in a group
+func (p *pythonDeps) addRequirement(spec, group, depType string) {
+	if name, version, ok := ParseRequirement(spec); ok {
+		p.add(name, version, group, depType)
+	}
+}
+
+// groupDepType returns the dependency type for an optional extra or group name
+func groupDepType(group string) string {
+	if pythonDevGroups[strings.ToLower(group)] {
+		return "dev"
+	}
+	return "prod"
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// poetryDependency is a Poetry dependency, written as a version string, a table
+// such as { version = "^1", extras = [...] }, or a list of tables with markers
+type poetryDependency struct {
+	Version string
+}
+
+// UnmarshalTOML implements toml.Unmarshaler
+func (d *poetryDependency) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case string:
+		d.Version = v
+	case map[string]any:
+		d.Version, _ = v["version"].(string)
+	case []any:
+		// Multiple constraints for different environments; keep the first version
+		for _, item := range v {
+			if table, ok := item.(map[string]any); ok {
+				if version, ok := table["version"].(string); ok {
+					d.Version = version
+					break
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected dependency value: %v", value)
+	}
+	return nil
+}
+
+// pyprojectManifest is the subset of pyproject.toml pkt reads
+type pyprojectManifest struct {
+	Project struct {
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// PEP 735 groups; entries are requirement strings or {include-group = "..."} tables
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		UV struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+		} `toml:"uv"`
+		Poetry struct {
+			Dependencies    map[string]poetryDependency `toml:"dependencies"`
+			DevDependencies map[string]poetryDependency `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]poetryDependency `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+var requirementName = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?`)
+
+// ParseRequirement splits a PEP 508 requirement such as
+// `requests[socks] >= 2.28 ; python_version < "3.11"` into name and version specifier.
+// Environment markers and extras are dropped. Direct references such as
+// `pkg @ https://example.com/pkg.whl` have no version; bare URLs and paths, which
+// have no name, are not requirements.
+func ParseRequirement(spec string) (name, version string, ok bool) {
+	// The marker separator needs surrounding whitespace inside a URL
+	if strings.Contains(spec, "@") {
+		if i := strings.Index(spec, " ;"); i >= 0 {
+			spec = spec[:i]
+		}
+	} else {
+		spec, _, _ = strings.Cut(spec, ";")
+	}
+	spec = strings.TrimSpace(spec)
+
+	matches := requirementName.FindStringSubmatch(spec)
+	if matches == nil {
+		return "", "", false
+	}
+
+	version = strings.TrimSpace(spec[len(matches[0]):])
+	switch {
+	case version == "":
+	case version[0] == '@':
+		// Direct reference: the URL is not a version
+		return matches[1], "", true
+	case !strings.ContainsRune("<>=!~(", rune(version[0])):
+		// Not a name followed by a specifier, e.g. git+https://... or ./local/pkg
+		return "", "", false
+	}
+	version = strings.TrimSuffix(strings.TrimPrefix(version, "("), ")")
+	version = strings.Join(strings.Fields(version), "")
+	return matches[1], version, true
+}
+
+// collectPyproject adds the dependencies declared in pyproject.toml: PEP 621
+// dependencies and extras, PEP 735 groups, uv dev-dependencies and Poetry groups
+func collectPyproject(pyprojectPath string, deps *pythonDeps) error {
+	var manifest pyprojectManifest
+	if _, err := toml.DecodeFile(pyprojectPath, &manifest); err != nil {
+		return fmt.Errorf("failed to parse pyproject.toml: %w", err)
+	}
+
+	// Runtime dependencies
+	for _, spec := range manifest.Project.Dependencies {
+		deps.addRequirement(spec, pythonMainGroup, "prod")
+	}
+	for _, name := range sortedKeys(manifest.Tool.Poetry.Dependencies) {
+		// Poetry lists the interpreter constraint alongside the packages
+		if name == "python" {
+			continue
+		}
+		deps.add(name, manifest.Tool.Poetry.Dependencies[name].Version, pythonMainGroup, "prod")
+	}
+
+	// Optional extras are runtime dependencies unless named like a dev group
+	for _, extra := range sortedKeys(manifest.Project.OptionalDependencies) {
+		for _, spec := range manifest.Project.OptionalDependencies[extra] {
+			deps.addRequirement(spec, extra, groupDepType(extra))
+		}
+	}
+
+	// PEP 735 groups are never installed with the package. Included groups are
+	// recorded under their own name when that group is visited.
+	for _, group := range sortedKeys(manifest.DependencyGroups) {
+		for _, entry := range manifest.DependencyGroups[group] {
+			if spec, ok := entry.(string); ok {
+				deps.addRequirement(spec, group, "dev")
+			}
+		}
+	}
+
+	for _, spec := range manifest.Tool.UV.DevDependencies {
+		deps.addRequirement(spec, "dev", "dev")
+	}
+
+	// Poetry groups; "main" is an alias of [tool.poetry.dependencies]
+	for _, group := range sortedKeys(manifest.Tool.Poetry.Group) {
+		depType := "dev"
+		if group == pythonMainGroup {
+			depType = "prod"
+		}
+		groupDeps := manifest.Tool.Poetry.Group[group].Dependencies
+		for _, name := range sortedKeys(groupDeps) {
+			deps.add(name, groupDeps[name].Version, group, depType)
+		}
+	}
+	for _, name := range sortedKeys(manifest.Tool.Poetry.DevDependencies) {
+		deps.add(name, manifest.Tool.Poetry.DevDependencies[name].Version, "dev", "dev")
+	}
+
+	return nil
+}
+
+// ParsePyproject parses Python pyproject.toml: PEP 621 dependencies and extras,
+// PEP 735 dependency groups, uv dev-dependencies and Poetry dependency groups
+func ParsePyproject(projectPath string) (map[string]*db.Dependency, error) {
+	pyprojectPath := filepath.Join(projectPath, "pyproject.toml")
+	deps := newPythonDeps()
+
+	if _, err := os.Stat(pyprojectPath); err != nil {
+		if os.IsNotExist(err) {
+			return deps.deps, nil
+		}
+		return nil, err
+	}
+
+	if err := collectPyproject(pyprojectPath, deps); err != nil {
+		return nil, err
+	}
+	return deps.deps, nil
+}
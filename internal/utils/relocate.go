@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// RelocationCandidate is a directory that may be the new location of a moved project
+type RelocationCandidate struct {
+	Path        string
+	MatchRemote bool // The origin remote matches, not just the folder name
+}
+
+// FindRelocationCandidates searches the given roots, up to maxDepth levels deep, for
+// directories named like one of names or whose origin remote matches remote. Missing
+// roots are ignored, and dependency, build and hidden folders are skipped. Remote
+// matches are listed first, then name matches, each sorted by path.
+func FindRelocationCandidates(roots, names []string, remote string, maxDepth int) ([]RelocationCandidate, error) {
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		if name != "" {
+			wanted[name] = true
+		}
+	}
+	remote = NormalizeRemote(remote)
+
+	found := make(map[string]*RelocationCandidate)
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if p != root && d != nil && d.IsDir() {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if !d.IsDir() || p == root {
+				return nil
+			}
+			if workspaceSkipDirs[d.Name()] || d.Name() == "__pycache__" || strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			matchRemote := remote != "" && IsGitRepo(p) && NormalizeRemote(GitRemote(p)) == remote
+			if matchRemote || wanted[d.Name()] {
+				if existing, ok := found[p]; ok {
+					existing.MatchRemote = existing.MatchRemote || matchRemote
+				} else {
+					found[p] = &RelocationCandidate{Path: p, MatchRemote: matchRemote}
+				}
+			}
+
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			if maxDepth > 0 && strings.Count(filepath.ToSlash(rel), "/")+1 >= maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := make([]RelocationCandidate, 0, len(found))
+	for _, candidate := range found {
+		candidates = append(candidates, *candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].MatchRemote != candidates[j].MatchRemote {
+			return candidates[i].MatchRemote
+		}
+		return candidates[i].Path < candidates[j].Path
+	})
+
+	return candidates, nil
+}
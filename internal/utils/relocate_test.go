@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindRelocationCandidates(t *testing.T) {
+	root := writeProjectFiles(t, map[string]string{
+		"archive/api/go.mod":             "module example.com/api\n",
+		"clients/api/package.json":       `{}`,
+		"clients/web/node_modules/api/x": "",
+		"deep/a/b/c/api/go.mod":          "module example.com/api\n",
+		"renamed-service/go.mod":         "module example.com/service\n",
+		"unrelated/README.md":            "",
+	})
+
+	candidates, err := FindRelocationCandidates([]string{root, filepath.Join(root, "missing")}, []string{"api"}, "", 3)
+	if err != nil {
+		t.Fatalf("Failed to search candidates: %v", err)
+	}
+
+	want := []string{"archive/api", "clients/api"}
+	if len(candidates) != len(want) {
+		t.Fatalf("Expected candidates %v, got %+v", want, candidates)
+	}
+	for i, candidate := range candidates {
+		rel, _ := filepath.Rel(root, candidate.Path)
+		if filepath.ToSlash(rel) != want[i] || candidate.MatchRemote {
+			t.Errorf("Expected name match %s, got %+v", want[i], candidate)
+		}
+	}
+
+	// A renamed folder is found by its origin remote
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := filepath.Join(root, "renamed-service")
+	for _, args := range [][]string{
+		{"init", "-q", dir},
+		{"-C", dir, "remote", "add", "origin", "git@github.com:acme/service.git"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	candidates, err = FindRelocationCandidates([]string{root}, []string{"service"}, "https://github.com/acme/service", 3)
+	if err != nil {
+		t.Fatalf("Failed to search candidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Path != dir || !candidates[0].MatchRemote {
+		t.Errorf("Expected remote match %s, got %+v", dir, candidates)
+	}
+}
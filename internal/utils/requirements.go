@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+// requirementsParser reads pip requirements files, following -r includes and
+// remembering -c constraints
+type requirementsParser struct {
+	deps        *pythonDeps
+	constraints map[string]string // normalized name -> version specifier
+	visited     map[string]bool
+}
+
+// newRequirementsParser creates a parser that records into deps
+func newRequirementsParser(deps *pythonDeps) *requirementsParser {
+	return &requirementsParser{
+		deps:        deps,
+		constraints: make(map[string]string),
+		visited:     make(map[string]bool),
+	}
+}
+
+// readRequirementLines returns the logical lines of a requirements file, with
+// comments removed and backslash continuations joined
+func readRequirementLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var lines []string
+	var current strings.Builder
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Comments start a line or follow whitespace
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			line = ""
+		} else if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		line = strings.TrimSpace(line)
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\"))
+			current.WriteString(" ")
+			continue
+		}
+
+		current.WriteString(line)
+		if logical := strings.TrimSpace(current.String()); logical != "" {
+			lines = append(lines, logical)
+		}
+		current.Reset()
+	}
+	if logical := strings.TrimSpace(current.String()); logical != "" {
+		lines = append(lines, logical)
+	}
+
+	return lines, scanner.Err()
+}
+
+// optionValue returns the argument of an option written as "-r file",
+// "--requirement file" or "--requirement=file"
+func optionValue(line string, names ...string) (string, bool) {
+	for _, name := range names {
+		if rest, ok := strings.CutPrefix(line, name+"="); ok {
+			return strings.TrimSpace(rest), true
+		}
+		if rest, ok := strings.CutPrefix(line, name); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return "", false
+}
+
+// parseFile records the requirements of a file under a group.
+// Constraint files only contribute versions, never new packages.
+func (r *requirementsParser) parseFile(path, group, depType string, constraintsOnly bool) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if r.visited[absPath] {
+		return nil
+	}
+	r.visited[absPath] = true
+
+	lines, err := readRequirementLines(path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	for _, line := range lines {
+		if include, ok := optionValue(line, "-r", "--requirement"); ok {
+			if err := r.parseFile(filepath.Join(dir, include), group, depType, constraintsOnly); err != nil {
+				return fmt.Errorf("failed to read %s: %w", include, err)
+			}
+			continue
+		}
+		if include, ok := optionValue(line, "-c", "--constraint"); ok {
+			if err := r.parseFile(filepath.Join(dir, include), group, depType, true); err != nil {
+				return fmt.Errorf("failed to read %s: %w", include, err)
+			}
+			continue
+		}
+
+		// Skip other options (-e, --index-url, ...)
+		if strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		// Bare URLs and paths, e.g. git+https://...#egg=name, have no PEP 508 name
+		name, version, ok := ParseRequirement(line)
+		if !ok {
+			continue
+		}
+		if constraintsOnly {
+			r.constraints[NormalizePythonName(name)] = version
+			continue
+		}
+		r.deps.add(name, version, group, depType)
+	}
+
+	return nil
+}
+
+// applyConstraints fills in the version of unpinned requirements from -c constraint files
+func (r *requirementsParser) applyConstraints() {
+	for key, dep := range r.deps.index {
+		if dep.Version == "" {
+			dep.Version = r.constraints[key]
+		}
+	}
+}
+
+// collectRequirements adds a requirements file, if present, under a group
+func collectRequirements(path, group, depType string, deps *pythonDeps) error {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	parser := newRequirementsParser(deps)
+	if err := parser.parseFile(path, group, depType, false); err != nil {
+		return err
+	}
+	parser.applyConstraints()
+	return nil
+}
+
+// ParseRequirementsTxt parses Python requirements.txt, following -r and -c includes
+func ParseRequirementsTxt(projectPath string) (map[string]*db.Dependency, error) {
+	deps := newPythonDeps()
+	if err := collectRequirements(filepath.Join(projectPath, "requirements.txt"), pythonMainGroup, "prod", deps); err != nil {
+		return nil, err
+	}
+	return deps.deps, nil
+}
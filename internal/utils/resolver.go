@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/genesix/pkt/internal/db"
 	"github.com/oklog/ulid/v2"
 )
@@ -48,20 +47,20 @@ func ResolveProject(input string) (*db.Project, error) {
 	return selectProject(projects)
 }
 
-// selectProject prompts the user to select from multiple projects
+// selectProject prompts the user to select from multiple projects. In non-interactive
+// mode it fails, listing the candidate IDs.
 func selectProject(projects []*db.Project) (*db.Project, error) {
 	options := make([]string, len(projects))
 	for i, p := range projects {
 		options[i] = fmt.Sprintf("%s (%s) - %s", p.Name, p.ID, p.Path)
 	}
 
-	var selected string
-	prompt := &survey.Select{
-		Message: "Multiple projects found. Select one:",
-		Options: options,
+	if NonInteractive() {
+		return nil, fmt.Errorf("%d projects are named %s, use an ID instead:\n  %s", len(projects), projects[0].Name, strings.Join(options, "\n  "))
 	}
 
-	if err := survey.AskOne(prompt, &selected); err != nil {
+	selected, err := Select("Multiple projects found. Select one:", options, "")
+	if err != nil {
 		return nil, fmt.Errorf("project selection cancelled: %w", err)
 	}
 
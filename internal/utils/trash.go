@@ -0,0 +1,267 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+const (
+	// trashSnapshotFile holds an entry's metadata and database snapshot
+	trashSnapshotFile = "snapshot.json"
+	// trashFilesDir holds the project folder inside an entry
+	trashFilesDir = "files"
+)
+
+// TrashEntry is a deleted project kept in ~/.pkt/trash until it is restored or purged
+type TrashEntry struct {
+	ID        string              `json:"id"`
+	DeletedAt time.Time           `json:"deleted_at"`
+	HasFiles  bool                `json:"has_files"` // False when the project folder was already gone
+	Snapshot  *db.ProjectSnapshot `json:"snapshot"`
+}
+
+// Project returns the deleted project
+func (e *TrashEntry) Project() *db.Project {
+	return e.Snapshot.Project
+}
+
+// Dir returns the folder of the entry inside the trash
+func (e *TrashEntry) Dir() (string, error) {
+	trash, err := TrashDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(trash, e.ID), nil
+}
+
+// TrashDir returns the directory holding deleted projects
+func TrashDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".pkt", "trash"), nil
+}
+
+// MoveToTrash moves a project's folder into the trash together with a snapshot of
+// its database rows, then removes it from the database
+func MoveToTrash(project *db.Project) (*TrashEntry, error) {
+	snapshot, err := db.SnapshotProject(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot project: %w", err)
+	}
+
+	entry := &TrashEntry{ID: GenerateID(), DeletedAt: time.Now(), Snapshot: snapshot}
+	if info, err := os.Stat(project.Path); err == nil && info.IsDir() {
+		entry.HasFiles = true
+	}
+
+	dir, err := entry.Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trash entry: %w", err)
+	}
+	if err := writeTrashEntry(dir, entry); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	filesDir := filepath.Join(dir, trashFilesDir)
+	if entry.HasFiles {
+		if err := MoveDir(project.Path, filesDir); err != nil {
+			// A partial copy is discarded; a complete one is kept next to what is left of the source
+			if _, statErr := os.Stat(filesDir); os.IsNotExist(statErr) {
+				_ = os.RemoveAll(dir)
+			}
+			return nil, fmt.Errorf("failed to move project to trash: %w", err)
+		}
+	}
+
+	if err := db.DeleteProject(project.ID); err != nil {
+		if entry.HasFiles {
+			_ = MoveDir(filesDir, project.Path)
+		}
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// writeTrashEntry stores the entry's metadata and snapshot in its folder
+func writeTrashEntry(dir string, entry *TrashEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode trash entry: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, trashSnapshotFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write trash entry: %w", err)
+	}
+	return nil
+}
+
+// ListTrash returns the projects in the trash, most recently deleted first. Folders
+// without a readable snapshot are skipped.
+func ListTrash() ([]*TrashEntry, error) {
+	trash, err := TrashDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := os.ReadDir(trash)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	var entries []*TrashEntry
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(trash, d.Name(), trashSnapshotFile))
+		if err != nil {
+			continue
+		}
+		entry := &TrashEntry{}
+		if err := json.Unmarshal(data, entry); err != nil || entry.Snapshot == nil || entry.Snapshot.Project == nil {
+			continue
+		}
+		entry.ID = d.Name()
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+	return entries, nil
+}
+
+// FindTrashEntry finds a project in the trash by trash ID, project ID or project name
+func FindTrashEntry(query string) (*TrashEntry, error) {
+	entries, err := ListTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	var byName []*TrashEntry
+	for _, entry := range entries {
+		if entry.ID == query || entry.Project().ID == query {
+			return entry, nil
+		}
+		if entry.Project().Name == query {
+			byName = append(byName, entry)
+		}
+	}
+
+	switch len(byName) {
+	case 0:
+		return nil, fmt.Errorf("no project '%s' in the trash (see 'pkt trash ls')", query)
+	case 1:
+		return byName[0], nil
+	}
+
+	options := make([]string, len(byName))
+	for i, entry := range byName {
+		options[i] = fmt.Sprintf("%s (deleted %s from %s)", entry.ID, entry.DeletedAt.Format("2006-01-02 15:04"), ShortPath(entry.Project().Path))
+	}
+	return nil, fmt.Errorf("%d deleted projects are named %s, use a trash ID instead:\n  %s", len(byName), query, strings.Join(options, "\n  "))
+}
+
+// RestoreFromTrash moves a project's folder back, to its original path or to path when
+// given, and restores its database rows
+func RestoreFromTrash(entry *TrashEntry, path string) (*db.Project, error) {
+	snapshot := entry.Snapshot
+	project := snapshot.Project
+
+	if path != "" && path != project.Path {
+		relocateSnapshot(snapshot, project.Path, path)
+	}
+	target := project.Path
+
+	if existing, err := db.GetProjectByID(project.ID); err == nil {
+		return nil, fmt.Errorf("project ID %s is already tracked as %s", project.ID, existing.Name)
+	}
+	if existing, err := db.GetProjectByPath(target); err == nil {
+		return nil, fmt.Errorf("%s is already tracked as %s", ShortPath(target), existing.Name)
+	}
+	if entry.HasFiles {
+		if _, err := os.Stat(target); err == nil {
+			return nil, fmt.Errorf("%s already exists", ShortPath(target))
+		}
+	}
+
+	// A member whose workspace root is gone comes back as a top-level project
+	if project.ParentID != "" {
+		if _, err := db.GetProjectByID(project.ParentID); err != nil {
+			project.ParentID = ""
+		}
+	}
+
+	dir, err := entry.Dir()
+	if err != nil {
+		return nil, err
+	}
+	filesDir := filepath.Join(dir, trashFilesDir)
+
+	if entry.HasFiles {
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := MoveDir(filesDir, target); err != nil {
+			return nil, fmt.Errorf("failed to move project out of the trash: %w", err)
+		}
+	}
+
+	if err := db.RestoreSnapshot(snapshot); err != nil {
+		if entry.HasFiles {
+			_ = MoveDir(target, filesDir)
+		}
+		return nil, err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return project, fmt.Errorf("restored, but failed to remove the trash entry: %w", err)
+	}
+	return project, nil
+}
+
+// relocateSnapshot points a snapshot and its workspace members at a new root folder
+func relocateSnapshot(snapshot *db.ProjectSnapshot, oldRoot, newRoot string) {
+	p := snapshot.Project
+	if rel, err := filepath.Rel(oldRoot, p.Path); err == nil && !strings.HasPrefix(rel, "..") {
+		p.Path = filepath.Join(newRoot, rel)
+	}
+	for _, member := range snapshot.Members {
+		relocateSnapshot(member, oldRoot, newRoot)
+	}
+}
+
+// PurgeTrashEntry permanently deletes a project from the trash, along with its
+// archive if it was archived
+func PurgeTrashEntry(entry *TrashEntry) error {
+	dir, err := entry.Dir()
+	if err != nil {
+		return err
+	}
+	if archive := entry.Project().ArchivePath; archive != "" {
+		if err := os.Remove(archive); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete %s: %w", archive, err)
+		}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", dir, err)
+	}
+	return nil
+}
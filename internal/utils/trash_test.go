@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+func TestListTrash(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	trash, err := TrashDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for i, name := range []string{"old-app", "api", "old-app"} {
+		entry := &TrashEntry{
+			ID:        GenerateID(),
+			DeletedAt: now.Add(-time.Duration(i) * time.Hour),
+			Snapshot:  &db.ProjectSnapshot{Project: &db.Project{ID: name + "-id", Name: name}},
+		}
+		dir := filepath.Join(trash, entry.ID)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeTrashEntry(dir, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Folders without a snapshot are not entries
+	_ = os.MkdirAll(filepath.Join(trash, "stray"), 0755)
+
+	entries, err := ListTrash()
+	if err != nil {
+		t.Fatalf("Failed to list trash: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Project().Name != "old-app" || entries[1].Project().Name != "api" {
+		t.Errorf("Expected most recently deleted first, got %s, %s", entries[0].Project().Name, entries[1].Project().Name)
+	}
+
+	if entry, err := FindTrashEntry("api"); err != nil || entry.ID != entries[1].ID {
+		t.Errorf("Expected api by name, got %v, %v", entry, err)
+	}
+	if entry, err := FindTrashEntry(entries[2].ID); err != nil || entry.ID != entries[2].ID {
+		t.Errorf("Expected entry by trash ID, got %v, %v", entry, err)
+	}
+	if _, err := FindTrashEntry("old-app"); err == nil {
+		t.Error("Expected an ambiguous name to fail")
+	}
+	if _, err := FindTrashEntry("missing"); err == nil {
+		t.Error("Expected an unknown name to fail")
+	}
+}
+
+func TestRelocateSnapshot(t *testing.T) {
+	snapshot := &db.ProjectSnapshot{
+		Project: &db.Project{Path: "/ws/mono"},
+		Members: []*db.ProjectSnapshot{{Project: &db.Project{Path: "/ws/mono/packages/ui"}}},
+	}
+
+	relocateSnapshot(snapshot, "/ws/mono", "/archive/mono")
+
+	if snapshot.Project.Path != "/archive/mono" {
+		t.Errorf("Expected root at /archive/mono, got %s", snapshot.Project.Path)
+	}
+	if got := snapshot.Members[0].Project.Path; got != "/archive/mono/packages/ui" {
+		t.Errorf("Expected member at /archive/mono/packages/ui, got %s", got)
+	}
+}
+
+func TestMoveDirKeepsSymlinks(t *testing.T) {
+	root := writeProjectFiles(t, map[string]string{"app/src/main.go": "package main\n"})
+	src := filepath.Join(root, "app")
+	if err := os.Symlink("src/main.go", filepath.Join(src, "link.go")); err != nil {
+		t.Skip("symlinks not supported")
+	}
+
+	dst := filepath.Join(root, "moved", "app")
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := MoveDir(src, dst); err != nil {
+		t.Fatalf("Failed to move: %v", err)
+	}
+
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Error("Expected the source to be gone")
+	}
+	if target, err := os.Readlink(filepath.Join(dst, "link.go")); err != nil || target != "src/main.go" {
+		t.Errorf("Expected the symlink to be kept, got %q, %v", target, err)
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// venvDirs are the virtual environment folders created by uv/poetry and by pip
+var venvDirs = []string{".venv", "venv"}
+
+// VenvStatus describes the virtual environment of a Python project
+type VenvStatus struct {
+	Dir     string // Path of the venv folder
+	Version string // Python version of the venv interpreter, when it runs
+	Err     error  // Why the venv is broken, nil when it is healthy
+}
+
+// CheckVenv finds the .venv or venv folder of a project and checks that its interpreter
+// still runs. It returns nil when the project has no virtual environment.
+func CheckVenv(projectDir string) *VenvStatus {
+	for _, name := range venvDirs {
+		dir := filepath.Join(projectDir, name)
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+
+		status := &VenvStatus{Dir: dir}
+		if _, err := os.Stat(filepath.Join(dir, "pyvenv.cfg")); err != nil {
+			status.Err = fmt.Errorf("pyvenv.cfg not found, not a virtual environment")
+			return status
+		}
+
+		python := venvPython(dir)
+		if _, err := os.Stat(python); err != nil {
+			// A dangling symlink is left behind when the base Python is upgraded or removed
+			status.Err = fmt.Errorf("interpreter %s is missing or points to a removed Python", ShortPath(python))
+			return status
+		}
+
+		output, err := exec.Command(python, "--version").CombinedOutput()
+		if err != nil {
+			status.Err = fmt.Errorf("interpreter %s does not run: %w", ShortPath(python), err)
+			return status
+		}
+		status.Version = strings.TrimPrefix(strings.TrimSpace(string(output)), "Python ")
+		return status
+	}
+	return nil
+}
+
+// venvPython returns the interpreter path inside a virtual environment
+func venvPython(dir string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(dir, "Scripts", "python.exe")
+	}
+	return filepath.Join(dir, "bin", "python")
+}
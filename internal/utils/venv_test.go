@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCheckVenv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("venv layout differs on Windows")
+	}
+
+	root := writeProjectFiles(t, map[string]string{
+		"healthy/requirements.txt": "flask\n",
+		"healthy/venv/pyvenv.cfg":  "home = /usr/bin\n",
+		"healthy/venv/bin/python":  "#!/bin/sh\necho 'Python 3.11.4'\n",
+		"broken/pyproject.toml":    "[project]\nname = \"broken\"\n",
+		"broken/.venv/pyvenv.cfg":  "home = /opt/python3.9/bin\n",
+		"notvenv/venv/README.md":   "just a folder",
+		"none/requirements.txt":    "flask\n",
+	})
+	if err := os.Chmod(filepath.Join(root, "healthy/venv/bin/python"), 0755); err != nil {
+		t.Fatalf("Failed to make interpreter executable: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "broken/.venv/bin"), 0755); err != nil {
+		t.Fatalf("Failed to create bin dir: %v", err)
+	}
+	if err := os.Symlink("/opt/python3.9/bin/python3.9", filepath.Join(root, "broken/.venv/bin/python")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	status := CheckVenv(filepath.Join(root, "healthy"))
+	if status == nil || status.Err != nil {
+		t.Fatalf("Expected a healthy venv, got %+v", status)
+	}
+	if status.Version != "3.11.4" || status.Dir != filepath.Join(root, "healthy", "venv") {
+		t.Errorf("Unexpected venv status: %+v", status)
+	}
+
+	if status := CheckVenv(filepath.Join(root, "broken")); status == nil || status.Err == nil {
+		t.Errorf("Expected a dangling interpreter to be reported, got %+v", status)
+	}
+	if status := CheckVenv(filepath.Join(root, "notvenv")); status == nil || status.Err == nil {
+		t.Errorf("Expected a folder without pyvenv.cfg to be reported, got %+v", status)
+	}
+	if status := CheckVenv(filepath.Join(root, "none")); status != nil {
+		t.Errorf("Expected no venv, got %+v", status)
+	}
+}
@@ -0,0 +1,392 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/genesix/pkt/internal/db"
+	"github.com/genesix/pkt/internal/lang"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceMember is a package inside a monorepo workspace
+type WorkspaceMember struct {
+	Name     string
+	Path     string
+	Language string
+}
+
+// workspaceSkipDirs are never searched for workspace members
+var workspaceSkipDirs = map[string]bool{
+	"node_modules": true,
+	"target":       true,
+	"vendor":       true,
+	"venv":         true,
+	"dist":         true,
+	"build":        true,
+}
+
+// DiscoverWorkspaceMembers finds the members declared by npm/pnpm/bun workspaces,
+// Cargo [workspace] members, go.work and uv workspaces in a project root.
+// Members are returned sorted by path; the root itself is never a member.
+func DiscoverWorkspaceMembers(root string) ([]WorkspaceMember, error) {
+	discoverers := []func(string) ([]WorkspaceMember, error){
+		discoverJSWorkspace,
+		discoverCargoWorkspace,
+		discoverGoWork,
+		discoverUVWorkspace,
+	}
+
+	seen := make(map[string]bool)
+	var members []WorkspaceMember
+	for _, discover := range discoverers {
+		found, err := discover(root)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range found {
+			if member.Path == filepath.Clean(root) || seen[member.Path] {
+				continue
+			}
+			seen[member.Path] = true
+			members = append(members, member)
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].Path < members[j].Path })
+	return members, nil
+}
+
+// discoverJSWorkspace reads the workspaces field of package.json and pnpm-workspace.yaml
+func discoverJSWorkspace(root string) ([]WorkspaceMember, error) {
+	var patterns []string
+
+	pkg, err := ReadPackageJSON(root)
+	if err == nil {
+		patterns = append(patterns, pkg.Workspaces...)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	if err == nil {
+		var pnpmWorkspace struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal(data, &pnpmWorkspace); err != nil {
+			return nil, fmt.Errorf("failed to parse pnpm-workspace.yaml: %w", err)
+		}
+		patterns = append(patterns, pnpmWorkspace.Packages...)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	// Exclusions are written inline as "!pattern"
+	var include, exclude []string
+	for _, pattern := range patterns {
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			exclude = append(exclude, negated)
+		} else {
+			include = append(include, pattern)
+		}
+	}
+
+	dirs, err := matchWorkspaceDirs(root, "package.json", include, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []WorkspaceMember
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if member, err := ReadPackageJSON(dir); err == nil && member.Name != "" {
+			name = member.Name
+		}
+		members = append(members, WorkspaceMember{Name: name, Path: dir, Language: "javascript"})
+	}
+	return members, nil
+}
+
+// discoverCargoWorkspace reads [workspace] members and exclude from Cargo.toml
+func discoverCargoWorkspace(root string) ([]WorkspaceMember, error) {
+	cargoPath := filepath.Join(root, "Cargo.toml")
+	if _, err := os.Stat(cargoPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	manifest, err := readCargoManifest(cargoPath)
+	if err != nil || manifest.Workspace == nil {
+		return nil, err
+	}
+
+	dirs, err := matchWorkspaceDirs(root, "Cargo.toml", manifest.Workspace.Members, manifest.Workspace.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []WorkspaceMember
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if member, err := readCargoManifest(filepath.Join(dir, "Cargo.toml")); err == nil && member.Package.Name != "" {
+			name = member.Package.Name
+		}
+		members = append(members, WorkspaceMember{Name: name, Path: dir, Language: "rust"})
+	}
+	return members, nil
+}
+
+// discoverGoWork reads the use directives of go.work
+func discoverGoWork(root string) ([]WorkspaceMember, error) {
+	workPath := filepath.Join(root, "go.work")
+	data, err := os.ReadFile(workPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	work, err := modfile.ParseWork(workPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	var members []WorkspaceMember
+	for _, use := range work.Use {
+		dir := filepath.Join(root, filepath.FromSlash(use.Path))
+		if filepath.IsAbs(use.Path) {
+			dir = filepath.Clean(use.Path)
+		}
+
+		file, err := readGoMod(dir)
+		if err != nil || file == nil {
+			continue
+		}
+
+		name := filepath.Base(dir)
+		if file.Module != nil {
+			name = path.Base(file.Module.Mod.Path)
+		}
+		members = append(members, WorkspaceMember{Name: name, Path: dir, Language: "go"})
+	}
+	return members, nil
+}
+
+// discoverUVWorkspace reads [tool.uv.workspace] members and exclude from pyproject.toml
+func discoverUVWorkspace(root string) ([]WorkspaceMember, error) {
+	var manifest struct {
+		Tool struct {
+			UV struct {
+				Workspace *struct {
+					Members []string `toml:"members"`
+					Exclude []string `toml:"exclude"`
+				} `toml:"workspace"`
+			} `toml:"uv"`
+		} `toml:"tool"`
+	}
+
+	pyprojectPath := filepath.Join(root, "pyproject.toml")
+	if _, err := os.Stat(pyprojectPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	if _, err := toml.DecodeFile(pyprojectPath, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse pyproject.toml: %w", err)
+	}
+
+	workspace := manifest.Tool.UV.Workspace
+	if workspace == nil {
+		return nil, nil
+	}
+
+	dirs, err := matchWorkspaceDirs(root, "pyproject.toml", workspace.Members, workspace.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []WorkspaceMember
+	for _, dir := range dirs {
+		var member struct {
+			Project struct {
+				Name string `toml:"name"`
+			} `toml:"project"`
+		}
+		name := filepath.Base(dir)
+		if _, err := toml.DecodeFile(filepath.Join(dir, "pyproject.toml"), &member); err == nil && member.Project.Name != "" {
+			name = member.Project.Name
+		}
+		members = append(members, WorkspaceMember{Name: name, Path: dir, Language: "python"})
+	}
+	return members, nil
+}
+
+// matchWorkspaceDirs returns the directories under root containing manifest whose
+// relative path matches an include glob and no exclude glob. Globs use "/" and
+// support "**" for any number of directories.
+func matchWorkspaceDirs(root, manifest string, include, exclude []string) ([]string, error) {
+	if len(include) == 0 {
+		return nil, nil
+	}
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != root && (workspaceSkipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+			return filepath.SkipDir
+		}
+		if p == root {
+			return nil
+		}
+
+		if _, err := os.Stat(filepath.Join(p, manifest)); err != nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchAnyGlob(include, rel) && !matchAnyGlob(exclude, rel) {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search workspace members: %w", err)
+	}
+
+	return dirs, nil
+}
+
+// matchAnyGlob reports whether rel matches one of the workspace globs
+func matchAnyGlob(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "./"), "/")
+		if matchGlobSegments(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlobSegments matches path segments against glob segments, where "**"
+// matches zero or more segments
+func matchGlobSegments(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchGlobSegments(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(parts) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern[0], parts[0])
+	return ok && matchGlobSegments(pattern[1:], parts[1:])
+}
+
+// SyncWorkspaceMembers discovers the members of a workspace root, tracks each as a
+// sub-project linked to the root and syncs its dependencies. Projects that belong to
+// another workspace root are left alone. Members that are no longer part of the
+// workspace become top-level projects again, or go to the trash when the workspace
+// brought them in and their folder is gone.
+func SyncWorkspaceMembers(root *db.Project) ([]*db.Project, error) {
+	discovered, err := DiscoverWorkspaceMembers(root.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]bool)
+	for _, member := range discovered {
+		current[member.Path] = true
+
+		project, err := db.GetProjectByPath(member.Path)
+		created := err != nil
+		if created {
+			// Members share the root's package manager when they use the same language
+			packageManager := root.PackageManager
+			if member.Language != root.Language {
+				memberLang, err := lang.Get(member.Language)
+				if err != nil {
+					return nil, err
+				}
+				packageManager = memberLang.DetectPackageManager(member.Path)
+			}
+
+			project, err = db.CreateProject(GenerateID(), member.Name, member.Path, member.Language, packageManager)
+			if err != nil {
+				return nil, fmt.Errorf("failed to track workspace member %s: %w", member.Name, err)
+			}
+		}
+
+		// A project that already belongs to another workspace root stays there
+		if !created && project.ParentID != "" && project.ParentID != root.ID {
+			continue
+		}
+
+		if project.ParentID != root.ID {
+			// A project tracked on its own is adopted, remembering that it came first
+			link := db.AdoptProject
+			if created {
+				link = db.SetProjectParent
+			}
+			if err := link(project.ID, root.ID); err != nil {
+				return nil, fmt.Errorf("failed to link workspace member %s: %w", member.Name, err)
+			}
+		}
+
+		if _, err := SyncProjectDependencies(project.ID, project.Path, project.Language); err != nil {
+			return nil, fmt.Errorf("%s: %w", member.Name, err)
+		}
+	}
+
+	tracked, err := db.GetWorkspaceMembers(root.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*db.Project
+	for _, project := range tracked {
+		if current[project.Path] {
+			members = append(members, project)
+			continue
+		}
+		// Members the workspace brought in whose folder is gone go to the trash, where
+		// 'pkt restore' finds them; every other member is detached and stays tracked
+		if _, err := os.Stat(project.Path); os.IsNotExist(err) && !project.Adopted {
+			if _, err := MoveToTrash(project); err != nil {
+				return nil, fmt.Errorf("failed to move workspace member %s to the trash: %w", project.Name, err)
+			}
+			continue
+		}
+		if err := db.SetProjectParent(project.ID, ""); err != nil {
+			return nil, fmt.Errorf("failed to detach workspace member %s: %w", project.Name, err)
+		}
+	}
+
+	return members, nil
+}
@@ -0,0 +1,267 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/genesix/pkt/internal/db"
+)
+
+// memberNames maps member paths relative to root to member names and languages
+func memberNames(t *testing.T, root string, members []WorkspaceMember) map[string]string {
+	t.Helper()
+
+	names := make(map[string]string)
+	for _, member := range members {
+		rel, err := filepath.Rel(root, member.Path)
+		if err != nil {
+			t.Fatalf("Member outside root: %s", member.Path)
+		}
+		names[filepath.ToSlash(rel)] = member.Name + "/" + member.Language
+	}
+	return names
+}
+
+func TestDiscoverWorkspaceMembers(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  map[string]string
+	}{
+		{
+			name: "npm workspaces",
+			files: map[string]string{
+				"package.json":                           `{"name": "root", "workspaces": ["packages/*"]}`,
+				"packages/a/package.json":                `{"name": "@acme/a"}`,
+				"packages/b/package.json":                `{}`,
+				"packages/notes/README.md":               "no manifest",
+				"node_modules/x/package.json":            `{"name": "x"}`,
+				"packages/a/node_modules/y/package.json": `{"name": "y"}`,
+			},
+			want: map[string]string{
+				"packages/a": "@acme/a/javascript",
+				"packages/b": "b/javascript",
+			},
+		},
+		{
+			name: "pnpm workspace with globstar and exclusion",
+			files: map[string]string{
+				"package.json":                 `{"name": "root"}`,
+				"pnpm-workspace.yaml":          "packages:\n  - 'apps/*'\n  - 'libs/**'\n  - '!libs/legacy'\n",
+				"apps/web/package.json":        `{"name": "web"}`,
+				"libs/ui/package.json":         `{"name": "ui"}`,
+				"libs/core/utils/package.json": `{"name": "utils"}`,
+				"libs/legacy/package.json":     `{"name": "legacy"}`,
+				"tools/script/package.json":    `{"name": "script"}`,
+			},
+			want: map[string]string{
+				"apps/web":        "web/javascript",
+				"libs/ui":         "ui/javascript",
+				"libs/core/utils": "utils/javascript",
+			},
+		},
+		{
+			name: "cargo workspace",
+			files: map[string]string{
+				"Cargo.toml":                "[workspace]\nmembers = [\"crates/*\"]\nexclude = [\"crates/scratch\"]\n",
+				"crates/cli/Cargo.toml":     "[package]\nname = \"acme-cli\"\n",
+				"crates/core/Cargo.toml":    "[package]\nname = \"acme-core\"\n",
+				"crates/scratch/Cargo.toml": "[package]\nname = \"scratch\"\n",
+			},
+			want: map[string]string{
+				"crates/cli":  "acme-cli/rust",
+				"crates/core": "acme-core/rust",
+			},
+		},
+		{
+			name: "go.work",
+			files: map[string]string{
+				"go.work":          "go 1.24\n\nuse (\n\t.\n\t./api\n\t./tools/gen\n\t./missing\n)\n",
+				"go.mod":           "module example.com/root\n\ngo 1.24\n",
+				"api/go.mod":       "module example.com/mono/api\n\ngo 1.24\n",
+				"tools/gen/go.mod": "module example.com/mono/gen\n\ngo 1.24\n",
+			},
+			want: map[string]string{
+				"api":       "api/go",
+				"tools/gen": "gen/go",
+			},
+		},
+		{
+			name: "uv workspace",
+			files: map[string]string{
+				"pyproject.toml":              "[project]\nname = \"root\"\n\n[tool.uv.workspace]\nmembers = [\"packages/*\"]\nexclude = [\"packages/old\"]\n",
+				"packages/svc/pyproject.toml": "[project]\nname = \"svc\"\n",
+				"packages/old/pyproject.toml": "[project]\nname = \"old\"\n",
+			},
+			want: map[string]string{
+				"packages/svc": "svc/python",
+			},
+		},
+		{
+			name: "not a workspace",
+			files: map[string]string{
+				"package.json":     `{"name": "app"}`,
+				"sub/package.json": `{"name": "sub"}`,
+				"Cargo.toml":       "[package]\nname = \"app\"\n",
+			},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeProjectFiles(t, tt.files)
+
+			members, err := DiscoverWorkspaceMembers(root)
+			if err != nil {
+				t.Fatalf("Failed to discover members: %v", err)
+			}
+
+			got := memberNames(t, root, members)
+			if len(got) != len(tt.want) {
+				t.Errorf("Expected %d members, got %v", len(tt.want), got)
+			}
+			for rel, want := range tt.want {
+				if got[rel] != want {
+					t.Errorf("%s: expected %s, got %q", rel, want, got[rel])
+				}
+			}
+		})
+	}
+}
+
+func TestMatchAnyGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		rel     string
+		want    bool
+	}{
+		{"packages/*", "packages/a", true},
+		{"./packages/*", "packages/a", true},
+		{"packages/*/", "packages/a", true},
+		{"packages/*", "packages/a/b", false},
+		{"packages/**", "packages/a/b", true},
+		{"**/svc", "deep/nested/svc", true},
+		{"apps/web", "apps/web", true},
+		{"apps/we?", "apps/web", true},
+		{"apps/*", "libs/web", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchAnyGlob([]string{tt.pattern}, tt.rel); got != tt.want {
+			t.Errorf("matchAnyGlob(%q, %q) = %v, expected %v", tt.pattern, tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestSyncWorkspaceMembersKeepsLeavingProjects(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := db.Connect(); err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json":            `{"name": "mono", "workspaces": ["packages/*"]}`,
+		"packages/a/package.json": `{"name": "a"}`,
+		"packages/b/package.json": `{"name": "b"}`,
+		"packages/c/package.json": `{"name": "c"}`,
+	})
+	root, err := db.CreateProject("ROOT001", "mono", dir, "javascript", "npm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// b was tracked on its own before the workspace root
+	if _, err := db.CreateProject("SOLO001", "b", filepath.Join(dir, "packages", "b"), "javascript", "npm"); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.AddTags("SOLO001", "keep")
+
+	members, err := SyncWorkspaceMembers(root)
+	if err != nil {
+		t.Fatalf("Failed to sync members: %v", err)
+	}
+	if len(members) != 3 {
+		t.Fatalf("Expected 3 members, got %d", len(members))
+	}
+	byName := make(map[string]*db.Project)
+	for _, member := range members {
+		byName[member.Name] = member
+	}
+	if !byName["b"].Adopted {
+		t.Error("Expected the pre-registered project to be adopted")
+	}
+	_ = db.AddTags(byName["a"].ID, "web")
+
+	// All three leave the workspace, and c is deleted from disk
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "mono", "workspaces": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, "packages", "c")); err != nil {
+		t.Fatal(err)
+	}
+	if members, err = SyncWorkspaceMembers(root); err != nil {
+		t.Fatalf("Failed to resync members: %v", err)
+	}
+	if len(members) != 0 {
+		t.Errorf("Expected no members after leaving the workspace, got %d", len(members))
+	}
+
+	for _, id := range []string{"SOLO001", byName["a"].ID} {
+		project, err := db.GetProjectByID(id)
+		if err != nil {
+			t.Fatalf("Expected %s to stay tracked: %v", id, err)
+		}
+		if project.IsMember() || project.Adopted {
+			t.Errorf("Expected %s to be top-level again, got %+v", project.Name, project)
+		}
+		if tags, _ := db.GetProjectTags(id); len(tags) != 1 {
+			t.Errorf("Expected %s to keep its tags, got %v", project.Name, tags)
+		}
+	}
+
+	if _, err := db.GetProjectByID(byName["c"].ID); err == nil {
+		t.Error("Expected the member whose folder is gone to stop being tracked")
+	}
+	if _, err := FindTrashEntry(byName["c"].ID); err != nil {
+		t.Errorf("Expected the member whose folder is gone to be in the trash: %v", err)
+	}
+}
+
+func TestSyncWorkspaceMembersLeavesOtherRoots(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := db.Connect(); err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json":            `{"name": "mono", "workspaces": ["packages/*"]}`,
+		"packages/a/package.json": `{"name": "a"}`,
+	})
+	root, err := db.CreateProject("ROOT002", "mono", dir, "javascript", "npm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.CreateProject("ROOT003", "other", t.TempDir(), "javascript", "npm"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.CreateProject("MEMB001", "a", filepath.Join(dir, "packages", "a"), "javascript", "npm"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetProjectParent("MEMB001", "ROOT003"); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := SyncWorkspaceMembers(root)
+	if err != nil {
+		t.Fatalf("Failed to sync members: %v", err)
+	}
+	if len(members) != 0 {
+		t.Errorf("Expected no members, got %d", len(members))
+	}
+	if member, _ := db.GetProjectByID("MEMB001"); member == nil || member.ParentID != "ROOT003" {
+		t.Errorf("Expected the project to stay a member of its own root, got %+v", member)
+	}
+}